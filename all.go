@@ -0,0 +1,80 @@
+package discogs
+
+import (
+	"context"
+)
+
+// The All functions below fetch every page of a paginated endpoint and return the combined items. The pages are
+// requested through the provided service, so a client wrapped with RateLimited paces the requests accordingly.
+// A limit greater than zero caps the number of items returned; no further pages are requested once it is reached.
+// On error the items collected so far are returned along with the error.
+
+// AllArtistReleases returns all releases associated with the artist.
+func AllArtistReleases(ctx context.Context, s DatabaseService, artistID int, pagination *Pagination, limit int, opts ...RequestOption) ([]ReleaseSource, error) {
+	var releases []ReleaseSource
+	it := ArtistReleasesIter(ctx, s, artistID, pagination, opts...)
+	for (limit <= 0 || len(releases) < limit) && it.Next() {
+		releases = append(releases, it.Item())
+	}
+	return releases, it.Err()
+}
+
+// AllLabelReleases returns all releases associated with the label.
+func AllLabelReleases(ctx context.Context, s DatabaseService, labelID int, pagination *Pagination, limit int, opts ...RequestOption) ([]ReleaseSource, error) {
+	var releases []ReleaseSource
+	it := LabelReleasesIter(ctx, s, labelID, pagination, opts...)
+	for (limit <= 0 || len(releases) < limit) && it.Next() {
+		releases = append(releases, it.Item())
+	}
+	return releases, it.Err()
+}
+
+// AllMasterVersions returns all versions of the master release.
+func AllMasterVersions(ctx context.Context, s DatabaseService, masterID int, pagination *Pagination, limit int, opts ...RequestOption) ([]Version, error) {
+	var versions []Version
+	it := MasterVersionsIter(ctx, s, masterID, pagination, opts...)
+	for (limit <= 0 || len(versions) < limit) && it.Next() {
+		versions = append(versions, it.Item())
+	}
+	return versions, it.Err()
+}
+
+// AllCollectionItemsByFolder returns all items in a folder of the user's collection.
+func AllCollectionItemsByFolder(ctx context.Context, s CollectionService, username string, folderID int, pagination *Pagination, limit int, opts ...RequestOption) ([]CollectionItemSource, error) {
+	var items []CollectionItemSource
+	it := CollectionItemsByFolderIter(ctx, s, username, folderID, pagination, opts...)
+	for (limit <= 0 || len(items) < limit) && it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// AllWantlist returns all releases in the user's wantlist.
+func AllWantlist(ctx context.Context, s WantlistService, username string, pagination *Pagination, limit int, opts ...RequestOption) ([]Want, error) {
+	var wants []Want
+	it := WantlistIter(ctx, s, username, pagination, opts...)
+	for (limit <= 0 || len(wants) < limit) && it.Next() {
+		wants = append(wants, it.Item())
+	}
+	return wants, it.Err()
+}
+
+// AllInventory returns all listings in the seller's inventory.
+func AllInventory(ctx context.Context, s MarketPlaceService, username string, pagination *Pagination, limit int, opts ...RequestOption) ([]MarketplaceListing, error) {
+	var listings []MarketplaceListing
+	it := InventoryIter(ctx, s, username, pagination, opts...)
+	for (limit <= 0 || len(listings) < limit) && it.Next() {
+		listings = append(listings, it.Item())
+	}
+	return listings, it.Err()
+}
+
+// AllSearch returns all results of the search request.
+func AllSearch(ctx context.Context, s SearchService, req SearchRequest, limit int, opts ...RequestOption) ([]Result, error) {
+	var results []Result
+	it := SearchIter(ctx, s, req, opts...)
+	for (limit <= 0 || len(results) < limit) && it.Next() {
+		results = append(results, it.Item())
+	}
+	return results, it.Err()
+}
@@ -0,0 +1,178 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Batcher fetches many database items concurrently. Items whose ID is repeated are fetched once.
+type Batcher struct {
+	d           DatabaseService
+	rl          *RateLimit
+	concurrency int
+}
+
+// NewBatcher returns a Batcher fetching up to concurrency items at a time from d. If rl is not nil, every request is
+// made through rl.Call so the workers pause together when the rate limit is reached; rl should then also be set as
+// the RateLimit of the client's Options so it's kept up to date.
+func NewBatcher(d DatabaseService, rl *RateLimit, concurrency int) *Batcher {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Batcher{
+		d:           d,
+		rl:          rl,
+		concurrency: concurrency,
+	}
+}
+
+// BatchError is returned by Batcher when some of the items couldn't be fetched. errors.Is reports whether any of the
+// errors matches, e.g. context.Canceled if the batch was canceled.
+type BatchError struct {
+	// Errors maps the IDs of the items which couldn't be fetched to the errors returned for them.
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	ids := make([]int, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("%d: %s", id, e.Errors[id])
+	}
+	return fmt.Sprintf("failed to fetch %d items: %s", len(ids), strings.Join(msgs, "; "))
+}
+
+// Is reports whether any of the errors is target.
+func (e *BatchError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the errors of the items which couldn't be fetched.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// run calls fetch for the index of the first occurrence of every ID in ids using up to b.concurrency goroutines,
+// then calls dup for the indexes of repeated IDs with the index of their first occurrence. It returns a *BatchError
+// holding the errors of the failed calls, if any.
+func (b *Batcher) run(ctx context.Context, ids []int, fetch func(ctx context.Context, i int) error, dup func(i, first int)) error {
+	var mu sync.Mutex
+	errs := make(map[int]error)
+
+	first := make(map[int]int, len(ids))
+	var unique []int
+	for i, id := range ids {
+		if _, ok := first[id]; !ok {
+			first[id] = i
+			unique = append(unique, i)
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < b.concurrency && w < len(unique); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				var err error
+				if b.rl != nil {
+					i := i
+					err = b.rl.Call(ctx, func() error {
+						return fetch(ctx, i)
+					})
+				} else {
+					err = fetch(ctx, i)
+				}
+				if err != nil {
+					mu.Lock()
+					errs[ids[i]] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, i := range unique {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, id := range ids {
+		if first[id] != i {
+			dup(i, first[id])
+		}
+	}
+
+	if len(errs) > 0 {
+		return &BatchError{Errors: errs}
+	}
+	return nil
+}
+
+// Releases returns the releases with the given IDs, in the same order. Releases which couldn't be fetched are nil and
+// a *BatchError holding their errors is returned.
+func (b *Batcher) Releases(ctx context.Context, ids []int, opts ...RequestOption) ([]*Release, error) {
+	releases := make([]*Release, len(ids))
+	err := b.run(ctx, ids, func(ctx context.Context, i int) error {
+		var err error
+		releases[i], err = b.d.Release(ctx, ids[i], opts...)
+		return err
+	}, func(i, first int) { releases[i] = releases[first] })
+	return releases, err
+}
+
+// Masters returns the master releases with the given IDs, in the same order. Masters which couldn't be fetched are
+// nil and a *BatchError holding their errors is returned.
+func (b *Batcher) Masters(ctx context.Context, ids []int, opts ...RequestOption) ([]*Master, error) {
+	masters := make([]*Master, len(ids))
+	err := b.run(ctx, ids, func(ctx context.Context, i int) error {
+		var err error
+		masters[i], err = b.d.Master(ctx, ids[i], opts...)
+		return err
+	}, func(i, first int) { masters[i] = masters[first] })
+	return masters, err
+}
+
+// Artists returns the artists with the given IDs, in the same order. Artists which couldn't be fetched are nil and a
+// *BatchError holding their errors is returned.
+func (b *Batcher) Artists(ctx context.Context, ids []int, opts ...RequestOption) ([]*Artist, error) {
+	artists := make([]*Artist, len(ids))
+	err := b.run(ctx, ids, func(ctx context.Context, i int) error {
+		var err error
+		artists[i], err = b.d.Artist(ctx, ids[i], opts...)
+		return err
+	}, func(i, first int) { artists[i] = artists[first] })
+	return artists, err
+}
+
+// Labels returns the labels with the given IDs, in the same order. Labels which couldn't be fetched are nil and a
+// *BatchError holding their errors is returned.
+func (b *Batcher) Labels(ctx context.Context, ids []int, opts ...RequestOption) ([]*Label, error) {
+	labels := make([]*Label, len(ids))
+	err := b.run(ctx, ids, func(ctx context.Context, i int) error {
+		var err error
+		labels[i], err = b.d.Label(ctx, ids[i], opts...)
+		return err
+	}, func(i, first int) { labels[i] = labels[first] })
+	return labels, err
+}
@@ -0,0 +1,64 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatcherReleases(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("X-Discogs-Ratelimit", "60")
+		w.Header().Set("X-Discogs-Ratelimit-Used", "10")
+		w.Header().Set("X-Discogs-Ratelimit-Remaining", "50")
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	rl := &RateLimit{}
+	d := initDiscogsClient(t, &Options{URL: ts.URL, RateLimit: rl})
+	b := NewBatcher(d, rl, 3)
+
+	ids := []int{8138518, 1, 8138518, 8138518}
+	releases, err := b.Releases(context.Background(), ids)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err got=%v; want a *BatchError", err)
+	}
+	if len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[1], ErrNotFound) {
+		t.Errorf("errors got=%v; want release 1 not found", batchErr.Errors)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("requests got=%d; want=2", n)
+	}
+
+	for i, release := range releases {
+		if ids[i] == 1 {
+			if release != nil {
+				t.Errorf("release %d got=%v; want nil", i, release)
+			}
+		} else if release == nil || release.ID != ids[i] {
+			t.Errorf("release %d got=%v; want %d", i, release, ids[i])
+		}
+	}
+}
+
+func TestBatcherCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	b := NewBatcher(initDiscogsClient(t, &Options{URL: ts.URL}), nil, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := b.Artists(ctx, []int{38661, 38661, 1}); !errors.Is(err, context.Canceled) {
+		t.Errorf("err got=%v; want=%s", err, context.Canceled)
+	}
+}
@@ -0,0 +1,176 @@
+package discogs
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileCacheExt is the extension of the files holding the values of a FileCache.
+const fileCacheExt = ".cache"
+
+// FileCache is a Cache storing values as files in a directory, so they persist across process runs. When the files
+// grow beyond the maximum size, the least recently used values are evicted. Since the Cache interface can't report
+// errors, values that can't be read are treated as missing and values that can't be written aren't cached.
+//
+// A FileCache is safe for concurrent use. Several processes may share the directory, but the size limit is only
+// enforced by each of them as they store values.
+type FileCache struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize int64
+	size    int64
+}
+
+// NewFileCache returns a FileCache storing values in dir, which is created if it doesn't exist. A maxSize of zero or
+// less means the size of the cache isn't limited.
+func NewFileCache(dir string, maxSize int64) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &FileCache{dir: dir, maxSize: maxSize}
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		c.size += f.Size()
+	}
+	return c, nil
+}
+
+// path returns the path of the file holding the value of key.
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileCacheExt)
+}
+
+// Get returns the value stored for key and whether it was found and hasn't expired.
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.path(key)
+	b, err := os.ReadFile(path)
+	if err != nil || len(b) < 8 {
+		return nil, false
+	}
+
+	// the file holds the expiry time in Unix nanoseconds, zero if the value doesn't expire, followed by the value
+	now := time.Now()
+	if expires := int64(binary.BigEndian.Uint64(b)); expires != 0 && now.UnixNano() > expires {
+		c.remove(path)
+		return nil, false
+	}
+
+	// the modification time tracks the last use for eviction
+	_ = os.Chtimes(path, now, now)
+	return b[8:], true
+}
+
+// Set stores value for key, evicting the least recently used values if the cache grows beyond its maximum size.
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+	b := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(b, uint64(expires))
+	copy(b[8:], value)
+
+	// write to a temporary file first so readers never see a partially written value
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	path := c.path(key)
+	if fi, err := os.Stat(path); err == nil {
+		c.size -= fi.Size()
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	c.size += int64(len(b))
+
+	if c.maxSize > 0 && c.size > c.maxSize {
+		c.evict()
+	}
+}
+
+// evict removes the least recently used values until the cache fits its maximum size.
+func (c *FileCache) evict() {
+	files, err := c.files()
+	if err != nil {
+		return
+	}
+
+	// other processes may have changed the directory, so start from its actual size
+	c.size = 0
+	for _, f := range files {
+		c.size += f.Size()
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime().Before(files[j].ModTime()) })
+	for _, f := range files {
+		if c.size <= c.maxSize {
+			break
+		}
+		c.remove(filepath.Join(c.dir, f.Name()))
+	}
+}
+
+// remove deletes the file of a value and accounts for its size.
+func (c *FileCache) remove(path string) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if err := os.Remove(path); err == nil {
+		c.size -= fi.Size()
+	}
+}
+
+// files returns the files holding the values of the cache.
+func (c *FileCache) files() ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []os.FileInfo
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), fileCacheExt) {
+			continue
+		}
+		if fi, err := e.Info(); err == nil {
+			files = append(files, fi)
+		}
+	}
+	return files, nil
+}
+
+// Size returns the total size in bytes of the values in the cache, including any which have expired but haven't
+// been evicted yet.
+func (c *FileCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
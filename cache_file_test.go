@@ -0,0 +1,72 @@
+package discogs
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	// every value takes 9 bytes, so the cache holds two of them
+	c, err := NewFileCache(dir, 18)
+	if err != nil {
+		t.Fatalf("failed to create cache: %s", err)
+	}
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+
+	// reading a makes b the least recently used value
+	past := time.Now().Add(-time.Hour)
+	for _, key := range []string{"a", "b"} {
+		if err := os.Chtimes(c.path(key), past, past); err != nil {
+			t.Fatalf("failed to set modification time: %s", err)
+		}
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Fatalf("a got=%q, %t; want=%q, true", v, ok, "1")
+	}
+
+	c.Set("c", []byte("3"), 0)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if c.Size() != 18 {
+		t.Errorf("size got=%d; want=18", c.Size())
+	}
+
+	// the values persist across instances
+	c, err = NewFileCache(dir, 18)
+	if err != nil {
+		t.Fatalf("failed to reopen cache: %s", err)
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("a got=%q, %t; want=%q, true", v, ok, "1")
+	}
+	if v, ok := c.Get("c"); !ok || string(v) != "3" {
+		t.Errorf("c got=%q, %t; want=%q, true", v, ok, "3")
+	}
+	if c.Size() != 18 {
+		t.Errorf("size got=%d; want=18", c.Size())
+	}
+}
+
+func TestFileCacheExpiry(t *testing.T) {
+	c, err := NewFileCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("failed to create cache: %s", err)
+	}
+	c.Set("a", []byte("1"), time.Nanosecond)
+	c.Set("b", []byte("2"), time.Hour)
+	time.Sleep(time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("expected a to expire")
+	}
+	if v, ok := c.Get("b"); !ok || string(v) != "2" {
+		t.Errorf("b got=%q, %t; want=%q, true", v, ok, "2")
+	}
+	if c.Size() != 9 {
+		t.Errorf("size got=%d; want=9", c.Size())
+	}
+}
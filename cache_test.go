@@ -0,0 +1,102 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+
+	// reading a makes b the least recently used value
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Fatalf("a got=%q, %t; want=%q, true", v, ok, "1")
+	}
+
+	c.Set("c", []byte("3"), 0)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("a got=%q, %t; want=%q, true", v, ok, "1")
+	}
+	if v, ok := c.Get("c"); !ok || string(v) != "3" {
+		t.Errorf("c got=%q, %t; want=%q, true", v, ok, "3")
+	}
+	if c.Len() != 2 {
+		t.Errorf("len got=%d; want=2", c.Len())
+	}
+}
+
+func TestLRUCacheExpiry(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("expected a to expire")
+	}
+	if c.Len() != 0 {
+		t.Errorf("len got=%d; want=0", c.Len())
+	}
+}
+
+func TestCached(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	d := Cached(initDiscogsClient(t, &Options{URL: ts.URL}), NewLRUCache(10), time.Minute)
+
+	for i := 0; i < 3; i++ {
+		release, err := d.Release(context.Background(), 8138518)
+		if err != nil {
+			t.Fatalf("failed to get release: %s", err)
+		}
+
+		json, err := json.Marshal(release)
+		if err != nil {
+			t.Fatalf("failed to marshal release: %s", err)
+		}
+		compareJson(t, string(json), releaseJson)
+	}
+
+	if requests != 1 {
+		t.Errorf("requests got=%d; want=1", requests)
+	}
+}
+
+func TestCachedBulk(t *testing.T) {
+	var perPage []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = append(perPage, r.URL.Query().Get("per_page"))
+		if _, err := io.WriteString(w, `{"pagination": {"page": 2, "pages": 3}, "releases": []}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := Cached(initDiscogsClient(t, &Options{URL: ts.URL}), NewLRUCache(10), time.Minute)
+	ctx := context.Background()
+
+	calls := [][]RequestOption{nil, {WithBulk()}, nil, {WithBulk()}}
+	for _, opts := range calls {
+		if _, err := d.ArtistReleases(ctx, 1, &Pagination{Page: 2}, opts...); err != nil {
+			t.Fatalf("failed to get artist releases: %s", err)
+		}
+	}
+
+	if len(perPage) != 2 || perPage[0] != "50" || perPage[1] != "100" {
+		t.Errorf("requested items per page got=%v; want=[50 100]", perPage)
+	}
+}
@@ -0,0 +1,58 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Cached returns d with the database functions replaced with versions that serve responses from cache when
+// possible, storing new responses for ttl. Catalog data such as artists, releases and masters rarely changes, so
+// caching it considerably reduces the number of requests made. Other services are passed through to d unchanged.
+// Clients derived with WithToken or WithOAuth share the cache.
+func Cached(d Discogs, cache Cache, ttl time.Duration) Discogs {
+	return Intercept(d, ForServices(func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		if call.Result == nil {
+			return invoke(ctx)
+		}
+		return cached(cache, ttl, call.key(), call.Result, func() error { return invoke(ctx) })
+	}, ServiceDatabase))
+}
+
+// cached decodes the value cached for key into v or, if there is none, invokes f() to populate v and caches the
+// result.
+func cached(cache Cache, ttl time.Duration, key string, v interface{}, f func() error) error {
+	if b, ok := cache.Get(key); ok {
+		if err := json.Unmarshal(b, v); err == nil {
+			return nil
+		}
+	}
+
+	if err := f(); err != nil {
+		return err
+	}
+
+	if b, err := json.Marshal(v); err == nil {
+		cache.Set(key, b, ttl)
+	}
+	return nil
+}
+
+// paginationKey returns the part of a cache key identifying the requested page, including the number of items per page
+// requested in bulk mode by opts.
+func paginationKey(pagination *Pagination, opts []RequestOption) string {
+	params := pagination.params()
+	if newRequestOptions(opts).bulk {
+		params.Set("per_page", strconv.Itoa(maxPerPage))
+	}
+	return "?" + params.Encode()
+}
+
+// currencyKey returns the part of a cache key identifying the currency requested by opts.
+func currencyKey(opts []RequestOption) string {
+	if c := newRequestOptions(opts).currency; c != "" {
+		return "#" + c
+	}
+	return ""
+}
@@ -0,0 +1,189 @@
+// Package catalog answers database lookups from a local index of the Discogs data dumps, falling back to the API for
+// entities missing from the index. Heavy users can index the monthly dumps once and serve most lookups without
+// spending their rate limit.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/irlndts/go-discogs"
+	"github.com/irlndts/go-discogs/dumps"
+)
+
+// Store is a persistent key-value store holding the index, e.g. backed by bbolt or badger.
+type Store interface {
+	// Get returns the value stored for key and whether there is one.
+	Get(key string) ([]byte, bool, error)
+	// Put stores value for key.
+	Put(key string, value []byte) error
+}
+
+// MemoryStore is a Store keeping the index in memory.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: map[string][]byte{}}
+}
+
+// Get returns the value stored for key and whether there is one.
+func (s *MemoryStore) Get(key string) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.values[key]
+	return v, ok, nil
+}
+
+// Put stores value for key.
+func (s *MemoryStore) Put(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = value
+	return nil
+}
+
+// Kinds of the entities in the index, used as key prefixes.
+const (
+	kindRelease = "release"
+	kindArtist  = "artist"
+	kindLabel   = "label"
+	kindMaster  = "master"
+)
+
+func key(kind string, id int) string {
+	return kind + "/" + strconv.Itoa(id)
+}
+
+func put(s Store, kind string, id int, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.Put(key(kind, id), b)
+}
+
+// IndexReleases stores the releases of a releases dump in s and returns their number.
+func IndexReleases(s Store, r io.Reader) (int, error) {
+	n := 0
+	err := dumps.Releases(r, func(release *discogs.Release) error {
+		n++
+		return put(s, kindRelease, release.ID, release)
+	})
+	return n, err
+}
+
+// IndexArtists stores the artists of an artists dump in s and returns their number.
+func IndexArtists(s Store, r io.Reader) (int, error) {
+	n := 0
+	err := dumps.Artists(r, func(artist *discogs.Artist) error {
+		n++
+		return put(s, kindArtist, artist.ID, artist)
+	})
+	return n, err
+}
+
+// IndexLabels stores the labels of a labels dump in s and returns their number.
+func IndexLabels(s Store, r io.Reader) (int, error) {
+	n := 0
+	err := dumps.Labels(r, func(label *discogs.Label) error {
+		n++
+		return put(s, kindLabel, label.ID, label)
+	})
+	return n, err
+}
+
+// IndexMasters stores the master releases of a masters dump in s and returns their number.
+func IndexMasters(s Store, r io.Reader) (int, error) {
+	n := 0
+	err := dumps.Masters(r, func(master *discogs.Master) error {
+		n++
+		return put(s, kindMaster, master.ID, master)
+	})
+	return n, err
+}
+
+// Catalog implements discogs.DatabaseService, answering Release, Artist, Label and Master lookups from the index and
+// passing all other requests, as well as lookups of entities missing from the index, to the wrapped service.
+// Entities fetched from the API are added to the index. Releases from the dumps carry no marketplace or community
+// data; request them from the API directly if those are needed.
+type Catalog struct {
+	discogs.DatabaseService
+	store Store
+}
+
+// New returns a Catalog serving lookups from store and falling back to d.
+func New(d discogs.DatabaseService, store Store) *Catalog {
+	return &Catalog{DatabaseService: d, store: store}
+}
+
+// lookup decodes the entity stored for kind and id into v or, if there is none, invokes f() to populate v and stores
+// the result.
+func (c *Catalog) lookup(kind string, id int, v interface{}, f func() error) error {
+	b, ok, err := c.store.Get(key(kind, id))
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := json.Unmarshal(b, v); err == nil {
+			return nil
+		}
+	}
+
+	if err := f(); err != nil {
+		return err
+	}
+	return put(c.store, kind, id, v)
+}
+
+// Release returns the release from the index or, if it's missing, from the API. Like DatabaseService.Release it
+// returns a *discogs.ReleaseStatusError for indexed releases that weren't accepted.
+func (c *Catalog) Release(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (v *discogs.Release, e error) {
+	e = c.lookup(kindRelease, releaseID, &v, func() error {
+		var err error
+		v, err = c.DatabaseService.Release(ctx, releaseID, opts...)
+		return err
+	})
+	if e == nil && v != nil && !v.Status.Accepted() {
+		return nil, &discogs.ReleaseStatusError{ReleaseID: releaseID, Status: v.Status, Release: v}
+	}
+	return
+}
+
+// Artist returns the artist from the index or, if it's missing, from the API.
+func (c *Catalog) Artist(ctx context.Context, artistID int, opts ...discogs.RequestOption) (v *discogs.Artist, e error) {
+	e = c.lookup(kindArtist, artistID, &v, func() error {
+		var err error
+		v, err = c.DatabaseService.Artist(ctx, artistID, opts...)
+		return err
+	})
+	return
+}
+
+// Label returns the label from the index or, if it's missing, from the API.
+func (c *Catalog) Label(ctx context.Context, labelID int, opts ...discogs.RequestOption) (v *discogs.Label, e error) {
+	e = c.lookup(kindLabel, labelID, &v, func() error {
+		var err error
+		v, err = c.DatabaseService.Label(ctx, labelID, opts...)
+		return err
+	})
+	return
+}
+
+// Master returns the master release from the index or, if it's missing, from the API.
+func (c *Catalog) Master(ctx context.Context, masterID int, opts ...discogs.RequestOption) (v *discogs.Master, e error) {
+	e = c.lookup(kindMaster, masterID, &v, func() error {
+		var err error
+		v, err = c.DatabaseService.Master(ctx, masterID, opts...)
+		return err
+	})
+	return
+}
@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/irlndts/go-discogs"
+	"github.com/irlndts/go-discogs/discogstest"
+)
+
+func TestCatalog(t *testing.T) {
+	store := NewMemoryStore()
+	f, err := os.Open("../dumps/testdata/releases.xml")
+	if err != nil {
+		t.Fatalf("failed to open dump: %s", err)
+	}
+	defer f.Close()
+	if n, err := IndexReleases(store, f); err != nil || n != 2 {
+		t.Fatalf("indexed releases got=%d, %v; want=2", n, err)
+	}
+
+	requests := 0
+	m := &discogstest.MockDiscogs{
+		ReleaseFunc: func(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.Release, error) {
+			requests++
+			if releaseID == 3 {
+				return &discogs.Release{ID: 3, Title: "From API"}, nil
+			}
+			return nil, discogs.ErrNotFound
+		},
+	}
+	c := New(m, store)
+	ctx := context.Background()
+
+	r, err := c.Release(ctx, 1)
+	if err != nil || r.Title != "Stockholm" {
+		t.Fatalf("release got=%+v, %v; want=Stockholm", r, err)
+	}
+	if requests != 0 {
+		t.Errorf("requests got=%d; want=0", requests)
+	}
+
+	for i := 0; i < 2; i++ {
+		r, err = c.Release(ctx, 3)
+		if err != nil || r.Title != "From API" {
+			t.Fatalf("release got=%+v, %v; want=From API", r, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests got=%d; want=1", requests)
+	}
+
+	if _, err := c.Release(ctx, 4); !errors.Is(err, discogs.ErrNotFound) {
+		t.Errorf("err got=%v; want=%s", err, discogs.ErrNotFound)
+	}
+
+	if _, err := IndexReleases(store, strings.NewReader(`<releases><release id="5" status="Draft"><title>Unfinished</title></release></releases>`)); err != nil {
+		t.Fatalf("failed to index draft release: %s", err)
+	}
+	var statusErr *discogs.ReleaseStatusError
+	if _, err := c.Release(ctx, 5); !errors.As(err, &statusErr) || statusErr.Status != discogs.ReleaseStatusDraft || statusErr.Release.Title != "Unfinished" {
+		t.Errorf("err got=%v; want a draft ReleaseStatusError", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests got=%d; want=2", requests)
+	}
+
+	// requests not served from the index are passed through
+	if _, err := c.ReleaseRating(ctx, 1); err != discogstest.ErrNotStubbed {
+		t.Errorf("err got=%v; want=%v", err, discogstest.ErrNotStubbed)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	store := NewMemoryStore()
+	tests := map[string]struct {
+		index func(Store, *strings.Reader) (int, error)
+		data  string
+	}{
+		"artists": {func(s Store, r *strings.Reader) (int, error) { return IndexArtists(s, r) }, `<artists><artist><id>1</id><name>The Persuader</name></artist></artists>`},
+		"labels":  {func(s Store, r *strings.Reader) (int, error) { return IndexLabels(s, r) }, `<labels><label><id>1</id><name>Planet E</name></label></labels>`},
+		"masters": {func(s Store, r *strings.Reader) (int, error) { return IndexMasters(s, r) }, `<masters><master id="1"><title>Stockholm</title></master></masters>`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if n, err := tt.index(store, strings.NewReader(tt.data)); err != nil || n != 1 {
+				t.Errorf("indexed got=%d, %v; want=1", n, err)
+			}
+		})
+	}
+
+	c := New(&discogstest.MockDiscogs{}, store)
+	ctx := context.Background()
+	if a, err := c.Artist(ctx, 1); err != nil || a.Name != "The Persuader" {
+		t.Errorf("artist got=%+v, %v", a, err)
+	}
+	if l, err := c.Label(ctx, 1); err != nil || l.Name != "Planet E" {
+		t.Errorf("label got=%+v, %v", l, err)
+	}
+	if m, err := c.Master(ctx, 1); err != nil || m.Title != "Stockholm" {
+		t.Errorf("master got=%+v, %v", m, err)
+	}
+}
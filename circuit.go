@@ -0,0 +1,158 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of the circuit of a CircuitBreaker.
+type CircuitState int
+
+// Circuit states.
+const (
+	// CircuitClosed lets requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails requests with ErrCircuitOpen without sending them.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial request through, whose outcome closes or reopens the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures CircuitBreaker. Zero values select the defaults.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed requests opening the circuit (default 5).
+	FailureThreshold int
+	// Cooldown is the time the circuit stays open before a trial request is let through (default 30s).
+	Cooldown time.Duration
+	// IsFailure reports whether a request failing with err indicates that the API is degraded. By default server
+	// errors, 429 Too Many Requests, ErrRateLimitExceeded and network errors do; other errors such as ErrNotFound
+	// show that the API works and reset the count of failures. Canceled requests and dry runs are never counted.
+	IsFailure func(err error) bool
+	// OnStateChange is called whenever the circuit changes its state (optional).
+	OnStateChange func(from, to CircuitState)
+}
+
+// isFailure reports whether err counts as a failure according to the configuration.
+func (c CircuitBreakerConfig) isFailure(err error) bool {
+	if c.IsFailure != nil {
+		return c.IsFailure(err)
+	}
+	if code, ok := statusCode(err); ok {
+		return code >= http.StatusInternalServerError || code == http.StatusTooManyRequests
+	}
+	var urlErr *url.Error
+	return errors.Is(err, ErrRateLimitExceeded) || errors.As(err, &urlErr)
+}
+
+// breaker tracks the failures of requests and the state of the circuit.
+type breaker struct {
+	cfg CircuitBreakerConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	opened   time.Time
+	trial    bool // a trial request is in progress while half-open
+}
+
+func newBreaker(cfg CircuitBreakerConfig) *breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second
+	}
+	return &breaker{cfg: cfg, now: time.Now}
+}
+
+// call invokes f() unless the circuit is open, in which case it fails with ErrCircuitOpen, and records the outcome.
+func (b *breaker) call(ctx context.Context, f func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := f()
+	b.record(ctx, err)
+	return err
+}
+
+// allow reports whether a request may be sent, moving an open circuit to half-open once the cooldown has passed.
+func (b *breaker) allow() error {
+	// notify of a change of state once b.mu is unlocked
+	notify := func() {}
+	defer func() { notify() }()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.opened) < b.cfg.Cooldown {
+			return ErrCircuitOpen
+		}
+		notify = b.setState(CircuitHalfOpen)
+		b.trial = true
+		return nil
+	case CircuitHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the circuit with the outcome of a request.
+func (b *breaker) record(ctx context.Context, err error) {
+	// notify of a change of state once b.mu is unlocked
+	notify := func() {}
+	defer func() { notify() }()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	switch {
+	case err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrDryRun)):
+		// the request says nothing about the API
+	case err != nil && b.cfg.isFailure(err):
+		b.failures++
+		if b.state == CircuitHalfOpen || b.failures >= b.cfg.FailureThreshold {
+			b.opened = b.now()
+			notify = b.setState(CircuitOpen)
+		}
+	default:
+		b.failures = 0
+		notify = b.setState(CircuitClosed)
+	}
+}
+
+// setState changes the state of the circuit and returns a function notifying the OnStateChange callback of the
+// change, to call once b.mu is unlocked so that the callback neither holds up nor deadlocks requests.
+func (b *breaker) setState(state CircuitState) func() {
+	if b.state == state || b.cfg.OnStateChange == nil {
+		b.state = state
+		return func() {}
+	}
+	from := b.state
+	b.state = state
+	return func() { b.cfg.OnStateChange(from, state) }
+}
@@ -0,0 +1,143 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	var changes []CircuitState
+	b := newBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Cooldown:         time.Minute,
+		OnStateChange:    func(from, to CircuitState) { changes = append(changes, to) },
+	})
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	serverErr := &StatusError{StatusCode: http.StatusServiceUnavailable}
+	fail := func() error { return serverErr }
+	succeed := func() error { return nil }
+
+	// errors which aren't failures reset the count
+	_ = b.call(ctx, fail)
+	_ = b.call(ctx, func() error { return ErrNotFound })
+	_ = b.call(ctx, fail)
+	if b.state != CircuitClosed {
+		t.Fatalf("state got=%s; want=%s", b.state, CircuitClosed)
+	}
+
+	if err := b.call(ctx, func() error { return ErrTooManyRequests }); err != ErrTooManyRequests {
+		t.Errorf("err got=%v; want=%s", err, ErrTooManyRequests)
+	}
+	if err := b.call(ctx, succeed); err != ErrCircuitOpen {
+		t.Errorf("err got=%v; want=%s", err, ErrCircuitOpen)
+	}
+
+	// a failed trial reopens the circuit, a successful one closes it
+	now = now.Add(time.Minute)
+	if err := b.call(ctx, fail); err != serverErr {
+		t.Errorf("err got=%v; want=%s", err, serverErr)
+	}
+	if err := b.call(ctx, succeed); err != ErrCircuitOpen {
+		t.Errorf("err got=%v; want=%s", err, ErrCircuitOpen)
+	}
+	now = now.Add(time.Minute)
+	if err := b.call(ctx, succeed); err != nil {
+		t.Errorf("err got=%v; want=nil", err)
+	}
+
+	want := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(changes) != len(want) {
+		t.Fatalf("changes got=%v; want=%v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes got=%v; want=%v", changes, want)
+			break
+		}
+	}
+}
+
+func TestBreakerHalfOpenTrial(t *testing.T) {
+	b := newBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Nanosecond})
+	ctx := context.Background()
+	_ = b.call(ctx, func() error { return ErrTooManyRequests })
+	time.Sleep(time.Millisecond)
+
+	// only a single trial request is let through while half-open
+	err := b.call(ctx, func() error {
+		if err := b.call(ctx, func() error { return nil }); err != ErrCircuitOpen {
+			t.Errorf("err got=%v; want=%s", err, ErrCircuitOpen)
+		}
+		return nil
+	})
+	if err != nil || b.state != CircuitClosed {
+		t.Errorf("trial got=%v, %s; want=nil, %s", err, b.state, CircuitClosed)
+	}
+
+	// canceled requests don't count
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_ = b.call(ctx, func() error { return ctx.Err() })
+	if b.state != CircuitClosed || b.failures != 0 {
+		t.Errorf("state got=%s, %d failures; want=%s, 0", b.state, b.failures, CircuitClosed)
+	}
+}
+
+func TestBreakerStateChangeUnlocked(t *testing.T) {
+	var b *breaker
+	var errs []error
+	b = newBreaker(CircuitBreakerConfig{
+		FailureThreshold: 1,
+		OnStateChange: func(from, to CircuitState) {
+			// a request made from the callback mustn't deadlock
+			errs = append(errs, b.allow())
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = b.call(context.Background(), func() error { return &StatusError{StatusCode: http.StatusServiceUnavailable} })
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("callback deadlocked")
+	}
+	if len(errs) != 1 || errs[0] != ErrCircuitOpen {
+		t.Errorf("errors in callback got=%v; want=[%s]", errs, ErrCircuitOpen)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	d := CircuitBreaker(initDiscogsClient(t, &Options{URL: ts.URL}), CircuitBreakerConfig{FailureThreshold: 3})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		var statusErr *StatusError
+		if _, err := d.Release(ctx, 8138518); !errors.As(err, &statusErr) {
+			t.Fatalf("err got=%v; want a status error", err)
+		}
+	}
+	// the circuit is shared by derived clients
+	if _, err := d.WithToken("token").Artist(ctx, 38661); err != ErrCircuitOpen {
+		t.Errorf("err got=%v; want=%s", err, ErrCircuitOpen)
+	}
+	if requests != 3 {
+		t.Errorf("requests got=%d; want=3", requests)
+	}
+}
@@ -0,0 +1,18 @@
+package discogs
+
+import "context"
+
+// CircuitBreaker returns d with all functions replaced with versions that fail fast with ErrCircuitOpen once the API
+// appears degraded: after cfg.FailureThreshold consecutive requests failed with server errors, 429 Too Many Requests
+// or network errors, the circuit opens and requests fail without being sent for cfg.Cooldown. A single trial request
+// is let through afterwards, closing the circuit if it succeeds and reopening it otherwise. This protects batch jobs
+// from hammering an API that's struggling. Clients derived with WithToken or WithOAuth share the circuit.
+func CircuitBreaker(d Discogs, cfg CircuitBreakerConfig) Discogs {
+	return circuited(d, newBreaker(cfg))
+}
+
+func circuited(d Discogs, b *breaker) Discogs {
+	return Intercept(d, func(ctx context.Context, _ Call, invoke func(ctx context.Context) error) error {
+		return b.call(ctx, func() error { return invoke(ctx) })
+	})
+}
@@ -0,0 +1,101 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Coalesced returns d with the database functions replaced with versions that share a single request among
+// concurrent calls for the same resource, e.g. many goroutines asking for the same artist at once. Every caller
+// receives its own copy of the response. Combined with RateLimited or Cached, wrap those with Coalesced so that the
+// calls waiting for a shared request neither consume rate limit budget nor miss the cache twice. Other services are
+// passed through to d unchanged.
+//
+// Calls capturing the raw response, setting headers, making dry runs or reporting progress aren't shared. Clients
+// derived with WithToken or WithOAuth share requests only among themselves.
+func Coalesced(d Discogs) Discogs {
+	calls := &flightGroup{calls: make(map[string]*flight)}
+	return intercept(d, ForServices(func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		if call.Result == nil {
+			return invoke(ctx)
+		}
+		return calls.call(ctx, call.key(), call.Opts, call.Result, func() error { return invoke(ctx) })
+	}, ServiceDatabase), Coalesced)
+}
+
+// flightGroup holds the calls in progress by key.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a call in progress. Once done is closed, body holds the encoded response or err the error of the call.
+type flight struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// call invokes f() to populate v unless a call for key is in progress already, in which case it waits for that call
+// and decodes its response into v.
+func (g *flightGroup) call(ctx context.Context, key string, opts []RequestOption, v interface{}, f func() error) error {
+	o := newRequestOptions(opts)
+	// the responses of these calls differ from the shared one, or the call has effects of its own
+	if o.raw != nil || len(o.header) > 0 || o.dryRun != nil || o.progress != nil {
+		return f()
+	}
+	if o.strict {
+		key += "#strict"
+	}
+
+	g.mu.Lock()
+	if fl, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-fl.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		switch {
+		case fl.err == nil && fl.body != nil:
+			return json.Unmarshal(fl.body, v)
+		case fl.err == nil, errors.Is(fl.err, context.Canceled), errors.Is(fl.err, context.DeadlineExceeded):
+			// the response couldn't be shared or the context of the call ended, which needn't apply to this one
+			return f()
+		default:
+			return fl.err
+		}
+	}
+	fl := &flight{done: make(chan struct{})}
+	g.calls[key] = fl
+	g.mu.Unlock()
+
+	// release the waiting calls even if f() panics, e.g. in a middleware
+	defer func() {
+		if r := recover(); r != nil {
+			fl.err = fmt.Errorf("discogs: shared call panicked: %v", r)
+			g.done(key, fl)
+			panic(r)
+		}
+		g.done(key, fl)
+	}()
+
+	err := f()
+	if err != nil {
+		fl.err = err
+	} else if b, err := json.Marshal(v); err == nil {
+		fl.body = b
+	}
+	return err
+}
+
+// done removes the completed flight for key and releases the calls waiting for it.
+func (g *flightGroup) done(key string, fl *flight) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(fl.done)
+}
@@ -0,0 +1,255 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesced(t *testing.T) {
+	var requests int32
+	arrived := make(chan struct{}, 10)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		arrived <- struct{}{}
+		<-release
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	d := Coalesced(initDiscogsClient(t, &Options{URL: ts.URL}))
+	ctx := context.Background()
+
+	const callers = 10
+	artists := make([]*Artist, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	get := func(i int) {
+		defer wg.Done()
+		artists[i], errs[i] = d.Artist(ctx, 38661)
+	}
+
+	wg.Add(callers)
+	go get(0)
+	<-arrived
+	for i := 1; i < callers; i++ {
+		go get(i)
+	}
+	// give the other callers time to join the request in progress
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("requests got=%d; want=1", n)
+	}
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil || artists[i].Name != "Eminem" {
+			t.Fatalf("artist %d got=%v, %v; want=Eminem", i, artists[i], errs[i])
+		}
+	}
+	if artists[0] == artists[1] {
+		t.Errorf("expected every caller to receive its own copy of the artist")
+	}
+
+	// calls made after the request completed make their own
+	if _, err := d.Artist(ctx, 38661); err != nil {
+		t.Fatalf("failed to get artist: %s", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("requests got=%d; want=2", n)
+	}
+}
+
+func TestCoalescedCanceled(t *testing.T) {
+	started := make(chan struct{})
+	d := &flightGroup{calls: make(map[string]*flight)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		var v int
+		done <- d.call(ctx, "key", nil, &v, func() error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	<-started
+
+	// the follower makes its own call when the context of the shared one ends
+	result := make(chan int)
+	go func() {
+		var v int
+		err := d.call(context.Background(), "key", nil, &v, func() error {
+			v = 1
+			return nil
+		})
+		if err != nil {
+			t.Errorf("failed to call: %s", err)
+		}
+		result <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Errorf("err got=%v; want=%s", err, context.Canceled)
+	}
+	if v := <-result; v != 1 {
+		t.Errorf("value got=%d; want=1", v)
+	}
+}
+
+func TestCoalescedPanic(t *testing.T) {
+	started := make(chan struct{})
+	proceed := make(chan struct{})
+	d := &flightGroup{calls: make(map[string]*flight)}
+
+	panicked := make(chan interface{})
+	go func() {
+		defer func() { panicked <- recover() }()
+		var v int
+		_ = d.call(context.Background(), "key", nil, &v, func() error {
+			close(started)
+			<-proceed
+			panic("middleware")
+		})
+	}()
+	<-started
+
+	followed := make(chan error)
+	go func() {
+		var v int
+		followed <- d.call(context.Background(), "key", nil, &v, func() error { return nil })
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(proceed)
+
+	if r := <-panicked; r != "middleware" {
+		t.Errorf("panic got=%v; want=middleware", r)
+	}
+	select {
+	case err := <-followed:
+		if err == nil {
+			t.Error("expected the follower to receive an error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("follower still waiting for the call that panicked")
+	}
+
+	// later calls make their own
+	var v int
+	if err := d.call(context.Background(), "key", nil, &v, func() error { v = 1; return nil }); err != nil || v != 1 {
+		t.Errorf("later call got=%d, %v; want=1", v, err)
+	}
+}
+
+func TestCoalescedDryRun(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-release
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	d := Coalesced(initDiscogsClient(t, &Options{URL: ts.URL}))
+	ctx := context.Background()
+
+	// a dry run doesn't join the real request in progress
+	real := make(chan error)
+	go func() {
+		artist, err := d.Artist(ctx, 38661)
+		if err == nil && artist.Name != "Eminem" {
+			t.Errorf("artist got=%s; want=Eminem", artist.Name)
+		}
+		real <- err
+	}()
+	<-arrived
+	var dryRuns int
+	if _, err := d.Artist(ctx, 38661, WithDryRun(func(r *http.Request) { dryRuns++ })); err != ErrDryRun || dryRuns != 1 {
+		t.Errorf("dry run got=%v, %d requests; want=%s, 1 request", err, dryRuns, ErrDryRun)
+	}
+	close(release)
+	if err := <-real; err != nil {
+		t.Errorf("err got=%v; want=nil", err)
+	}
+
+	// nor does a real call join a dry run in progress
+	g := &flightGroup{calls: make(map[string]*flight)}
+	started, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		var v int
+		if err := g.call(ctx, "key", []RequestOption{WithDryRun(func(*http.Request) {})}, &v, func() error {
+			close(started)
+			<-done
+			return ErrDryRun
+		}); err != ErrDryRun {
+			t.Errorf("dry run err got=%v; want=%s", err, ErrDryRun)
+		}
+	}()
+	<-started
+	var v int
+	if err := g.call(ctx, "key", nil, &v, func() error { v = 1; return nil }); err != nil || v != 1 {
+		t.Errorf("got=%d, %v; want=1, nil", v, err)
+	}
+	close(done)
+}
+
+func TestCoalescedBulk(t *testing.T) {
+	var requests int32
+	arrived := make(chan struct{}, 2)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		arrived <- struct{}{}
+		<-release
+		body := `{"pagination": {"page": 2, "pages": 3, "per_page": ` + r.URL.Query().Get("per_page") + `}, "releases": []}`
+		if _, err := io.WriteString(w, body); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := Coalesced(initDiscogsClient(t, &Options{URL: ts.URL}))
+	ctx := context.Background()
+
+	results := make([]*ArtistReleases, 2)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		results[0], errs[0] = d.ArtistReleases(ctx, 1, &Pagination{Page: 2})
+	}()
+	<-arrived
+	go func() {
+		defer wg.Done()
+		results[1], errs[1] = d.ArtistReleases(ctx, 1, &Pagination{Page: 2}, WithBulk())
+	}()
+	// the bulk call must make its own request rather than join the one in progress
+	select {
+	case <-arrived:
+	case <-time.After(time.Second):
+		t.Error("bulk call joined the request for 50 items per page")
+	}
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("requests got=%d; want=2", n)
+	}
+	for i, want := range []int{50, 100} {
+		if errs[i] != nil || results[i].Pagination.PerPage != want {
+			t.Errorf("call %d got=%v, %v; want %d items per page", i, results[i], errs[i], want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package discogs
+
+import (
+	"context"
+)
+
+// Duplicate is a release, or with master matching a master release, found more than once in a collection.
+type Duplicate struct {
+	// ReleaseID is the ID of the release, zero for master release duplicates.
+	ReleaseID int
+	// MasterID is the ID of the master release, zero for release duplicates.
+	MasterID int
+	// Items are the collection items of the release or master release, in collection order.
+	Items []CollectionItemSource
+}
+
+// CollectionDuplicates scans the whole collection of the user, across all folders, and returns the releases found
+// more than once. When byMaster is set, different releases of the same master release are reported as well, as
+// duplicates with MasterID set. The release duplicates are returned before the master release duplicates, each in the
+// order their first item appears in the collection.
+func CollectionDuplicates(ctx context.Context, s CollectionService, username string, byMaster bool, opts ...RequestOption) ([]Duplicate, error) {
+	items, err := AllCollectionItemsByFolder(ctx, s, username, FolderAll, nil, 0, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases, masters []int
+	byRelease := make(map[int][]CollectionItemSource)
+	byMasterID := make(map[int][]CollectionItemSource)
+	for _, item := range items {
+		if _, ok := byRelease[item.ID]; !ok {
+			releases = append(releases, item.ID)
+		}
+		byRelease[item.ID] = append(byRelease[item.ID], item)
+
+		if masterID := item.BasicInformation.MasterID; byMaster && masterID != 0 {
+			if _, ok := byMasterID[masterID]; !ok {
+				masters = append(masters, masterID)
+			}
+			byMasterID[masterID] = append(byMasterID[masterID], item)
+		}
+	}
+
+	var duplicates []Duplicate
+	for _, id := range releases {
+		if items := byRelease[id]; len(items) > 1 {
+			duplicates = append(duplicates, Duplicate{ReleaseID: id, Items: items})
+		}
+	}
+	for _, id := range masters {
+		// copies of a single release are reported as release duplicates already
+		if items := byMasterID[id]; distinctReleases(items) > 1 {
+			duplicates = append(duplicates, Duplicate{MasterID: id, Items: items})
+		}
+	}
+	return duplicates, nil
+}
+
+// distinctReleases returns the number of different releases among the items.
+func distinctReleases(items []CollectionItemSource) int {
+	ids := make(map[int]bool)
+	for _, item := range items {
+		ids[item.ID] = true
+	}
+	return len(ids)
+}
+
+// CollectionInstance is a copy of a release in a collection, with what's needed to edit or delete it.
+type CollectionInstance struct {
+	FolderID   int
+	FolderName string
+	InstanceID int64
+	Notes      []Notes
+}
+
+// FindCollectionInstances returns the instances of the release in the collection of the user, in collection order,
+// with the names of their folders. The folder name is empty if the folder isn't listed, e.g. when not authenticated
+// as the collection owner. The release not being in the collection isn't an error.
+func FindCollectionInstances(ctx context.Context, s CollectionService, username string, releaseID int, opts ...RequestOption) ([]CollectionInstance, error) {
+	items, err := s.CollectionItemsByRelease(ctx, username, releaseID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(items.Items) == 0 {
+		return nil, nil
+	}
+	folders, err := s.CollectionFolders(ctx, username, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string, len(folders.Folders))
+	for _, folder := range folders.Folders {
+		names[folder.ID] = folder.Name
+	}
+	instances := make([]CollectionInstance, 0, len(items.Items))
+	for _, item := range items.Items {
+		instances = append(instances, CollectionInstance{
+			FolderID:   item.FolderID,
+			FolderName: names[item.FolderID],
+			InstanceID: item.InstanceID,
+			Notes:      item.Notes,
+		})
+	}
+	return instances, nil
+}
+
+// CollectionReport aggregates the items of a collection folder.
+type CollectionReport struct {
+	// Items is the number of items in the folder.
+	Items int
+	// Genres, Styles, Formats and Labels count the items per genre, style, format and label name. An item counts
+	// once towards each of its genres, styles, formats and labels.
+	Genres  map[string]int
+	Styles  map[string]int
+	Formats map[FormatName]int
+	Labels  map[string]int
+	// Decades counts the items per decade of release, e.g. 1980. Items without a release year aren't counted.
+	Decades map[int]int
+	// Rated is the number of rated items and AverageRating their average rating.
+	Rated         int
+	AverageRating float64
+	// Additions counts the items per month they were added to the collection, e.g. "2020-01".
+	Additions map[string]int
+}
+
+// CollectionStats aggregates the items in the folder of the user's collection into a CollectionReport, fetching all
+// pages of the folder. FolderAll holds the whole collection.
+func CollectionStats(ctx context.Context, s CollectionService, username string, folderID int, opts ...RequestOption) (*CollectionReport, error) {
+	items, err := AllCollectionItemsByFolder(ctx, s, username, folderID, nil, 0, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CollectionReport{
+		Items:     len(items),
+		Genres:    make(map[string]int),
+		Styles:    make(map[string]int),
+		Formats:   make(map[FormatName]int),
+		Labels:    make(map[string]int),
+		Decades:   make(map[int]int),
+		Additions: make(map[string]int),
+	}
+	var ratings int
+	for _, item := range items {
+		info := item.BasicInformation
+		countDistinct(report.Genres, info.Genres)
+		countDistinct(report.Styles, info.Styles)
+
+		formats := make(map[FormatName]bool)
+		for _, f := range info.Formats {
+			if !formats[f.Name] {
+				formats[f.Name] = true
+				report.Formats[f.Name]++
+			}
+		}
+		labels := make([]string, 0, len(info.Labels))
+		for _, l := range info.Labels {
+			labels = append(labels, l.Name)
+		}
+		countDistinct(report.Labels, labels)
+
+		if info.Year > 0 {
+			report.Decades[info.Year/10*10]++
+		}
+		if item.Rating > 0 {
+			report.Rated++
+			ratings += item.Rating
+		}
+		if !item.DateAdded.IsZero() {
+			report.Additions[item.DateAdded.Format("2006-01")]++
+		}
+	}
+	if report.Rated > 0 {
+		report.AverageRating = float64(ratings) / float64(report.Rated)
+	}
+	return report, nil
+}
+
+// countDistinct increments the count of every distinct value.
+func countDistinct(counts map[string]int, values []string) {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			counts[v]++
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// CollectionAnalysisServer serves a collection in pages of two items: release 1 of master 10 in folders 1 and 2,
+// release 2 of master 10, release 3 of master 20 and release 4 without a master. The instances of release 1 and the
+// folders are served too.
+func CollectionAnalysisServer(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/users/test_user/collection/folders":
+		if _, err := io.WriteString(w, `{"folders": [{"id": 0, "name": "All", "count": 5}, {"id": 1, "name": "Uncategorized", "count": 3}, {"id": 2, "name": "Sell", "count": 2}]}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	case "/users/test_user/collection/releases/1":
+		if _, err := io.WriteString(w, `{"pagination": {"page": 1, "pages": 1, "per_page": 50, "items": 2, "urls": {}}, "releases": [`+
+			`{"id": 1, "instance_id": 101, "folder_id": 1, "notes": [{"field_id": 1, "value": "Near Mint (NM or M-)"}]}, `+
+			`{"id": 1, "instance_id": 102, "folder_id": 2}]}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	case "/users/test_user/collection/releases/5":
+		if _, err := io.WriteString(w, `{"pagination": {"page": 1, "pages": 0, "per_page": 50, "items": 0, "urls": {}}, "releases": []}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	items := []string{
+		`{"id": 1, "instance_id": 101, "folder_id": 1, "rating": 4, "date_added": "2019-03-01T10:00:00-08:00", "basic_information": {"id": 1, "master_id": 10, "title": "One", "year": 1983, "genres": ["Electronic"], "styles": ["Synth-pop"], "formats": [{"name": "Vinyl", "qty": "1"}], "labels": [{"id": 26391, "name": "Mute"}]}}`,
+		`{"id": 1, "instance_id": 102, "folder_id": 2, "rating": 0, "date_added": "2019-05-01T10:00:00-08:00", "basic_information": {"id": 1, "master_id": 10, "title": "One", "year": 1983, "genres": ["Electronic"], "styles": ["Synth-pop"], "formats": [{"name": "Vinyl", "qty": "1"}], "labels": [{"id": 26391, "name": "Mute"}]}}`,
+		`{"id": 2, "instance_id": 103, "folder_id": 1, "rating": 2, "date_added": "2020-01-19T14:19:11-08:00", "basic_information": {"id": 2, "master_id": 10, "title": "One", "year": 1999, "genres": ["Electronic", "Pop"], "styles": ["Synth-pop"], "formats": [{"name": "CD", "qty": "1"}], "labels": [{"id": 26391, "name": "Mute"}]}}`,
+		`{"id": 3, "instance_id": 104, "folder_id": 1, "rating": 0, "date_added": "2020-02-01T10:00:00-08:00", "basic_information": {"id": 3, "master_id": 20, "title": "Two", "year": 2018, "genres": ["Rock"], "styles": ["Shoegaze"], "formats": [{"name": "Vinyl", "qty": "2"}], "labels": [{"id": 833694, "name": "Permanent Record"}]}}`,
+		`{"id": 4, "instance_id": 105, "folder_id": 2, "rating": 3, "date_added": "2020-02-03T10:00:00-08:00", "basic_information": {"id": 4, "master_id": 0, "title": "Three", "year": 0, "genres": ["Rock"], "styles": [], "formats": [{"name": "Cassette", "qty": "1"}], "labels": [{"id": 833694, "name": "Permanent Record"}]}}`,
+	}
+	if r.URL.Path != "/users/test_user/collection/folders/0/releases" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	pages := (len(items) + 1) / 2
+	end := page * 2
+	if end > len(items) {
+		end = len(items)
+	}
+	if _, err := fmt.Fprintf(w, `{"pagination": {"page": %d, "pages": %d, "per_page": 2, "items": %d, "urls": {}}, "releases": [%s]}`,
+		page, pages, len(items), strings.Join(items[(page-1)*2:end], ", ")); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func TestCollectionDuplicates(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(CollectionAnalysisServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	duplicates, err := CollectionDuplicates(ctx, d, "test_user", false)
+	if err != nil {
+		t.Fatalf("failed to find duplicates: %s", err)
+	}
+	if len(duplicates) != 1 || duplicates[0].ReleaseID != 1 || len(duplicates[0].Items) != 2 {
+		t.Fatalf("duplicates got=%+v; want release 1 twice", duplicates)
+	}
+	if duplicates[0].Items[0].FolderID != 1 || duplicates[0].Items[1].FolderID != 2 {
+		t.Errorf("folders got=%d, %d; want=1, 2", duplicates[0].Items[0].FolderID, duplicates[0].Items[1].FolderID)
+	}
+
+	duplicates, err = CollectionDuplicates(ctx, d, "test_user", true)
+	if err != nil {
+		t.Fatalf("failed to find duplicates: %s", err)
+	}
+	if len(duplicates) != 2 || duplicates[1].MasterID != 10 || duplicates[1].ReleaseID != 0 || len(duplicates[1].Items) != 3 {
+		t.Errorf("duplicates got=%+v; want release 1 and master 10", duplicates)
+	}
+
+	if _, err := CollectionDuplicates(ctx, d, "", false); err != ErrInvalidUsername {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUsername)
+	}
+}
+
+func TestFindCollectionInstances(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(CollectionAnalysisServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	instances, err := FindCollectionInstances(ctx, d, "test_user", 1)
+	if err != nil {
+		t.Fatalf("failed to find instances: %s", err)
+	}
+	want := []CollectionInstance{
+		{FolderID: 1, FolderName: "Uncategorized", InstanceID: 101, Notes: []Notes{{FieldID: 1, Value: "Near Mint (NM or M-)"}}},
+		{FolderID: 2, FolderName: "Sell", InstanceID: 102},
+	}
+	if diff := cmp.Diff(want, instances); diff != "" {
+		t.Errorf("(-want +got)\n%s", diff)
+	}
+
+	instances, err = FindCollectionInstances(ctx, d, "test_user", 5)
+	if err != nil || len(instances) != 0 {
+		t.Errorf("instances got=%v, %v; want=none", instances, err)
+	}
+
+	if _, err := FindCollectionInstances(ctx, d, "test_user", 0); err != ErrInvalidReleaseID {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidReleaseID)
+	}
+}
+
+func TestCollectionStats(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(CollectionAnalysisServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	report, err := CollectionStats(context.Background(), d, "test_user", 0)
+	if err != nil {
+		t.Fatalf("failed to get collection stats: %s", err)
+	}
+
+	want := &CollectionReport{
+		Items:         5,
+		Genres:        map[string]int{"Electronic": 3, "Pop": 1, "Rock": 2},
+		Styles:        map[string]int{"Synth-pop": 3, "Shoegaze": 1},
+		Formats:       map[FormatName]int{"Vinyl": 3, "CD": 1, "Cassette": 1},
+		Labels:        map[string]int{"Mute": 3, "Permanent Record": 2},
+		Decades:       map[int]int{1980: 2, 1990: 1, 2010: 1},
+		Rated:         3,
+		AverageRating: 3,
+		Additions:     map[string]int{"2019-03": 1, "2019-05": 1, "2020-01": 1, "2020-02": 2},
+	}
+	if diff := cmp.Diff(want, report); diff != "" {
+		t.Errorf("(-want +got)\n%s", diff)
+	}
+
+	if _, err := CollectionStats(context.Background(), d, "", 0); err != ErrInvalidUsername {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUsername)
+	}
+}
@@ -0,0 +1,58 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RateAllOptions configures RateAll.
+type RateAllOptions struct {
+	// RateLimit, if not nil, paces the rating requests through RateLimit.Call, e.g. with a budget set by SetBudget so a
+	// large migration doesn't exhaust the requests of other clients. It should also be set as the RateLimit of the
+	// client's Options so it's kept up to date.
+	RateLimit *RateLimit
+	// Progress, if not nil, is called after every rated instance with the number of instances rated so far as Items
+	// and the number to rate as TotalItems. Reading the folder is reported through WithProgress instead.
+	Progress ProgressFunc
+}
+
+// RateAll sets the rating of the instances in the folder folderID of the user's collection, which can be FolderAll,
+// for which filter returns true, or of all instances if filter is nil. Instances already having the rating are
+// skipped, so an interrupted run can be repeated. The whole folder is read before rating the instances in collection
+// order. RateAll stops at the first error and returns the number of instances rated.
+func RateAll(ctx context.Context, s CollectionService, username string, folderID, rating int, filter func(CollectionItemSource) bool, o RateAllOptions, opts ...RequestOption) (int, error) {
+	if err := validateRating(rating); err != nil {
+		return 0, err
+	}
+	items, err := AllCollectionItemsByFolder(ctx, s, username, folderID, nil, 0, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	var matching []CollectionItemSource
+	for _, item := range items {
+		if item.Rating != rating && (filter == nil || filter(item)) {
+			matching = append(matching, item)
+		}
+	}
+
+	start := time.Now()
+	for i, item := range matching {
+		rate := func() error {
+			return s.RateCollectionInstance(ctx, username, item.FolderID, item.ID, item.InstanceID, rating, opts...)
+		}
+		if o.RateLimit != nil {
+			err = o.RateLimit.Call(ctx, rate)
+		} else {
+			err = rate()
+		}
+		if err != nil {
+			return i, fmt.Errorf("release %d instance %d: %w", item.ID, item.InstanceID, err)
+		}
+		if o.Progress != nil {
+			o.Progress(Progress{Items: i + 1, TotalItems: len(matching), Elapsed: time.Since(start)})
+		}
+	}
+	return len(matching), nil
+}
@@ -0,0 +1,117 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ratingServer serves the collection of CollectionAnalysisServer and records the ratings posted to its instances.
+type ratingServer struct {
+	mu      sync.Mutex
+	ratings []string
+	fail    bool
+}
+
+func (s *ratingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		CollectionAnalysisServer(w, r)
+		return
+	}
+	if s.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var body struct {
+		Rating int `json:"rating"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.ratings = append(s.ratings, strings.TrimPrefix(r.URL.Path, "/users/test_user/collection/folders/")+" "+strconv.Itoa(body.Rating))
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestCollectionServiceRateCollectionInstance(t *testing.T) {
+	s := &ratingServer{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	if err := d.RateCollectionInstance(ctx, "test_user", 1, 2, 103, 5); err != nil {
+		t.Fatalf("failed to rate instance: %s", err)
+	}
+	if want := []string{"1/releases/2/instances/103 5"}; !reflect.DeepEqual(s.ratings, want) {
+		t.Errorf("ratings got=%v; want=%v", s.ratings, want)
+	}
+
+	tests := map[string]struct {
+		username  string
+		folderID  int
+		releaseID int
+		rating    int
+		want      error
+	}{
+		"username":   {folderID: 1, releaseID: 2, want: ErrInvalidUsername},
+		"folder all": {username: "test_user", folderID: FolderAll, releaseID: 2, want: ErrInvalidFolderID},
+		"release id": {username: "test_user", folderID: 1, want: ErrInvalidReleaseID},
+		"rating":     {username: "test_user", folderID: 1, releaseID: 2, rating: -1, want: ErrInvalidRating},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := d.RateCollectionInstance(ctx, tt.username, tt.folderID, tt.releaseID, 103, tt.rating); !errors.Is(err, tt.want) {
+				t.Errorf("err got=%v; want=%s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateAll(t *testing.T) {
+	s := &ratingServer{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	var progress []int
+	vinyl := func(item CollectionItemSource) bool {
+		return item.BasicInformation.Formats[0].Name == "Vinyl"
+	}
+	n, err := RateAll(ctx, d, "test_user", FolderAll, 4, vinyl, RateAllOptions{
+		RateLimit: &RateLimit{},
+		Progress:  func(p Progress) { progress = append(progress, p.Items, p.TotalItems) },
+	})
+	if err != nil {
+		t.Fatalf("failed to rate all: %s", err)
+	}
+	// release 1 in folder 1 is rated 4 already
+	want := []string{"2/releases/1/instances/102 4", "1/releases/3/instances/104 4"}
+	if n != 2 || !reflect.DeepEqual(s.ratings, want) {
+		t.Errorf("ratings got=%d, %v; want=2, %v", n, s.ratings, want)
+	}
+	if want := []int{1, 2, 2, 2}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("progress got=%v; want=%v", progress, want)
+	}
+
+	s.fail = true
+	if n, err := RateAll(ctx, d, "test_user", FolderAll, 1, nil, RateAllOptions{}); n != 0 || err == nil {
+		t.Errorf("got=%d, %v; want=0, error", n, err)
+	}
+
+	if _, err := RateAll(ctx, d, "test_user", FolderAll, 6, nil, RateAllOptions{}); !errors.Is(err, ErrInvalidRating) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidRating)
+	}
+}
@@ -0,0 +1,112 @@
+package discogs
+
+import (
+	"context"
+	"sync"
+)
+
+// RateSource provides exchange rates between currencies.
+type RateSource interface {
+	// Rate returns the amount of currency to that equals one unit of currency from.
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// RateSourceFunc adapts a function to a RateSource, e.g. to plug in an external exchange rate service.
+type RateSourceFunc func(ctx context.Context, from, to string) (float64, error)
+
+// Rate calls f(ctx, from, to).
+func (f RateSourceFunc) Rate(ctx context.Context, from, to string) (float64, error) {
+	return f(ctx, from, to)
+}
+
+// MarketplaceRates returns a RateSource deriving exchange rates from the marketplace: it requests the statistics of
+// the release in both currencies and divides the lowest prices, which Discogs converts with its own exchange rates.
+// The release should be one that's always for sale.
+func MarketplaceRates(s MarketPlaceService, releaseID int) RateSource {
+	return RateSourceFunc(func(ctx context.Context, from, to string) (float64, error) {
+		fromStats, err := s.ReleaseStatistics(ctx, releaseID, WithCurrency(from))
+		if err != nil {
+			return 0, err
+		}
+		toStats, err := s.ReleaseStatistics(ctx, releaseID, WithCurrency(to))
+		if err != nil {
+			return 0, err
+		}
+		if fromStats.LowestPrice == nil || toStats.LowestPrice == nil || fromStats.LowestPrice.Amount == 0 {
+			return 0, ErrNoExchangeRate
+		}
+		return toStats.LowestPrice.Value() / fromStats.LowestPrice.Value(), nil
+	})
+}
+
+// CurrencyConverter converts prices between currencies using the rates of a RateSource. Rates are requested once per
+// pair of currencies and reused afterwards. It is safe for concurrent use.
+type CurrencyConverter struct {
+	source RateSource
+
+	mu    sync.Mutex
+	rates map[[2]string]float64
+}
+
+// NewCurrencyConverter returns a CurrencyConverter using the rates of source.
+func NewCurrencyConverter(source RateSource) *CurrencyConverter {
+	return &CurrencyConverter{
+		source: source,
+		rates:  map[[2]string]float64{},
+	}
+}
+
+// Convert returns the price in the given currency.
+func (c *CurrencyConverter) Convert(ctx context.Context, p Price, to string) (Price, error) {
+	if p.Currency == to {
+		return p, nil
+	}
+	if _, err := currency(to); err != nil {
+		return Price{}, err
+	}
+
+	rate, err := c.rate(ctx, p.Currency, to)
+	if err != nil {
+		return Price{}, err
+	}
+	return NewPrice(p.Value()*rate, to), nil
+}
+
+func (c *CurrencyConverter) rate(ctx context.Context, from, to string) (float64, error) {
+	key := [2]string{from, to}
+	c.mu.Lock()
+	rate, ok := c.rates[key]
+	c.mu.Unlock()
+	if ok {
+		return rate, nil
+	}
+
+	rate, err := c.source.Rate(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.rates[key] = rate
+	c.mu.Unlock()
+	return rate, nil
+}
+
+// Convert returns a copy of the price suggestions with every price converted to the given currency.
+func (l *PriceListing) Convert(ctx context.Context, c *CurrencyConverter, currency string) (*PriceListing, error) {
+	converted := *l
+	for _, p := range []**Price{
+		&converted.VeryGood, &converted.GoodPlus, &converted.NearMint, &converted.Good,
+		&converted.VeryGoodPlus, &converted.Mint, &converted.Fair, &converted.Poor,
+	} {
+		if *p == nil {
+			continue
+		}
+		v, err := c.Convert(ctx, **p, currency)
+		if err != nil {
+			return nil, err
+		}
+		*p = &v
+	}
+	return &converted, nil
+}
@@ -0,0 +1,77 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMarketplaceRates(t *testing.T) {
+	prices := map[string]float64{"USD": 20, "EUR": 18, "GBP": 0}
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		cur := r.URL.Query().Get("curr_abbr")
+		if _, err := fmt.Fprintf(w, `{"num_for_sale": 4, "lowest_price": {"value": %g, "currency": "%s"}, "blocked_from_sale": false}`, prices[cur], cur); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+	c := NewCurrencyConverter(MarketplaceRates(d, testReleaseID))
+
+	p, err := c.Convert(ctx, NewPrice(10, "USD"), "EUR")
+	if err != nil {
+		t.Fatalf("failed to convert price: %s", err)
+	}
+	if want := NewPrice(9, "EUR"); p != want {
+		t.Errorf("price got=%s; want=%s", p, want)
+	}
+
+	// the rate is reused
+	if _, err := c.Convert(ctx, NewPrice(20, "USD"), "EUR"); err != nil {
+		t.Fatalf("failed to convert price: %s", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests got=%d; want=2", requests)
+	}
+
+	if _, err := c.Convert(ctx, NewPrice(10, "GBP"), "EUR"); err != ErrNoExchangeRate {
+		t.Errorf("err got=%v; want=%s", err, ErrNoExchangeRate)
+	}
+	if _, err := c.Convert(ctx, NewPrice(10, "USD"), "RUR"); err != ErrCurrencyNotSupported {
+		t.Errorf("err got=%v; want=%s", err, ErrCurrencyNotSupported)
+	}
+}
+
+func TestPriceListingConvert(t *testing.T) {
+	c := NewCurrencyConverter(RateSourceFunc(func(ctx context.Context, from, to string) (float64, error) {
+		if from == "EUR" && to == "USD" {
+			return 1.1, nil
+		}
+		return 0, ErrNoExchangeRate
+	}))
+
+	mint := NewPrice(10, "EUR")
+	poor := NewPrice(1, "USD")
+	l := &PriceListing{Mint: &mint, Poor: &poor}
+
+	converted, err := l.Convert(context.Background(), c, "USD")
+	if err != nil {
+		t.Fatalf("failed to convert price listing: %s", err)
+	}
+	if *converted.Mint != NewPrice(11, "USD") || *converted.Poor != poor || converted.Good != nil {
+		t.Errorf("price listing got=%+v", converted)
+	}
+	if *l.Mint != mint {
+		t.Errorf("original price listing modified: %+v", l)
+	}
+
+	if _, err := l.Convert(context.Background(), c, "GBP"); err != ErrNoExchangeRate {
+		t.Errorf("err got=%v; want=%s", err, ErrNoExchangeRate)
+	}
+}
@@ -0,0 +1,104 @@
+package discogs
+
+import (
+	"strings"
+)
+
+// countryAliases lists the country names Discogs uses with the ISO 3166 codes and common variants mapping to them.
+// Discogs names the UK and the US by their abbreviations and keeps historic countries, e.g. "USSR".
+var countryAliases = []struct {
+	name    string
+	aliases []string
+}{
+	{"Argentina", []string{"AR", "ARG"}},
+	{"Australia", []string{"AU", "AUS"}},
+	{"Austria", []string{"AT", "AUT", "Österreich"}},
+	{"Belgium", []string{"BE", "BEL"}},
+	{"Brazil", []string{"BR", "BRA", "Brasil"}},
+	{"Bulgaria", []string{"BG", "BGR"}},
+	{"Canada", []string{"CA", "CAN"}},
+	{"Chile", []string{"CL", "CHL"}},
+	{"China", []string{"CN", "CHN", "People's Republic of China", "PRC"}},
+	{"Colombia", []string{"CO", "COL"}},
+	{"Croatia", []string{"HR", "HRV"}},
+	{"Cuba", []string{"CU", "CUB"}},
+	{"Czech Republic", []string{"CZ", "CZE", "Czechia"}},
+	{"Denmark", []string{"DK", "DNK"}},
+	{"Estonia", []string{"EE", "EST"}},
+	{"Finland", []string{"FI", "FIN"}},
+	{"France", []string{"FR", "FRA"}},
+	{"Germany", []string{"DE", "DEU", "Deutschland", "West Germany", "FRG"}},
+	{"Greece", []string{"GR", "GRC"}},
+	{"Hong Kong", []string{"HK", "HKG"}},
+	{"Hungary", []string{"HU", "HUN"}},
+	{"Iceland", []string{"IS", "ISL"}},
+	{"India", []string{"IN", "IND"}},
+	{"Indonesia", []string{"ID", "IDN"}},
+	{"Ireland", []string{"IE", "IRL", "Republic of Ireland", "Eire"}},
+	{"Israel", []string{"IL", "ISR"}},
+	{"Italy", []string{"IT", "ITA", "Italia"}},
+	{"Jamaica", []string{"JM", "JAM"}},
+	{"Japan", []string{"JP", "JPN"}},
+	{"Latvia", []string{"LV", "LVA"}},
+	{"Lithuania", []string{"LT", "LTU"}},
+	{"Luxembourg", []string{"LU", "LUX"}},
+	{"Malaysia", []string{"MY", "MYS"}},
+	{"Mexico", []string{"MX", "MEX", "México"}},
+	{"Netherlands", []string{"NL", "NLD", "Holland", "The Netherlands", "Nederland"}},
+	{"New Zealand", []string{"NZ", "NZL"}},
+	{"Nigeria", []string{"NG", "NGA"}},
+	{"Norway", []string{"NO", "NOR"}},
+	{"Peru", []string{"PE", "PER"}},
+	{"Philippines", []string{"PH", "PHL"}},
+	{"Poland", []string{"PL", "POL", "Polska"}},
+	{"Portugal", []string{"PT", "PRT"}},
+	{"Romania", []string{"RO", "ROU"}},
+	{"Russia", []string{"RU", "RUS", "Russian Federation"}},
+	{"Serbia", []string{"RS", "SRB"}},
+	{"Singapore", []string{"SG", "SGP"}},
+	{"Slovakia", []string{"SK", "SVK"}},
+	{"Slovenia", []string{"SI", "SVN"}},
+	{"South Africa", []string{"ZA", "ZAF"}},
+	{"South Korea", []string{"KR", "KOR", "Korea", "Republic of Korea", "Korea, Republic of"}},
+	{"Spain", []string{"ES", "ESP", "España"}},
+	{"Sweden", []string{"SE", "SWE", "Sverige"}},
+	{"Switzerland", []string{"CH", "CHE", "Schweiz", "Suisse"}},
+	{"Taiwan", []string{"TW", "TWN"}},
+	{"Thailand", []string{"TH", "THA"}},
+	{"Turkey", []string{"TR", "TUR", "Türkiye"}},
+	{"UK", []string{"GB", "GBR", "United Kingdom", "Great Britain", "Britain"}},
+	{"Ukraine", []string{"UA", "UKR"}},
+	{"Uruguay", []string{"UY", "URY"}},
+	{"US", []string{"USA", "United States", "United States of America", "America"}},
+	{"USSR", []string{"SU", "Soviet Union"}},
+	{"Venezuela", []string{"VE", "VEN"}},
+	{"Yugoslavia", []string{"YU", "YUG"}},
+}
+
+// countries maps the normalized names, codes and variants of the countries to the names Discogs uses.
+var countries = func() map[string]string {
+	m := make(map[string]string)
+	for _, c := range countryAliases {
+		m[countryKey(c.name)] = c.name
+		for _, alias := range c.aliases {
+			m[countryKey(alias)] = c.name
+		}
+	}
+	return m
+}()
+
+// countryKey returns the key of a country name in countries, ignoring case, dots and surrounding whitespace, so
+// "u.s.a." matches "USA".
+func countryKey(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), ".", ""))
+}
+
+// NormalizeCountry returns the name Discogs uses for the country given by name, ISO 3166 code or a common variant,
+// e.g. "UK" for "United Kingdom" or "GB" and "US" for "USA". Countries it doesn't know, including regions such as
+// "Europe", are returned as is.
+func NormalizeCountry(country string) string {
+	if name, ok := countries[countryKey(country)]; ok {
+		return name
+	}
+	return country
+}
@@ -0,0 +1,40 @@
+package discogs
+
+import (
+	"testing"
+)
+
+func TestNormalizeCountry(t *testing.T) {
+	tests := map[string]string{
+		"United Kingdom": "UK",
+		"GB":             "UK",
+		"uk":             "UK",
+		"USA":            "US",
+		"u.s.a.":         "US",
+		"United States":  "US",
+		"DE":             "Germany",
+		" germany ":      "Germany",
+		"Holland":        "Netherlands",
+		"KOR":            "South Korea",
+		"Czechia":        "Czech Republic",
+		"Europe":         "Europe",
+		"Atlantis":       "Atlantis",
+	}
+	for in, want := range tests {
+		if got := NormalizeCountry(in); got != want {
+			t.Errorf("%q: got=%q; want=%q", in, got, want)
+		}
+	}
+}
+
+func TestCountryAliasesUnique(t *testing.T) {
+	seen := make(map[string]string)
+	for _, c := range countryAliases {
+		for _, key := range append([]string{c.name}, c.aliases...) {
+			if name, ok := seen[countryKey(key)]; ok {
+				t.Errorf("%q maps to %s and %s", key, name, c.name)
+			}
+			seen[countryKey(key)] = c.name
+		}
+	}
+}
@@ -2,6 +2,7 @@ package discogs
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 	"strconv"
 )
@@ -16,21 +17,24 @@ const (
 // DatabaseService is an interface to work with database.
 type DatabaseService interface {
 	// Artist represents a person in the discogs database.
-	Artist(ctx context.Context, artistID int) (*Artist, error)
+	Artist(ctx context.Context, artistID int, opts ...RequestOption) (*Artist, error)
 	// ArtistReleases returns a list of releases and masters associated with the artist.
-	ArtistReleases(ctx context.Context, artistID int, pagination *Pagination) (*ArtistReleases, error)
+	ArtistReleases(ctx context.Context, artistID int, pagination *Pagination, opts ...RequestOption) (*ArtistReleases, error)
 	// Label returns a label.
-	Label(ctx context.Context, labelID int) (*Label, error)
+	Label(ctx context.Context, labelID int, opts ...RequestOption) (*Label, error)
 	// LabelReleases returns a list of Releases associated with the label.
-	LabelReleases(ctx context.Context, labelID int, pagination *Pagination) (*LabelReleases, error)
+	LabelReleases(ctx context.Context, labelID int, pagination *Pagination, opts ...RequestOption) (*LabelReleases, error)
 	// Master returns a master release.
-	Master(ctx context.Context, masterID int) (*Master, error)
+	Master(ctx context.Context, masterID int, opts ...RequestOption) (*Master, error)
 	// MasterVersions retrieves a list of all Releases that are versions of this master.
-	MasterVersions(ctx context.Context, masterID int, pagination *Pagination) (*MasterVersions, error)
-	// Release returns release by release's ID.
-	Release(ctx context.Context, releaseID int) (*Release, error)
+	MasterVersions(ctx context.Context, masterID int, pagination *Pagination, opts ...RequestOption) (*MasterVersions, error)
+	// Release returns release by release's ID. Releases whose submission wasn't accepted, e.g. drafts, fail with a
+	// *ReleaseStatusError matching ErrReleaseNotAccepted.
+	Release(ctx context.Context, releaseID int, opts ...RequestOption) (*Release, error)
+	// ReleaseCommunityStats returns the number of users having and wanting the release.
+	ReleaseCommunityStats(ctx context.Context, releaseID int, opts ...RequestOption) (*ReleaseCommunityStats, error)
 	// ReleaseRating retruns community release rating.
-	ReleaseRating(ctx context.Context, releaseID int) (*ReleaseRating, error)
+	ReleaseRating(ctx context.Context, releaseID int, opts ...RequestOption) (*ReleaseRating, error)
 }
 
 type databaseService struct {
@@ -58,8 +62,8 @@ type Release struct {
 	Community         Community      `json:"community"`
 	Companies         []Company      `json:"companies"`
 	Country           string         `json:"country"`
-	DateAdded         string         `json:"date_added"`
-	DateChanged       string         `json:"date_changed"`
+	DateAdded         Time           `json:"date_added"`
+	DateChanged       Time           `json:"date_changed"`
 	EstimatedWeight   int            `json:"estimated_weight"`
 	ExtraArtists      []ArtistSource `json:"extraartists"`
 	FormatQuantity    int            `json:"format_quantity"`
@@ -77,32 +81,54 @@ type Release struct {
 	ReleasedFormatted string         `json:"released_formatted"`
 	ResourceURL       string         `json:"resource_url"`
 	Series            []Series       `json:"series"`
-	Status            string         `json:"status"`
+	Status            ReleaseStatus  `json:"status"`
 	Styles            []string       `json:"styles"`
 	Tracklist         []Track        `json:"tracklist"`
 	URI               string         `json:"uri"`
 	Videos            []Video        `json:"videos"`
 	Year              int            `json:"year"`
+	// Extra holds the fields of the API response the struct doesn't map.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (s *databaseService) Release(ctx context.Context, releaseID int) (*Release, error) {
+func (s *databaseService) Release(ctx context.Context, releaseID int, opts ...RequestOption) (*Release, error) {
+	cur, err := requestCurrency(s.currency, opts)
+	if err != nil {
+		return nil, err
+	}
 	params := url.Values{}
-	params.Set("curr_abbr", s.currency)
+	params.Set("curr_abbr", cur)
 
 	var release *Release
-	err := s.request(ctx, s.url+releasesURI+strconv.Itoa(releaseID), params, &release)
+	err = s.request(ctx, joinURL(s.url, releasesURI, strconv.Itoa(releaseID)), params, &release, opts...)
+	if err == nil && release != nil && !release.Status.Accepted() {
+		return nil, &ReleaseStatusError{ReleaseID: releaseID, Status: release.Status, Release: release}
+	}
 	return release, err
 }
 
+// ReleaseCommunityStats serves response for community release stats request. Unlike the marketplace Stats it holds
+// the release's popularity, without fetching the whole release.
+type ReleaseCommunityStats struct {
+	NumHave int `json:"num_have"`
+	NumWant int `json:"num_want"`
+}
+
+func (s *databaseService) ReleaseCommunityStats(ctx context.Context, releaseID int, opts ...RequestOption) (*ReleaseCommunityStats, error) {
+	var stats *ReleaseCommunityStats
+	err := s.request(ctx, joinURL(s.url, releasesURI, strconv.Itoa(releaseID), "stats"), nil, &stats, opts...)
+	return stats, err
+}
+
 // ReleaseRating serves response for community release rating request.
 type ReleaseRating struct {
 	ID     int    `json:"release_id"`
 	Rating Rating `json:"rating"`
 }
 
-func (s *databaseService) ReleaseRating(ctx context.Context, releaseID int) (*ReleaseRating, error) {
+func (s *databaseService) ReleaseRating(ctx context.Context, releaseID int, opts ...RequestOption) (*ReleaseRating, error) {
 	var rating *ReleaseRating
-	err := s.request(ctx, s.url+releasesURI+strconv.Itoa(releaseID)+"/rating", nil, &rating)
+	err := s.request(ctx, joinURL(s.url, releasesURI, strconv.Itoa(releaseID), "rating"), nil, &rating, opts...)
 	return rating, err
 }
 
@@ -124,11 +150,13 @@ type Artist struct {
 	URLs           []string `json:"urls"`
 	Groups         []Member `json:"groups,omitempty"`
 	DataQuality    string   `json:"data_quality"`
+	// Extra holds the fields of the API response the struct doesn't map.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (s *databaseService) Artist(ctx context.Context, artistID int) (*Artist, error) {
+func (s *databaseService) Artist(ctx context.Context, artistID int, opts ...RequestOption) (*Artist, error) {
 	var artist *Artist
-	err := s.request(ctx, s.url+artistsURI+strconv.Itoa(artistID), nil, &artist)
+	err := s.request(ctx, joinURL(s.url, artistsURI, strconv.Itoa(artistID)), nil, &artist, opts...)
 	return artist, err
 }
 
@@ -138,9 +166,20 @@ type ArtistReleases struct {
 	Releases   []ReleaseSource `json:"releases"`
 }
 
-func (s *databaseService) ArtistReleases(ctx context.Context, artistID int, pagination *Pagination) (*ArtistReleases, error) {
+// valid sort keys
+// https://www.discogs.com/developers#page:database,header:database-artist-releases
+var validArtistReleasesSort = map[SortKey]struct{}{
+	SortYear:   struct{}{},
+	SortTitle:  struct{}{},
+	SortFormat: struct{}{},
+}
+
+func (s *databaseService) ArtistReleases(ctx context.Context, artistID int, pagination *Pagination, opts ...RequestOption) (*ArtistReleases, error) {
+	if err := pagination.validate(validArtistReleasesSort); err != nil {
+		return nil, err
+	}
 	var releases *ArtistReleases
-	err := s.request(ctx, s.url+artistsURI+strconv.Itoa(artistID)+"/releases", pagination.params(), &releases)
+	err := s.request(ctx, joinURL(s.url, artistsURI, strconv.Itoa(artistID), "releases"), pagination.params(), &releases, opts...)
 	return releases, err
 }
 
@@ -153,16 +192,19 @@ type Label struct {
 	ContactInfo string     `json:"contact_info"`
 	URI         string     `json:"uri"`
 	Sublabels   []Sublable `json:"sublabels"`
+	ParentLabel *Sublable  `json:"parent_label,omitempty"`
 	URLs        []string   `json:"urls"`
 	Images      []Image    `json:"images"`
 	ResourceURL string     `json:"resource_url"`
 	ID          int        `json:"id"`
 	DataQuality string     `json:"data_quality"`
+	// Extra holds the fields of the API response the struct doesn't map.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (s *databaseService) Label(ctx context.Context, labelID int) (*Label, error) {
+func (s *databaseService) Label(ctx context.Context, labelID int, opts ...RequestOption) (*Label, error) {
 	var label *Label
-	err := s.request(ctx, s.url+labelsURI+strconv.Itoa(labelID), nil, &label)
+	err := s.request(ctx, joinURL(s.url, labelsURI, strconv.Itoa(labelID)), nil, &label, opts...)
 	return label, err
 }
 
@@ -172,9 +214,13 @@ type LabelReleases struct {
 	Releases   []ReleaseSource `json:"releases"`
 }
 
-func (s *databaseService) LabelReleases(ctx context.Context, labelID int, pagination *Pagination) (*LabelReleases, error) {
+func (s *databaseService) LabelReleases(ctx context.Context, labelID int, pagination *Pagination, opts ...RequestOption) (*LabelReleases, error) {
+	// label releases can't be sorted
+	if err := pagination.validate(nil); err != nil {
+		return nil, err
+	}
 	var releases *LabelReleases
-	err := s.request(ctx, s.url+labelsURI+strconv.Itoa(labelID)+"/releases", pagination.params(), &releases)
+	err := s.request(ctx, joinURL(s.url, labelsURI, strconv.Itoa(labelID), "releases"), pagination.params(), &releases, opts...)
 	return releases, err
 }
 
@@ -202,11 +248,13 @@ type Master struct {
 	VersionsURL          string         `json:"versions_url"`
 	ResourceURL          string         `json:"resource_url"`
 	DataQuality          string         `json:"data_quality"`
+	// Extra holds the fields of the API response the struct doesn't map.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-func (s *databaseService) Master(ctx context.Context, masterID int) (*Master, error) {
+func (s *databaseService) Master(ctx context.Context, masterID int, opts ...RequestOption) (*Master, error) {
 	var master *Master
-	err := s.request(ctx, s.url+mastersURI+strconv.Itoa(masterID), nil, &master)
+	err := s.request(ctx, joinURL(s.url, mastersURI, strconv.Itoa(masterID)), nil, &master, opts...)
 	return master, err
 }
 
@@ -216,8 +264,22 @@ type MasterVersions struct {
 	Versions   []Version `json:"versions"`
 }
 
-func (s *databaseService) MasterVersions(ctx context.Context, masterID int, pagination *Pagination) (*MasterVersions, error) {
+// valid sort keys
+// https://www.discogs.com/developers#page:database,header:database-master-release-versions
+var validMasterVersionsSort = map[SortKey]struct{}{
+	SortReleased: struct{}{},
+	SortTitle:    struct{}{},
+	SortFormat:   struct{}{},
+	SortLabel:    struct{}{},
+	SortCatno:    struct{}{},
+	SortCountry:  struct{}{},
+}
+
+func (s *databaseService) MasterVersions(ctx context.Context, masterID int, pagination *Pagination, opts ...RequestOption) (*MasterVersions, error) {
+	if err := pagination.validate(validMasterVersionsSort); err != nil {
+		return nil, err
+	}
 	var versions *MasterVersions
-	err := s.request(ctx, s.url+mastersURI+strconv.Itoa(masterID)+"/versions", pagination.params(), &versions)
+	err := s.request(ctx, joinURL(s.url, mastersURI, strconv.Itoa(masterID), "versions"), pagination.params(), &versions, opts...)
 	return versions, err
 }
@@ -3,11 +3,14 @@ package discogs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -25,6 +28,12 @@ func DatabaseServer(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+	case "/releases/8138518/stats":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, `{"num_have": 73, "num_want": 18}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 	case "/masters/718441":
 		w.WriteHeader(http.StatusOK)
 		if _, err := io.WriteString(w, masterJson); err != nil {
@@ -37,6 +46,24 @@ func DatabaseServer(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+	case "/artists/1289":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, groupArtistJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	case "/releases/2":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, draftReleaseJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	case "/releases/1":
+		w.WriteHeader(http.StatusNotFound)
+		if _, err := io.WriteString(w, `{"message": "Release not found."}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
@@ -74,6 +101,99 @@ func TestDatabaseServiceRelease(t *testing.T) {
 	compareJson(t, string(json), releaseJson)
 }
 
+func TestDatabaseServiceReleaseNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	_, err := d.Release(context.Background(), 1)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err got=%v; want=%s", err, ErrNotFound)
+	}
+	if !strings.Contains(err.Error(), "Release not found.") {
+		t.Errorf("err got=%s; want the discogs message to be included", err)
+	}
+}
+
+func TestDatabaseServiceReleaseNotAccepted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	release, err := d.Release(context.Background(), 2)
+	if release != nil {
+		t.Errorf("release got=%v; want=nil", release)
+	}
+	if !errors.Is(err, ErrReleaseNotAccepted) || !errors.Is(err, ErrAPI) {
+		t.Fatalf("err got=%v; want=%s", err, ErrReleaseNotAccepted)
+	}
+	var statusErr *ReleaseStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("err got=%v; want a release status error", err)
+	}
+	if statusErr.ReleaseID != 2 || statusErr.Status != ReleaseStatusDraft || statusErr.Release.Title != "Untitled Draft" {
+		t.Errorf("release status error got=%+v; want release 2 in draft", statusErr)
+	}
+	if want := "discogs error: release not accepted: release 2 is draft"; err.Error() != want {
+		t.Errorf("err got=%q; want=%q", err, want)
+	}
+}
+
+func TestDatabaseServiceReleaseCommunity(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	release, err := d.Release(context.Background(), 8138518)
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+
+	c := release.Community
+	if c.Have != 73 || c.Want != 18 {
+		t.Errorf("have/want got=%d/%d; want=73/18", c.Have, c.Want)
+	}
+	if c.Rating.Count != 11 || c.Rating.Average != 4.91 {
+		t.Errorf("rating got=%+v; want={Average:4.91 Count:11}", c.Rating)
+	}
+	if c.Status != "Accepted" || c.Submitter.Username != "magnetic-loft-music" || len(c.Contributors) != 2 {
+		t.Errorf("community got=%+v", c)
+	}
+}
+
+func TestDatabaseServiceReleaseCommunityStats(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	stats, err := d.ReleaseCommunityStats(context.Background(), 8138518)
+	if err != nil {
+		t.Fatalf("failed to get release stats: %s", err)
+	}
+	if stats.NumHave != 73 || stats.NumWant != 18 {
+		t.Errorf("have/want got=%d/%d; want=73/18", stats.NumHave, stats.NumWant)
+	}
+}
+
+func TestReleaseSubTracks(t *testing.T) {
+	const data = `{"id": 1, "tracklist": [{"position": "", "type_": "index", "title": "Suite", "duration": "12:00", "sub_tracks": [{"position": "1a", "type_": "track", "title": "Part One", "duration": "5:00"}, {"position": "1b", "type_": "track", "title": "Part Two", "duration": "7:00"}]}]}`
+
+	var release Release
+	if err := json.Unmarshal([]byte(data), &release); err != nil {
+		t.Fatalf("failed to unmarshal release: %s", err)
+	}
+	if len(release.Tracklist) != 1 {
+		t.Fatalf("tracklist got=%d tracks; want=1", len(release.Tracklist))
+	}
+	want := []Track{
+		{Position: "1a", Type: "track", Title: "Part One", Duration: "5:00"},
+		{Position: "1b", Type: "track", Title: "Part Two", Duration: "7:00"},
+	}
+	if diff := cmp.Diff(release.Tracklist[0].SubTracks, want); diff != "" {
+		t.Errorf("sub tracks (-got +want)\n%s", diff)
+	}
+}
+
 func TestDatabaseServiceMaster(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
 	defer ts.Close()
@@ -91,6 +211,83 @@ func TestDatabaseServiceMaster(t *testing.T) {
 	compareJson(t, string(json), masterJson)
 }
 
+func TestReleaseMedia(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	release, err := d.Release(context.Background(), 8138518)
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	master, err := d.Master(context.Background(), 718441)
+	if err != nil {
+		t.Fatalf("failed to get master: %s", err)
+	}
+
+	if img, ok := PrimaryImage(release.Images); !ok || img.Type != ImagePrimary || img.Width == 0 {
+		t.Errorf("primary image got=%+v, %t", img, ok)
+	}
+	if len(release.Videos) == 0 || len(master.Videos) == 0 {
+		t.Fatalf("videos got=%d, %d; want some", len(release.Videos), len(master.Videos))
+	}
+	v := release.Videos[0]
+	if v.Length() != 301*time.Second || v.EmbedURL() != "https://www.youtube.com/embed/i4_kwCTrTRs" {
+		t.Errorf("video got=%v, %s; want=5m1s, https://www.youtube.com/embed/i4_kwCTrTRs", v.Length(), v.EmbedURL())
+	}
+	if id := master.Videos[0].YouTubeID(); id != "EKOPq3pDQBM" {
+		t.Errorf("youtube id got=%s; want=EKOPq3pDQBM", id)
+	}
+}
+
+func TestVideoYouTubeID(t *testing.T) {
+	tests := map[string]string{
+		"https://www.youtube.com/watch?v=i4_kwCTrTRs": "i4_kwCTrTRs",
+		"https://youtu.be/i4_kwCTrTRs":                "i4_kwCTrTRs",
+		"https://www.youtube.com/embed/i4_kwCTrTRs":   "i4_kwCTrTRs",
+		"https://vimeo.com/12345":                     "",
+		"":                                            "",
+	}
+	for uri, want := range tests {
+		if id := (Video{URI: uri}).YouTubeID(); id != want {
+			t.Errorf("%s: got=%s; want=%s", uri, id, want)
+		}
+	}
+	if u := (Video{URI: "https://youtu.be/i4_kwCTrTRs"}).EmbedURL(); u != "" {
+		t.Errorf("embed url of video that may not be embedded got=%s; want empty", u)
+	}
+	if _, ok := PrimaryImage(nil); ok {
+		t.Error("primary image of no images found")
+	}
+	if img, _ := PrimaryImage([]Image{{Type: ImageSecondary, URI: "a"}, {Type: ImageSecondary, URI: "b"}}); img.URI != "a" {
+		t.Errorf("primary image got=%s; want=a", img.URI)
+	}
+}
+
+func TestArtistCredit(t *testing.T) {
+	tests := map[string]struct {
+		artists []ArtistSource
+		want    string
+	}{
+		"none":   {nil, ""},
+		"single": {[]ArtistSource{{Name: "Mike Smith (2)", Join: "&"}}, "Mike Smith"},
+		"feat": {[]ArtistSource{
+			{Name: "Artist A", Join: "Feat."},
+			{Name: "Artist B (3)", Anv: "B", Join: ","},
+			{Name: "Artist C", Join: "&"},
+			{Name: "Artist D"},
+		}, "Artist A Feat. B, Artist C & Artist D"},
+		"joins": {[]ArtistSource{{Name: "A", Join: " / "}, {Name: "B", Join: ", with"}, {Name: "C", Join: ""}, {Name: "D"}}, "A / B, with C, D"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ArtistCredit(tt.artists); got != tt.want {
+				t.Errorf("got=%q; want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDatabaseServiceArtist(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
 	defer ts.Close()
@@ -107,3 +304,131 @@ func TestDatabaseServiceArtist(t *testing.T) {
 	}
 	compareJson(t, string(json), artistJson)
 }
+
+func TestDatabaseServiceGroupArtist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	artist, err := d.Artist(context.Background(), 1289)
+	if err != nil {
+		t.Fatalf("failed to get artist: %s", err)
+	}
+
+	json, err := json.Marshal(artist)
+	if err != nil {
+		t.Fatalf("failed to marshal artist: %s", err)
+	}
+	compareJson(t, string(json), groupArtistJson)
+}
+
+func TestDatabaseServiceArtistReleasesSort(t *testing.T) {
+	var sort, order string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sort = r.URL.Query().Get("sort")
+		order = r.URL.Query().Get("sort_order")
+		if _, err := io.WriteString(w, `{"pagination": {}, "releases": []}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	if _, err := d.ArtistReleases(context.Background(), 38661, &Pagination{Sort: "year", SortOrder: "desc"}); err != nil {
+		t.Fatalf("failed to get artist releases: %s", err)
+	}
+	if sort != "year" || order != "desc" {
+		t.Errorf("sort got=%s %s; want=year desc", sort, order)
+	}
+
+	if _, err := d.ArtistReleases(context.Background(), 38661, &Pagination{Sort: "invalid"}); !errors.Is(err, ErrInvalidSortKey) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidSortKey)
+	}
+}
+
+func TestReleasesByRole(t *testing.T) {
+	releases := []ReleaseSource{
+		{ID: 1, Role: RoleMain, Type: ReleaseSourceTypeMaster},
+		{ID: 2, Role: RoleAppearance, Type: ReleaseSourceTypeRelease},
+		{ID: 3, Role: RoleMain, Type: ReleaseSourceTypeRelease},
+		{ID: 4, Role: RoleTrackAppearance, Type: ReleaseSourceTypeRelease},
+	}
+
+	main := ReleasesByRole(releases, RoleMain)
+	if len(main) != 2 || main[0].ID != 1 || main[1].ID != 3 {
+		t.Errorf("main releases got=%v; want=[1 3]", main)
+	}
+	if got := ReleasesByRole(releases, RoleUnofficialRelease); len(got) != 0 {
+		t.Errorf("unofficial releases got=%v; want=[]", got)
+	}
+}
+
+func TestPaginationValidate(t *testing.T) {
+	tests := map[string]struct {
+		pagination *Pagination
+		sorts      map[SortKey]struct{}
+		err        error
+	}{
+		"nil":                {nil, nil, nil},
+		"empty":              {&Pagination{}, nil, nil},
+		"valid":              {&Pagination{Sort: SortYear, SortOrder: SortDesc, Page: 2, PerPage: 100}, validArtistReleasesSort, nil},
+		"invalid sort key":   {&Pagination{Sort: SortPrice}, validArtistReleasesSort, ErrInvalidSortKey},
+		"unsortable":         {&Pagination{Sort: SortYear}, nil, ErrInvalidSortKey},
+		"invalid sort order": {&Pagination{SortOrder: "descending"}, validArtistReleasesSort, ErrInvalidSortOrder},
+		"negative page":      {&Pagination{Page: -1}, nil, ErrInvalidPagination},
+		"per page too big":   {&Pagination{PerPage: 101}, nil, nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tt.pagination.validate(tt.sorts); !errors.Is(err, tt.err) {
+				t.Errorf("err got=%v; want=%v", err, tt.err)
+			}
+		})
+	}
+
+	err := (&Pagination{Sort: "invalid"}).validate(validArtistReleasesSort)
+	if want := `discogs error: invalid sort key: "invalid", expected one of format, title, year`; err == nil || err.Error() != want {
+		t.Errorf("err got=%v; want=%s", err, want)
+	}
+}
+
+func TestPaginationParams(t *testing.T) {
+	tests := map[string]struct {
+		pagination *Pagination
+		want       string
+	}{
+		"nil":                {nil, "page=1&per_page=50"},
+		"empty":              {&Pagination{}, "page=1&per_page=50"},
+		"page":               {&Pagination{Page: 3, PerPage: 25}, "page=3&per_page=25"},
+		"per page too big":   {&Pagination{PerPage: 500}, "page=1&per_page=500"},
+		"negative per page":  {&Pagination{PerPage: -5}, "page=1&per_page=1"},
+		"default sort order": {&Pagination{Sort: SortYear}, "page=1&per_page=50&sort=year&sort_order=asc"},
+		"sort order":         {&Pagination{Sort: SortYear, SortOrder: SortDesc}, "page=1&per_page=50&sort=year&sort_order=desc"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.pagination.params().Encode(); got != tt.want {
+				t.Errorf("params got=%q; want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginatedEndpointsValidateSort(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	if _, err := d.LabelReleases(ctx, 1, &Pagination{Sort: SortYear}); !errors.Is(err, ErrInvalidSortKey) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidSortKey)
+	}
+	if _, err := d.MasterVersions(ctx, 718441, &Pagination{Sort: SortYear}); !errors.Is(err, ErrInvalidSortKey) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidSortKey)
+	}
+	if _, err := d.Wantlist(ctx, testUsername, &Pagination{SortOrder: "up"}); !errors.Is(err, ErrInvalidSortOrder) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidSortOrder)
+	}
+}
@@ -0,0 +1,132 @@
+package discogs
+
+import (
+	"context"
+	"sort"
+)
+
+// DealOptions describes the marketplace offers WantlistDeals looks for.
+type DealOptions struct {
+	// MaxPrice is the highest price to report offers at, inclusive. Its currency defaults to USD.
+	MaxPrice Price
+	// Sellers lists the sellers whose inventories are searched for listings of the wanted releases. Without sellers
+	// the lowest price of every wanted release across the whole marketplace is checked instead, with one request per
+	// release.
+	Sellers []string
+	// Converter converts the prices of listings in other currencies than MaxPrice's. Without it such listings are
+	// skipped. It's only used with Sellers, as the marketplace statistics are requested in MaxPrice's currency.
+	Converter *CurrencyConverter
+}
+
+// Deal is a release in a wantlist that's for sale at or below the maximum price.
+type Deal struct {
+	Want Want
+	// Price is the lowest price the release is offered at, in the currency of the maximum price.
+	Price Price
+	// ForSale is the number of listings of the release across the marketplace when matching against the marketplace
+	// statistics, or else the number of matching listings.
+	ForSale int
+	// Listings are the matching listings of the sellers, cheapest first. It's empty when matching against the
+	// marketplace statistics.
+	Listings []MarketplaceListing
+}
+
+// WantlistDeals cross-references the user's wantlist with the marketplace and returns the wanted releases for sale at
+// or below the maximum price, in wantlist order.
+func WantlistDeals(ctx context.Context, d Discogs, username string, o DealOptions, opts ...RequestOption) ([]Deal, error) {
+	cur, err := currency(o.MaxPrice.Currency)
+	if err != nil {
+		return nil, err
+	}
+	max := Price{Amount: o.MaxPrice.Amount, Currency: cur}
+
+	wants, err := AllWantlist(ctx, d, username, nil, 0, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(o.Sellers) == 0 {
+		return statisticsDeals(ctx, d, wants, max, opts)
+	}
+	return listingDeals(ctx, d, wants, max, o, opts)
+}
+
+// statisticsDeals matches the wants against the marketplace statistics of their releases.
+func statisticsDeals(ctx context.Context, s MarketPlaceService, wants []Want, max Price, opts []RequestOption) ([]Deal, error) {
+	statsOpts := append(append([]RequestOption{}, opts...), WithCurrency(max.Currency))
+
+	var deals []Deal
+	for _, w := range wants {
+		stats, err := s.ReleaseStatistics(ctx, w.ID, statsOpts...)
+		if err != nil {
+			return deals, err
+		}
+		if stats.Blocked || stats.ForSale == 0 || stats.LowestPrice == nil {
+			continue
+		}
+		cmp, err := stats.LowestPrice.Compare(max)
+		if err != nil {
+			return deals, err
+		}
+		if cmp <= 0 {
+			deals = append(deals, Deal{Want: w, Price: *stats.LowestPrice, ForSale: stats.ForSale})
+		}
+	}
+	return deals, nil
+}
+
+// listingDeals matches the wants against the listings in the inventories of the sellers.
+func listingDeals(ctx context.Context, s MarketPlaceService, wants []Want, max Price, o DealOptions, opts []RequestOption) ([]Deal, error) {
+	type offer struct {
+		listing MarketplaceListing
+		price   Price
+	}
+	offers := make(map[int][]offer, len(wants))
+	for _, w := range wants {
+		offers[w.ID] = nil
+	}
+
+	for _, seller := range o.Sellers {
+		it := InventoryIter(ctx, s, seller, &Pagination{PerPage: maxPerPage}, opts...)
+		for it.Next() {
+			l := it.Item()
+			if _, ok := offers[l.Release.ID]; !ok || l.Status != "For Sale" {
+				continue
+			}
+
+			price := l.Price
+			if price.Currency != max.Currency {
+				if o.Converter == nil {
+					continue
+				}
+				var err error
+				if price, err = o.Converter.Convert(ctx, price, max.Currency); err != nil {
+					return nil, err
+				}
+			}
+			if price.Amount <= max.Amount {
+				offers[l.Release.ID] = append(offers[l.Release.ID], offer{listing: l, price: price})
+			}
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var deals []Deal
+	for _, w := range wants {
+		found := offers[w.ID]
+		if len(found) == 0 {
+			continue
+		}
+		sort.SliceStable(found, func(i, j int) bool { return found[i].price.Amount < found[j].price.Amount })
+
+		deal := Deal{Want: w, Price: found[0].price, ForSale: len(found)}
+		for _, f := range found {
+			deal.Listings = append(deal.Listings, f.listing)
+		}
+		deals = append(deals, deal)
+		// a release wanted twice is reported once
+		delete(offers, w.ID)
+	}
+	return deals, nil
+}
@@ -0,0 +1,89 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// DealsServer serves a wantlist of releases 1, 2 and 3, the marketplace statistics of those releases and the
+// inventory of test_seller, which offers release 1 twice, release 2 in EUR and release 4, which isn't wanted.
+func DealsServer(w http.ResponseWriter, r *http.Request) {
+	var body string
+	switch r.URL.Path {
+	case "/users/test_user/wants":
+		body = `{"pagination": {"page": 1, "pages": 1, "per_page": 50, "items": 3, "urls": {}}, "wants": [{"id": 1}, {"id": 2}, {"id": 3}]}`
+	case "/marketplace/stats/1":
+		body = `{"lowest_price": {"currency": "` + r.URL.Query().Get("curr_abbr") + `", "value": 9.5}, "num_for_sale": 4, "blocked_from_sale": false}`
+	case "/marketplace/stats/2":
+		body = `{"lowest_price": {"currency": "` + r.URL.Query().Get("curr_abbr") + `", "value": 25}, "num_for_sale": 1, "blocked_from_sale": false}`
+	case "/marketplace/stats/3":
+		body = `{"lowest_price": null, "num_for_sale": 0, "blocked_from_sale": false}`
+	case "/users/test_seller/inventory":
+		body = `{"pagination": {"page": 1, "pages": 1, "per_page": 100, "items": 4, "urls": {}}, "listings": [
+			{"id": 11, "status": "For Sale", "price": {"currency": "USD", "value": 15}, "release": {"id": 1}},
+			{"id": 12, "status": "For Sale", "price": {"currency": "USD", "value": 8}, "release": {"id": 1}},
+			{"id": 13, "status": "For Sale", "price": {"currency": "EUR", "value": 5}, "release": {"id": 2}},
+			{"id": 14, "status": "For Sale", "price": {"currency": "USD", "value": 1}, "release": {"id": 4}}]}`
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if _, err := io.WriteString(w, body); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func TestWantlistDealsStatistics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DealsServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	deals, err := WantlistDeals(context.Background(), d, "test_user", DealOptions{MaxPrice: NewPrice(10, "GBP")})
+	if err != nil {
+		t.Fatalf("failed to get deals: %s", err)
+	}
+	if len(deals) != 1 || deals[0].Want.ID != 1 || deals[0].ForSale != 4 {
+		t.Fatalf("deals got=%+v; want release 1", deals)
+	}
+	if want := NewPrice(9.5, "GBP"); deals[0].Price != want {
+		t.Errorf("price got=%s; want=%s", deals[0].Price, want)
+	}
+
+	if _, err := WantlistDeals(context.Background(), d, "test_user", DealOptions{MaxPrice: NewPrice(10, "XXX")}); err != ErrCurrencyNotSupported {
+		t.Errorf("err got=%v; want=%s", err, ErrCurrencyNotSupported)
+	}
+}
+
+func TestWantlistDealsSellers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DealsServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	o := DealOptions{MaxPrice: NewPrice(20, "USD"), Sellers: []string{"test_seller"}}
+	deals, err := WantlistDeals(ctx, d, "test_user", o)
+	if err != nil {
+		t.Fatalf("failed to get deals: %s", err)
+	}
+	if len(deals) != 1 || deals[0].Want.ID != 1 || deals[0].ForSale != 2 {
+		t.Fatalf("deals got=%+v; want release 1", deals)
+	}
+	if deals[0].Price != NewPrice(8, "USD") || deals[0].Listings[0].ID != 12 || deals[0].Listings[1].ID != 11 {
+		t.Errorf("deal got=%s %+v; want 8.00 USD, listings 12 and 11", deals[0].Price, deals[0].Listings)
+	}
+
+	o.Converter = NewCurrencyConverter(RateSourceFunc(func(ctx context.Context, from, to string) (float64, error) {
+		return 2, nil
+	}))
+	deals, err = WantlistDeals(ctx, d, "test_user", o)
+	if err != nil {
+		t.Fatalf("failed to get deals: %s", err)
+	}
+	if len(deals) != 2 || deals[1].Want.ID != 2 || deals[1].Price != NewPrice(10, "USD") {
+		t.Errorf("deals got=%+v; want releases 1 and 2", deals)
+	}
+}
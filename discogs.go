@@ -1,13 +1,23 @@
 package discogs
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -16,26 +26,88 @@ const (
 
 // Options is a set of options to use discogs API client
 type Options struct {
-	// Discogs API endpoint (optional).
+	// Discogs API endpoint (optional), e.g. a proxy or a local server for testing. It may include a path prefix.
 	URL string
+	// Discogs API endpoint as a parsed URL (optional), exclusive with URL.
+	BaseURL *url.URL
+	// Endpoints replacing the client's for the requests of single services (optional), e.g. to target a recorder or
+	// mock server for some of them only.
+	ServiceURLs ServiceURLs
 	// Currency to use (optional, default is USD).
 	Currency string
-	// UserAgent to to call discogs api with.
+	// UserAgent to to call discogs api with, e.g. as returned by the UserAgent function.
 	UserAgent string
+	// Reject user-agents CheckUserAgent reports, such as the defaults of HTTP libraries, which Discogs throttles
+	// (optional).
+	StrictUserAgent bool
 	// Token provided by discogs (optional).
 	Token string
-	// HTTP client instance to use for HTTP requests
+	// Consumer key and secret of a Discogs application (optional). They authenticate the application rather than a
+	// user, which is sufficient for search and image access without per-user tokens. Ignored if Token is set.
+	ConsumerKey    string
+	ConsumerSecret string
+	// OAuth credentials of the user to make requests for (optional). Ignored if Token is set.
+	OAuth *OAuthCredentials
+	// HTTP client instance to use for HTTP requests (optional). By default a client configured by Transport is used.
 	Client *http.Client
+	// Settings of the HTTP client used if Client isn't set (optional). Zero fields take the defaults documented by
+	// TransportOptions.
+	Transport TransportOptions
 	// Rate limit instance to track request rates
 	RateLimit *RateLimit
+	// Registry of rate limits per token (optional). When set and RateLimit isn't, the client tracks its request
+	// rates with the registry's RateLimit for Token, or for ConsumerKey if it authenticates with that, and is returned
+	// wrapped with RateLimited, so all clients created with the registry and the same credentials are paced together.
+	RateLimits *RateLimitRegistry
+	// Cache to store responses for conditional requests (optional). When set, responses carrying an ETag or
+	// Last-Modified header are stored and later requests for the same URL with the same credentials are revalidated
+	// with If-None-Match and If-Modified-Since, serving the stored response if Discogs replies that it hasn't been
+	// modified.
+	ConditionalCache Cache
+	// Middleware invoked around every API request (optional), the first one being the outermost.
+	Middleware []Middleware
+	// Callback invoked before every API request is sent (optional).
+	OnRequest RequestHook
+	// Callback invoked after every API request (optional).
+	OnResponse ResponseHook
+	// Tracer provider used to record a span for every API request (optional).
+	TracerProvider trace.TracerProvider
+	// Maximum time each API request may take (optional). WithTimeout overrides it for a single request.
+	RequestTimeout time.Duration
+	// Maximum size of API response bodies in bytes (optional). Larger responses fail with ErrResponseTooLarge
+	// instead of being decoded. Images aren't limited.
+	MaxResponseSize int64
+	// Fail requests whose responses contain fields the structs don't map with ErrUnknownField (optional). This
+	// helps to detect data Discogs added that the library drops. WithStrictDecoding enables it for a single request.
+	StrictDecoding bool
+	// Request the maximum of 100 items per page from paginated endpoints, whatever Pagination.PerPage requests
+	// (optional). Full syncs make half the requests they make with the default of 50 items per page. WithBulk
+	// enables it for a single request.
+	Bulk bool
+	// Function called with warnings about requests the client adjusted, e.g. log.Printf (optional).
+	Warnf func(format string, args ...interface{})
 }
 
 // Discogs is an interface for making Discogs API requests.
 type Discogs interface {
 	CollectionService
 	DatabaseService
+	FetchService
+	ImagesService
+	InventoryExportService
+	InventoryUploadService
 	MarketPlaceService
 	SearchService
+	WantlistService
+
+	// WithToken returns a client making requests with the given user token instead of the client's credentials.
+	// The derived client shares the HTTP client, middleware, hooks, rate limit and caches of the client, so
+	// deriving a client per user is cheap. Clients created with a RateLimitRegistry are paced by the rate limit of
+	// the new token.
+	WithToken(token string) Discogs
+	// WithOAuth returns a client making requests with the given OAuth credentials instead of the client's
+	// credentials, sharing everything else like WithToken.
+	WithOAuth(creds OAuthCredentials) Discogs
 }
 
 type discogs struct {
@@ -43,48 +115,135 @@ type discogs struct {
 	DatabaseService
 	SearchService
 	MarketPlaceService
+	ImagesService
+	InventoryExportService
+	InventoryUploadService
+	WantlistService
+	FetchService
+
+	// options and roundTrip are retained to derive clients with other credentials.
+	options   Options
+	roundTrip RoundTripFunc
 }
 
-type requestFunc func(ctx context.Context, path string, params url.Values, resp interface{}) error
+type requestFunc func(ctx context.Context, path string, params url.Values, resp interface{}, opts ...RequestOption) error
 
-// New returns a new discogs API client.
+// New returns a new discogs API client, or an error if the options are invalid as reported by Options.Validate.
 func New(o *Options) (Discogs, error) {
-	header := &http.Header{}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
 
-	if o == nil || o.UserAgent == "" {
-		return nil, ErrUserAgentInvalid
+	if o.URL == "" && o.BaseURL == nil {
+		o.URL = discogsAPI
 	}
 
-	header.Add("User-Agent", o.UserAgent)
+	client := o.Client
+	if client == nil {
+		client = httpClient(o.Transport)
+	}
 
-	cur, err := currency(o.Currency)
-	if err != nil {
-		return nil, err
+	var middleware []Middleware
+	if o.TracerProvider != nil {
+		middleware = append(middleware, tracing(o.TracerProvider))
+	}
+	middleware = append(middleware, o.Middleware...)
+	if o.OnRequest != nil || o.OnResponse != nil {
+		middleware = append(middleware, hooks(o.OnRequest, o.OnResponse))
 	}
 
-	// set token, it's required for some queries like search
-	if o.Token != "" {
+	return newClient(*o, chain(client.Do, middleware)), nil
+}
+
+// newClient returns a client for the validated options o sending its requests with roundTrip.
+func newClient(o Options, roundTrip RoundTripFunc) Discogs {
+	header := &http.Header{}
+	header.Add("User-Agent", o.UserAgent)
+	header.Add("Accept-Encoding", acceptEncoding)
+
+	cur, _ := currency(o.Currency)
+
+	// set credentials, they're required for some queries like search
+	var oauth *OAuthCredentials
+	var credential string
+	switch {
+	case o.Token != "":
 		header.Add("Authorization", "Discogs token="+o.Token)
+		credential = "token " + o.Token
+	case o.OAuth != nil:
+		oauth = o.OAuth
+		credential = "oauth " + o.OAuth.ConsumerKey + "&" + o.OAuth.Token
+	case o.ConsumerKey != "":
+		header.Add("Authorization", "Discogs key="+o.ConsumerKey+", secret="+o.ConsumerSecret)
+		credential = "key " + o.ConsumerKey
 	}
 
-	if o.URL == "" {
-		o.URL = discogsAPI
+	rl := o.RateLimit
+	if rl == nil && o.RateLimits != nil {
+		switch {
+		case oauth != nil:
+			rl = o.RateLimits.Get(oauth.Token)
+		case o.Token == "" && o.ConsumerKey != "":
+			rl = o.RateLimits.GetConsumerKey(o.ConsumerKey)
+		default:
+			rl = o.RateLimits.Get(o.Token)
+		}
+	}
+	t := &transport{
+		roundTrip:   roundTrip,
+		header:      header,
+		oauth:       oauth,
+		credential:  credential,
+		rl:          rl,
+		conditional: o.ConditionalCache,
+		timeout:     o.RequestTimeout,
+		maxSize:     o.MaxResponseSize,
+		strict:      o.StrictDecoding,
+		bulk:        o.Bulk,
+		warnf:       o.Warnf,
 	}
 
-	client := o.Client
-	if client == nil {
-		client = &http.Client{}
+	base := o.URL
+	if o.BaseURL != nil {
+		base = o.BaseURL.String()
+	}
+	serviceURL := func(override string) string {
+		if override != "" {
+			return override
+		}
+		return base
 	}
-	req := func(ctx context.Context, path string, params url.Values, resp interface{}) error {
-		return request(ctx, client, header, o.RateLimit, path, params, resp)
+	var d Discogs = discogs{
+		CollectionService:      newCollectionService(t.request, t.call, joinURL(serviceURL(o.ServiceURLs.Collection), "users")),
+		DatabaseService:        newDatabaseService(t.request, serviceURL(o.ServiceURLs.Database), cur),
+		SearchService:          newSearchService(t.request, joinURL(serviceURL(o.ServiceURLs.Search), "database/search")),
+		MarketPlaceService:     newMarketPlaceService(t.request, serviceURL(o.ServiceURLs.Marketplace), cur),
+		ImagesService:          newImagesService(t.download, base),
+		InventoryExportService: newInventoryExportService(t.request, t.post, t.download, serviceURL(o.ServiceURLs.Marketplace)),
+		InventoryUploadService: newInventoryUploadService(t.request, t.upload, serviceURL(o.ServiceURLs.Marketplace)),
+		WantlistService:        newWantlistService(t.request, t.call, joinURL(serviceURL(o.ServiceURLs.Wantlist), "users")),
+		FetchService:           newFetchService(t.request, t.call, base),
+		options:                o,
+		roundTrip:              roundTrip,
 	}
+	if o.RateLimit == nil && o.RateLimits != nil {
+		d = rateLimited(d, rl, true)
+	}
+	return d
+}
 
-	return discogs{
-		newCollectionService(req, o.URL+"/users"),
-		newDatabaseService(req, o.URL, cur),
-		newSearchService(req, o.URL+"/database/search"),
-		newMarketPlaceService(req, o.URL+"/marketplace", cur),
-	}, nil
+func (d discogs) WithToken(token string) Discogs {
+	o := d.options
+	o.Token = token
+	o.OAuth = nil
+	return newClient(o, d.roundTrip)
+}
+
+func (d discogs) WithOAuth(creds OAuthCredentials) Discogs {
+	o := d.options
+	o.Token = ""
+	o.OAuth = &creds
+	return newClient(o, d.roundTrip)
 }
 
 // currency validates currency for marketplace data.
@@ -101,41 +260,460 @@ func currency(c string) (string, error) {
 	}
 }
 
-func request(ctx context.Context, client *http.Client, header *http.Header, rl *RateLimit, path string, params url.Values, resp interface{}) error {
-	r, err := http.NewRequestWithContext(ctx, "GET", path+"?"+params.Encode(), nil)
+// transport performs the HTTP requests of a client.
+type transport struct {
+	roundTrip   RoundTripFunc
+	header      *http.Header
+	oauth       *OAuthCredentials
+	rl          *RateLimit
+	conditional Cache
+	credential  string // identifies the credentials of the client, empty if it has none
+	timeout     time.Duration
+	maxSize     int64
+	strict      bool
+	bulk        bool
+	warnf       func(format string, args ...interface{})
+}
+
+// conditionalEntry is a response stored for conditional requests.
+type conditionalEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// request performs a GET request and decodes the JSON response into resp.
+func (t *transport) request(ctx context.Context, path string, params url.Values, resp interface{}, opts ...RequestOption) error {
+	return t.call(ctx, http.MethodGet, path, params, nil, resp, opts...)
+}
+
+// call performs a request with the JSON encoding of body, if not nil, and decodes the JSON response into resp, if not
+// nil and the response has content. Only GET requests are revalidated with the conditional cache.
+func (t *transport) call(ctx context.Context, method, path string, params url.Values, body, resp interface{}, opts ...RequestOption) error {
+	o := t.requestOptions(opts)
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	var content io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(b)
+	}
+	r, err := t.newRequest(ctx, method, path, params, content, o)
 	if err != nil {
 		return err
 	}
-	r.Header = *header
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
 
-	response, err := client.Do(r)
+	conditional := t.conditional != nil && method == http.MethodGet
+	var key string
+	var cached *conditionalEntry
+	if conditional {
+		key = t.conditionalKey(r, o)
+		cached = t.conditionalEntry(key)
+		if cached != nil {
+			if cached.ETag != "" {
+				r.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				r.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+	}
+
+	response, err := t.send(r, o)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if rl != nil {
-		total, _ := strconv.Atoi(response.Header.Get("X-Discogs-Ratelimit"))               // The total number of requests you can make in a one minute window.
-		used, _ := strconv.Atoi(response.Header.Get("X-Discogs-Ratelimit-Used"))           // The number of requests you’ve made in your existing rate limit window.
-		remaining, _ := strconv.Atoi(response.Header.Get("X-Discogs-Ratelimit-Remaining")) // The number of remaining requests you are able to make in the existing rate limit window.
-		rl.Update(total, used, remaining)
+	if response.StatusCode == http.StatusNotModified {
+		// without a stored response there's nothing to decode
+		if cached == nil {
+			return &StatusError{StatusCode: response.StatusCode, Status: response.Status}
+		}
+		if o.raw != nil {
+			if _, err := o.raw.Write(cached.Body); err != nil {
+				return err
+			}
+		}
+		return decode(cached.Body, resp, o.strict)
+	}
+
+	var responseBody io.Reader = response.Body
+	if t.maxSize > 0 {
+		responseBody = &limitedReader{r: responseBody, n: t.maxSize}
+	}
+	if o.raw != nil {
+		responseBody = io.TeeReader(responseBody, o.raw)
+	}
+
+	// Bodies needed in full, to be stored for conditional requests or checked for unknown fields, and small bodies of a
+	// known length are read into pooled buffers. Decompressed bodies, which Discogs sends as gzip is requested, have no
+	// known length. All others are decoded as they're read, so that large pages aren't held in memory twice.
+	store := conditional && (response.Header.Get("ETag") != "" || response.Header.Get("Last-Modified") != "")
+	if store || o.strict || (response.ContentLength >= 0 && response.ContentLength <= maxPooledBuffer) {
+		buf := getBuffer()
+		defer putBuffer(buf)
+		if _, err := buf.ReadFrom(responseBody); err != nil {
+			return transportError(err)
+		}
+		if store {
+			// the buffer is reused, so the stored body is a copy
+			t.storeConditionalEntry(key, response.Header, append([]byte(nil), buf.Bytes()...))
+		}
+		if resp == nil || response.StatusCode == http.StatusNoContent {
+			return nil
+		}
+		return decode(buf.Bytes(), resp, o.strict)
+	}
+
+	stream := &errorReader{r: responseBody}
+	if resp != nil && response.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(stream).Decode(resp); err != nil {
+			if stream.err != nil {
+				return transportError(stream.err)
+			}
+			return err
+		}
+	}
+	// the remainder of the body is read for WithRawCapture and so the connection can be reused
+	if _, err := io.Copy(ioutil.Discard, stream); err != nil {
+		return transportError(err)
 	}
+	return nil
+}
+
+// errorReader reads from r and records the first error other than io.EOF, telling read errors from those of decoding.
+type errorReader struct {
+	r   io.Reader
+	err error
+}
+
+func (e *errorReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err != nil && err != io.EOF && e.err == nil {
+		e.err = err
+	}
+	return n, err
+}
+
+// decode decodes the JSON response b into v, failing with ErrUnknownField if strict and b contains fields v doesn't
+// map.
+func decode(b []byte, v interface{}, strict bool) error {
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	if !strict {
+		return nil
+	}
+	paths, err := unknownFields(b, v)
+	if err != nil {
+		return err
+	}
+	if len(paths) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownField, strings.Join(paths, ", "))
+	}
+	return nil
+}
+
+// maxPooledBuffer is the largest response of a known length read into a pooled buffer, and the capacity above which
+// buffers aren't returned to the pool, so single large responses such as big collections don't keep their memory in
+// use.
+const maxPooledBuffer = 1 << 20
+
+// buffers holds the buffers responses are read into, reused across requests as the allocations of reading responses
+// otherwise dominate high request volumes.
+var buffers = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	return buffers.Get().(*bytes.Buffer)
+}
+
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBuffer {
+		return
+	}
+	b.Reset()
+	buffers.Put(b)
+}
+
+// limitedReader reads from r until n bytes have been read, failing with ErrResponseTooLarge if there are more.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// read a single byte to tell a body of exactly the maximum size from a larger one
+		var b [1]byte
+		n, err := l.r.Read(b[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// conditionalKey returns the key of the response to r in the conditional cache. Clients derived with WithToken or
+// WithOAuth share the cache, so responses are stored per credential as well as per URL: one user's private data is
+// never served to another. Requests without credentials are keyed by their URL alone.
+func (t *transport) conditionalKey(r *http.Request, o *requestOptions) string {
+	credential := t.credential
+	if auth := o.header.Get("Authorization"); auth != "" {
+		credential = auth
+	}
+	if credential == "" {
+		return r.URL.String()
+	}
+	sum := sha256.Sum256([]byte(credential))
+	return r.URL.String() + "#" + hex.EncodeToString(sum[:])
+}
+
+func (t *transport) conditionalEntry(key string) *conditionalEntry {
+	b, ok := t.conditional.Get(key)
+	if !ok {
+		return nil
+	}
+	var entry conditionalEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+func (t *transport) storeConditionalEntry(key string, header http.Header, body []byte) {
+	entry := conditionalEntry{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Body:         body,
+	}
+	if entry.ETag == "" && entry.LastModified == "" {
+		return
+	}
+	if b, err := json.Marshal(entry); err == nil {
+		t.conditional.Set(key, b, 0)
+	}
+}
+
+// download performs a GET request and returns the response body, which the caller must close.
+func (t *transport) download(ctx context.Context, path string, opts ...RequestOption) (io.ReadCloser, error) {
+	o := t.requestOptions(opts)
+	cancel := context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	}
+
+	r, err := t.newRequest(ctx, http.MethodGet, path, nil, nil, o)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	response, err := t.send(r, o)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cancelReadCloser{ReadCloser: response.Body, cancel: cancel}, nil
+}
+
+// upload performs a POST request sending the file read from body as the multipart form field "upload" and returns
+// the Location header of the response, which points to the created resource.
+func (t *transport) upload(ctx context.Context, path, filename string, body io.Reader, opts ...RequestOption) (string, error) {
+	// the form is buffered to send it with its length, which Discogs requires
+	var form bytes.Buffer
+	mw := multipart.NewWriter(&form)
+	fw, err := mw.CreateFormFile("upload", filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(fw, body); err != nil {
+		return "", err
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+	return t.post(ctx, path, mw.FormDataContentType(), &form, opts...)
+}
+
+// post sends a POST request with the body of the content type, if any, and returns the Location header of the
+// response, which Discogs sets to the resource created by the request.
+func (t *transport) post(ctx context.Context, path, contentType string, body io.Reader, opts ...RequestOption) (string, error) {
+	o := t.requestOptions(opts)
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	r, err := t.newRequest(ctx, http.MethodPost, path, nil, body, o)
+	if err != nil {
+		return "", err
+	}
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := t.send(r, o)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if _, err := io.Copy(ioutil.Discard, response.Body); err != nil {
+		return "", transportError(err)
+	}
+	return response.Header.Get("Location"), nil
+}
+
+// cancelReadCloser cancels the context of a request when its response body is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelReadCloser) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// requestOptions returns the options of a request, defaulting to the client's timeout.
+func (t *transport) requestOptions(opts []RequestOption) *requestOptions {
+	o := newRequestOptions(opts)
+	if o.timeout <= 0 {
+		o.timeout = t.timeout
+	}
+	o.strict = o.strict || t.strict
+	o.bulk = o.bulk || t.bulk
+	return o
+}
+
+// perPage returns params requesting the number of items per page Discogs allows, the maximum if o requests bulk
+// mode. Requests for more than the maximum are warned about, as Discogs would return its default of 50 items.
+func (t *transport) perPage(path string, params url.Values, o *requestOptions) url.Values {
+	n, err := strconv.Atoi(params.Get("per_page"))
+	switch {
+	case err != nil:
+		return params
+	case n > maxPerPage:
+		if t.warnf != nil {
+			endpoint := ""
+			if u, err := url.Parse(path); err == nil {
+				endpoint = Endpoint(u.Path)
+			}
+			t.warnf("discogs: %d items per page requested from %s, limiting to the maximum of %d", n, endpoint, maxPerPage)
+		}
+	case o.bulk && n != maxPerPage:
+	default:
+		return params
+	}
+
+	// the params may be the caller's
+	c := make(url.Values, len(params))
+	for key, values := range params {
+		c[key] = values
+	}
+	c.Set("per_page", strconv.Itoa(maxPerPage))
+	return c
+}
+
+// newRequest returns a request for path with the client's headers and those set by o.
+func (t *transport) newRequest(ctx context.Context, method, path string, params url.Values, body io.Reader, o *requestOptions) (*http.Request, error) {
+	if len(params) > 0 {
+		path = withParams(path, t.perPage(path, params, o))
+	}
+	r, err := http.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	r.Header = t.header.Clone()
+	if o.anonymous {
+		r.Header.Del("Authorization")
+	} else if t.oauth != nil {
+		r.Header.Set("Authorization", t.oauth.authorization())
+	}
+	for key, values := range o.header {
+		r.Header[key] = values
+	}
+	return r, nil
+}
+
+// send sends the request, unless o requests a dry run.
+func (t *transport) send(r *http.Request, o *requestOptions) (*http.Response, error) {
+	if o.dryRun != nil {
+		o.dryRun(r)
+		return nil, ErrDryRun
+	}
+	return t.do(r)
+}
+
+// do sends the request and maps unsuccessful responses to errors. On success the caller is responsible for
+// closing the response body.
+func (t *transport) do(r *http.Request) (*http.Response, error) {
+	response, err := t.roundTrip(r)
+	if err != nil {
+		return nil, transportError(err)
+	}
+	if err := decompress(response); err != nil {
+		return nil, transportError(err)
+	}
+
+	// only API responses carry the rate limiting headers; the image hosts don't and leave the rate limit as it is
+	if t.rl != nil && response.Header.Get("X-Discogs-Ratelimit") != "" {
+		s := rateLimitSnapshot(response.Header)
+		t.rl.Update(s.Total, s.Used, s.Remaining)
+	}
+
+	if response.StatusCode/100 != 2 && response.StatusCode != http.StatusNotModified {
+		defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
 		switch response.StatusCode {
 		case http.StatusUnauthorized:
-			return ErrUnauthorized
+			return nil, ErrUnauthorized
+		case http.StatusNotFound:
+			if msg := errorMessage(response.Body); msg != "" {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, msg)
+			}
+			return nil, ErrNotFound
 		case http.StatusTooManyRequests:
-			return ErrTooManyRequests
+			return nil, ErrTooManyRequests
+		case http.StatusUnprocessableEntity:
+			return nil, parseValidationError(response.Body)
 		default:
-			return fmt.Errorf("unknown error: %s", response.Status)
+			return nil, &StatusError{StatusCode: response.StatusCode, Status: response.Status}
 		}
 	}
 
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return err
-	}
+	return response, nil
+}
 
-	return json.Unmarshal(body, &resp)
+// errorMessage extracts the message from a Discogs error response body,
+// e.g. {"message": "Release not found."}. It returns an empty string if the
+// body can't be parsed.
+func errorMessage(body io.Reader) string {
+	var e struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(body).Decode(&e); err != nil {
+		return ""
+	}
+	return e.Message
 }
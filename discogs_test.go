@@ -1,7 +1,19 @@
 package discogs
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -44,23 +56,95 @@ func TestNew(t *testing.T) {
 		"incorrect user-agent": {&Options{
 			UserAgent: "",
 			Currency:  "USD",
-		}, ErrUserAgentInvalid},
+		}, ErrInvalidUserAgent},
+		"generic user-agent": {&Options{
+			UserAgent:       "Go-http-client/1.1",
+			StrictUserAgent: true,
+		}, ErrInvalidUserAgent},
+		"generic user-agent not strict": {&Options{
+			UserAgent: "Go-http-client/1.1",
+		}, nil},
 		"incorrect currency": {&Options{
 			UserAgent: testUserAgent,
 			Currency:  "RUR",
 		}, ErrCurrencyNotSupported},
+		"consumer key and secret": {&Options{
+			UserAgent:      testUserAgent,
+			ConsumerKey:    "some key",
+			ConsumerSecret: "some secret",
+		}, nil},
+		"consumer key without secret": {&Options{
+			UserAgent:   testUserAgent,
+			ConsumerKey: "some key",
+		}, ErrInvalidCredentials},
+		"token and oauth": {&Options{
+			UserAgent: testUserAgent,
+			Token:     "some token",
+			OAuth:     &OAuthCredentials{ConsumerKey: "some key", Token: "some token"},
+		}, ErrInvalidCredentials},
+		"incomplete oauth": {&Options{
+			UserAgent: testUserAgent,
+			OAuth:     &OAuthCredentials{ConsumerKey: "some key"},
+		}, ErrInvalidCredentials},
+		"invalid url": {&Options{
+			UserAgent: testUserAgent,
+			URL:       "api.discogs.com",
+		}, ErrInvalidURL},
+		"url and base url": {&Options{
+			UserAgent: testUserAgent,
+			URL:       "https://api.discogs.com",
+			BaseURL:   &url.URL{Scheme: "https", Host: "api.discogs.com"},
+		}, ErrInvalidOptions},
+		"invalid service url": {&Options{
+			UserAgent:   testUserAgent,
+			ServiceURLs: ServiceURLs{Wantlist: "/wants"},
+		}, ErrInvalidURL},
+		"rate limit and registry": {&Options{
+			UserAgent:  testUserAgent,
+			RateLimit:  &RateLimit{},
+			RateLimits: NewRateLimitRegistry(),
+		}, ErrInvalidOptions},
+		"negative timeout": {&Options{
+			UserAgent:      testUserAgent,
+			RequestTimeout: -time.Second,
+		}, ErrInvalidOptions},
 	}
 
 	for name := range tests {
 		tt := tests[name]
 		t.Run(name, func(t *testing.T) {
-			if _, err := New(tt.options); err != tt.err {
+			if _, err := New(tt.options); !errors.Is(err, tt.err) {
 				t.Errorf("err got=%s; want=%s", err, tt.err)
 			}
 		})
 	}
 }
 
+func TestConsumerKeyAuth(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, ConsumerKey: "some key", ConsumerSecret: "some secret"})
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if want := "Discogs key=some key, secret=some secret"; auth != want {
+		t.Errorf("authorization got=%s; want=%s", auth, want)
+	}
+
+	d = initDiscogsClient(t, &Options{URL: ts.URL, Token: "some token", ConsumerKey: "some key", ConsumerSecret: "some secret"})
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if want := "Discogs token=some token"; auth != want {
+		t.Errorf("authorization got=%s; want=%s", auth, want)
+	}
+}
+
 func TestCurrency(t *testing.T) {
 	tests := []struct {
 		currency string
@@ -92,3 +176,213 @@ func TestCurrency(t *testing.T) {
 		}
 	}
 }
+
+func TestConditionalRequests(t *testing.T) {
+	const etag = `"8138518-1"`
+	var full, notModified int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, `{"id": 8138518, "title": "Elephant Riddim"}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, ConditionalCache: NewLRUCache(10)})
+
+	for i := 0; i < 3; i++ {
+		release, err := d.Release(context.Background(), 8138518)
+		if err != nil {
+			t.Fatalf("failed to get release: %s", err)
+		}
+		if release.Title != "Elephant Riddim" {
+			t.Errorf("title got=%q; want=%q", release.Title, "Elephant Riddim")
+		}
+	}
+
+	if full != 1 || notModified != 2 {
+		t.Errorf("full responses got=%d, not modified got=%d; want=1, 2", full, notModified)
+	}
+}
+
+func TestConditionalRequestsPerCredential(t *testing.T) {
+	// the server answers any matching ETag with 304, so only the keys of the cache tell the users apart
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"1"`)
+		w.WriteHeader(http.StatusOK)
+		if _, err := fmt.Fprintf(w, `{"username": %q}`, r.Header.Get("Authorization")); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Token: "a", ConditionalCache: NewLRUCache(10)})
+	clients := map[string]Discogs{"Discogs token=a": d, "Discogs token=b": d.WithToken("b")}
+	for i := 0; i < 2; i++ {
+		for want, c := range clients {
+			var v struct {
+				Username string `json:"username"`
+			}
+			if err := c.Do(context.Background(), http.MethodGet, "/oauth/identity", nil, nil, &v); err != nil {
+				t.Fatalf("failed to get identity: %s", err)
+			}
+			if v.Username != want {
+				t.Errorf("identity got=%q; want=%q", v.Username, want)
+			}
+		}
+	}
+
+	// a 304 without a stored response fails rather than decoding an empty body
+	ts304 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer ts304.Close()
+	d = initDiscogsClient(t, &Options{URL: ts304.URL, ConditionalCache: NewLRUCache(10)})
+	var statusErr *StatusError
+	if _, err := d.Release(context.Background(), 8138518); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotModified {
+		t.Errorf("err got=%v; want a 304 StatusError", err)
+	}
+}
+
+func TestNewWithRateLimitRegistry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Discogs-Ratelimit", "60")
+		w.Header().Set("X-Discogs-Ratelimit-Used", "1")
+		w.Header().Set("X-Discogs-Ratelimit-Remaining", "59")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, `{"id": 8138518}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	registry := NewRateLimitRegistry()
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Token: "some token", RateLimits: registry})
+	if _, ok := d.(*interceptedDiscogs); !ok {
+		t.Errorf("expected a rate limited client")
+	}
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if total, used, remaining, _ := registry.Get("some token").Get(); total != 60 || used != 1 || remaining != 59 {
+		t.Errorf("rate limit got=%d, %d, %d; want=60, 1, 59", total, used, remaining)
+	}
+	if total, _, _, _ := registry.Get("").Get(); total != 0 {
+		t.Errorf("expected the anonymous rate limit to be untouched")
+	}
+
+	d = initDiscogsClient(t, &Options{URL: ts.URL, ConsumerKey: "some key", ConsumerSecret: "some secret", RateLimits: registry})
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if total, _, _, _ := registry.GetConsumerKey("some key").Get(); total != 60 {
+		t.Errorf("expected the consumer key's rate limit to be updated")
+	}
+	if total, _, _, _ := registry.Get("").Get(); total != 0 {
+		t.Errorf("expected the anonymous rate limit to be untouched by the consumer key")
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	var auth []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Header().Set("X-Discogs-Ratelimit", "60")
+		w.Header().Set("X-Discogs-Ratelimit-Used", "1")
+		w.Header().Set("X-Discogs-Ratelimit-Remaining", "59")
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	requests := 0
+	counter := func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			requests++
+			return next(r)
+		}
+	}
+	registry := NewRateLimitRegistry()
+	base := initDiscogsClient(t, &Options{URL: ts.URL, Token: "base token", RateLimits: registry, Middleware: []Middleware{counter}})
+	d := Retry(Cached(base, NewLRUCache(10), time.Hour), RetryPolicy{})
+	ctx := context.Background()
+
+	user := d.WithToken("user token")
+	if _, err := user.Release(ctx, 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	oauth := d.WithOAuth(OAuthCredentials{ConsumerKey: "key", ConsumerSecret: "secret", Token: "oauth token", TokenSecret: "token secret"})
+	if _, err := oauth.Artist(ctx, 38661); err != nil {
+		t.Fatalf("failed to get artist: %s", err)
+	}
+	// the derived clients share the cache
+	if _, err := d.Release(ctx, 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+
+	if requests != 2 || len(auth) != 2 {
+		t.Fatalf("requests got=%d, %d; want=2", requests, len(auth))
+	}
+	if auth[0] != "Discogs token=user token" {
+		t.Errorf("authorization got=%s; want=Discogs token=user token", auth[0])
+	}
+	for _, want := range []string{`OAuth oauth_consumer_key="key"`, `oauth_signature="secret%26token%2520secret"`, `oauth_signature_method="PLAINTEXT"`, `oauth_token="oauth%20token"`} {
+		if !strings.Contains(auth[1], want) {
+			t.Errorf("authorization got=%s; want to contain %s", auth[1], want)
+		}
+	}
+
+	for _, token := range []string{"user token", "oauth token"} {
+		if total, _, _, _ := registry.Get(token).Get(); total != 60 {
+			t.Errorf("rate limit of %s got=%d; want=60", token, total)
+		}
+	}
+	if total, _, _, _ := registry.Get("base token").Get(); total != 0 {
+		t.Errorf("expected the rate limit of the base token to be untouched")
+	}
+}
+
+// TestLargeIDs checks that IDs exceeding the range of int on 32-bit platforms are decoded, with the field types
+// checked as well so the test fails on 64-bit platforms too.
+func TestLargeIDs(t *testing.T) {
+	const id int64 = math.MaxInt32 + 1000
+
+	var listing MarketplaceListing
+	var item OrderItem
+	var collectionItem CollectionItemSource
+	tests := map[string]struct {
+		json  string
+		v     interface{}
+		field func() reflect.Value
+	}{
+		"listing":         {`{"id": 2147484647}`, &listing, func() reflect.Value { return reflect.ValueOf(listing.ID) }},
+		"order item":      {`{"id": 2147484647}`, &item, func() reflect.Value { return reflect.ValueOf(item.ID) }},
+		"collection item": {`{"instance_id": 2147484647}`, &collectionItem, func() reflect.Value { return reflect.ValueOf(collectionItem.InstanceID) }},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.json), tt.v); err != nil {
+				t.Fatalf("failed to decode: %s", err)
+			}
+			f := tt.field()
+			if f.Kind() != reflect.Int64 {
+				t.Errorf("kind got=%s; want=%s", f.Kind(), reflect.Int64)
+			}
+			if f.Int() != id {
+				t.Errorf("id got=%d; want=%d", f.Int(), id)
+			}
+		})
+	}
+}
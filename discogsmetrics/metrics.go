@@ -0,0 +1,100 @@
+// Package discogsmetrics records Prometheus metrics of the requests discogs clients make to the Discogs API.
+package discogsmetrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/irlndts/go-discogs"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "discogs"
+
+// Metrics is a prometheus.Collector of the metrics of the requests sent through its Middleware:
+//
+//	discogs_requests_total{endpoint, code}           requests by endpoint and status code, "error" if none was received
+//	discogs_request_duration_seconds{endpoint}       request latency by endpoint
+//	discogs_rate_limited_total{endpoint}             requests answered with 429 Too Many Requests by endpoint
+//	discogs_rate_limit_remaining                     requests remaining in the rate limit window, as last reported
+//
+// The endpoint label holds names such as "/releases/{id}", see discogs.Endpoint.
+type Metrics struct {
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	rateLimited *prometheus.CounterVec
+	remaining   prometheus.Gauge
+}
+
+var _ prometheus.Collector = &Metrics{}
+
+// New returns Metrics to register with a prometheus.Registerer and whose Middleware to add to the clients to monitor.
+func New() *Metrics {
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of Discogs API requests by endpoint and status code.",
+		}, []string{"endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of Discogs API requests by endpoint.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limited_total",
+			Help:      "Number of Discogs API requests answered with 429 Too Many Requests by endpoint.",
+		}, []string{"endpoint"}),
+		remaining: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_remaining",
+			Help:      "Number of requests remaining in the Discogs rate limit window, as last reported.",
+		}),
+	}
+}
+
+// Middleware returns middleware recording the metrics of every request, e.g. for discogs.Options.Middleware or
+// discogs.WithMiddleware.
+func (m *Metrics) Middleware() discogs.Middleware {
+	return func(next discogs.RoundTripFunc) discogs.RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			endpoint := discogs.Endpoint(r.URL.Path)
+
+			start := time.Now()
+			resp, err := next(r)
+			m.duration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+
+			if err != nil {
+				m.requests.WithLabelValues(endpoint, "error").Inc()
+				return resp, err
+			}
+			m.requests.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+			if resp.StatusCode == http.StatusTooManyRequests {
+				m.rateLimited.WithLabelValues(endpoint).Inc()
+			}
+			if resp.Header.Get("X-Discogs-Ratelimit-Remaining") != "" {
+				m.remaining.Set(float64(discogs.ParseRateLimitSnapshot(resp.Header).Remaining))
+			}
+			return resp, nil
+		}
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+	m.rateLimited.Describe(ch)
+	m.remaining.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+	m.rateLimited.Collect(ch)
+	m.remaining.Collect(ch)
+}
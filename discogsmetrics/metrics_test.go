@@ -0,0 +1,106 @@
+package discogsmetrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/irlndts/go-discogs"
+	"github.com/irlndts/go-discogs/discogstest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	s := discogstest.NewServer()
+	defer s.Close()
+
+	m := New()
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatalf("failed to register metrics: %s", err)
+	}
+
+	d, err := discogs.New(&discogs.Options{
+		UserAgent:  "UnitTestClient/0.0.2",
+		URL:        s.URL,
+		Middleware: []discogs.Middleware{m.Middleware()},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	ctx := context.Background()
+
+	if _, err := d.Release(ctx, 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if _, err := d.Release(ctx, 1); !errors.Is(err, discogs.ErrNotFound) {
+		t.Errorf("err got=%v; want=%s", err, discogs.ErrNotFound)
+	}
+	s.Fail(http.StatusTooManyRequests, 1)
+	if _, err := d.Release(ctx, 8138518); err != discogs.ErrTooManyRequests {
+		t.Errorf("err got=%v; want=%s", err, discogs.ErrTooManyRequests)
+	}
+
+	counts := map[string]float64{
+		"200": testutil.ToFloat64(m.requests.WithLabelValues("/releases/{id}", "200")),
+		"404": testutil.ToFloat64(m.requests.WithLabelValues("/releases/{id}", "404")),
+		"429": testutil.ToFloat64(m.requests.WithLabelValues("/releases/{id}", "429")),
+	}
+	for code, want := range map[string]float64{"200": 1, "404": 1, "429": 1} {
+		if counts[code] != want {
+			t.Errorf("requests with code %s got=%v; want=%v", code, counts[code], want)
+		}
+	}
+	if n := testutil.ToFloat64(m.rateLimited.WithLabelValues("/releases/{id}")); n != 1 {
+		t.Errorf("rate limited got=%v; want=1", n)
+	}
+	if n := testutil.ToFloat64(m.remaining); n != discogstest.DefaultRateLimit-3 {
+		t.Errorf("remaining got=%v; want=%d", n, discogstest.DefaultRateLimit-3)
+	}
+	if n := testutil.CollectAndCount(m, "discogs_request_duration_seconds"); n != 1 {
+		t.Errorf("duration series got=%d; want=1", n)
+	}
+}
+
+func TestMetricsEndpoints(t *testing.T) {
+	s := discogstest.NewServer()
+	defer s.Close()
+
+	m := New()
+	d, err := discogs.New(&discogs.Options{
+		UserAgent:  "UnitTestClient/0.0.2",
+		URL:        s.URL,
+		Token:      "some token",
+		Middleware: []discogs.Middleware{m.Middleware()},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	ctx := context.Background()
+
+	// the server answers these with errors; only the endpoints they are recorded by matter
+	for i := 1; i <= 2; i++ {
+		_, _ = d.Listing(ctx, int64(172723812+i))
+		_, _ = d.Order(ctx, "1369620-"+strconv.Itoa(i))
+		_ = d.DeleteWant(ctx, "test_user", 130076+i)
+		_ = d.RateCollectionInstance(ctx, "test_user", 1, 130076, int64(1000+i), 5)
+	}
+
+	endpoints := map[string]string{
+		"/marketplace/listings/{id}":   "404",
+		"/marketplace/orders/{id}":     "404",
+		"/users/{username}/wants/{id}": "405",
+		"/users/{username}/collection/folders/{id}/releases/{id}/instances/{id}": "405",
+	}
+	for endpoint, code := range endpoints {
+		if n := testutil.ToFloat64(m.requests.WithLabelValues(endpoint, code)); n != 2 {
+			t.Errorf("requests to %s got=%v; want=2", endpoint, n)
+		}
+	}
+	if n := testutil.CollectAndCount(m, "discogs_request_duration_seconds"); n != len(endpoints) {
+		t.Errorf("duration series got=%d; want=%d", n, len(endpoints))
+	}
+}
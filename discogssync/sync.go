@@ -0,0 +1,169 @@
+// Package discogssync computes the differences between a local snapshot of a user's collection or wantlist and the
+// live data on Discogs, and applies local changes back to Discogs through a caller provided Applier.
+package discogssync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/irlndts/go-discogs"
+)
+
+// Item is a release in a snapshot of a collection or wantlist.
+type Item struct {
+	ReleaseID  int
+	InstanceID int64 // identifies the copy of a release in a collection; zero for wantlists
+	FolderID   int   // folder of a collection item; zero for wantlists
+	Rating     int
+}
+
+// key identifies the item within its snapshot: collection items by instance, wantlist items by release.
+func (i Item) key() [2]int64 {
+	return [2]int64{int64(i.ReleaseID), i.InstanceID}
+}
+
+// Change is an item present both locally and remotely whose details differ.
+type Change struct {
+	Local  Item
+	Remote Item
+}
+
+// Diff describes how the remote data differs from the local snapshot.
+type Diff struct {
+	Added         []Item   // items present remotely but not locally
+	Removed       []Item   // items present locally but not remotely
+	RatingChanged []Change // items whose rating differs
+	FolderMoved   []Change // collection items whose folder differs
+}
+
+// Empty reports whether there are no differences.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.RatingChanged) == 0 && len(d.FolderMoved) == 0
+}
+
+// Compare returns the differences between the local and remote items. The items of every list in the result are
+// ordered by release and instance ID.
+func Compare(local, remote []Item) Diff {
+	locals := make(map[[2]int64]Item, len(local))
+	for _, item := range local {
+		locals[item.key()] = item
+	}
+	remotes := make(map[[2]int64]Item, len(remote))
+	for _, item := range remote {
+		remotes[item.key()] = item
+	}
+
+	var d Diff
+	for key, r := range remotes {
+		l, ok := locals[key]
+		if !ok {
+			d.Added = append(d.Added, r)
+			continue
+		}
+		if l.Rating != r.Rating {
+			d.RatingChanged = append(d.RatingChanged, Change{Local: l, Remote: r})
+		}
+		if l.FolderID != r.FolderID {
+			d.FolderMoved = append(d.FolderMoved, Change{Local: l, Remote: r})
+		}
+	}
+	for key, l := range locals {
+		if _, ok := remotes[key]; !ok {
+			d.Removed = append(d.Removed, l)
+		}
+	}
+
+	sortItems(d.Added)
+	sortItems(d.Removed)
+	sortChanges(d.RatingChanged)
+	sortChanges(d.FolderMoved)
+	return d
+}
+
+func less(a, b Item) bool {
+	if a.ReleaseID != b.ReleaseID {
+		return a.ReleaseID < b.ReleaseID
+	}
+	return a.InstanceID < b.InstanceID
+}
+
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool { return less(items[i], items[j]) })
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool { return less(changes[i].Local, changes[j].Local) })
+}
+
+// Collection returns the items in all folders of the user's collection.
+func Collection(ctx context.Context, s discogs.CollectionService, username string, opts ...discogs.RequestOption) ([]Item, error) {
+	var items []Item
+	it := discogs.CollectionItemsByFolderIter(ctx, s, username, discogs.FolderAll, nil, opts...)
+	for it.Next() {
+		c := it.Item()
+		items = append(items, Item{ReleaseID: c.ID, InstanceID: c.InstanceID, FolderID: c.FolderID, Rating: c.Rating})
+	}
+	return items, it.Err()
+}
+
+// Wantlist returns the items in the user's wantlist.
+func Wantlist(ctx context.Context, s discogs.WantlistService, username string, opts ...discogs.RequestOption) ([]Item, error) {
+	var items []Item
+	it := discogs.WantlistIter(ctx, s, username, nil, opts...)
+	for it.Next() {
+		w := it.Item()
+		items = append(items, Item{ReleaseID: w.ID, Rating: w.Rating})
+	}
+	return items, it.Err()
+}
+
+// Applier makes changes to a collection or wantlist on Discogs, e.g. by calling the Discogs API with an
+// authenticated client.
+type Applier interface {
+	Add(ctx context.Context, item Item) error
+	Remove(ctx context.Context, item Item) error
+	SetRating(ctx context.Context, item Item) error
+	Move(ctx context.Context, item Item) error
+}
+
+// Apply makes the remote data match the local snapshot by reverting the differences in d: items added remotely are
+// removed, items removed remotely are added again and changed ratings and folders are set to their local values. It
+// stops at the first error. Collection items can't be added to or moved into discogs.FolderAll, so Apply fails with
+// discogs.ErrInvalidFolderID before making any change if d would.
+func Apply(ctx context.Context, a Applier, d Diff) error {
+	for _, item := range d.Removed {
+		if item.InstanceID != 0 {
+			if err := discogs.CheckFolderWritable(item.FolderID); err != nil {
+				return fmt.Errorf("release %d: %w", item.ReleaseID, err)
+			}
+		}
+	}
+	for _, c := range d.FolderMoved {
+		if err := discogs.CheckFolderWritable(c.Local.FolderID); err != nil {
+			return fmt.Errorf("release %d: %w", c.Local.ReleaseID, err)
+		}
+	}
+
+	for _, item := range d.Added {
+		if err := a.Remove(ctx, item); err != nil {
+			return err
+		}
+	}
+	for _, item := range d.Removed {
+		if err := a.Add(ctx, item); err != nil {
+			return err
+		}
+	}
+	for _, c := range d.RatingChanged {
+		if err := a.SetRating(ctx, c.Local); err != nil {
+			return err
+		}
+	}
+	for _, c := range d.FolderMoved {
+		if err := a.Move(ctx, c.Local); err != nil {
+			return err
+		}
+	}
+	return nil
+}
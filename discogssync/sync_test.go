@@ -0,0 +1,143 @@
+package discogssync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/irlndts/go-discogs"
+	"github.com/irlndts/go-discogs/discogstest"
+)
+
+func TestCompare(t *testing.T) {
+	local := []Item{
+		{ReleaseID: 1, InstanceID: 10, FolderID: 1, Rating: 5},
+		{ReleaseID: 2, InstanceID: 20, FolderID: 1, Rating: 3},
+		{ReleaseID: 3, InstanceID: 30, FolderID: 1},
+		{ReleaseID: 3, InstanceID: 31, FolderID: 1},
+	}
+	remote := []Item{
+		{ReleaseID: 1, InstanceID: 10, FolderID: 1, Rating: 5},
+		{ReleaseID: 2, InstanceID: 20, FolderID: 2, Rating: 4},
+		{ReleaseID: 3, InstanceID: 31, FolderID: 1},
+		{ReleaseID: 4, InstanceID: 40, FolderID: 1},
+	}
+
+	want := Diff{
+		Added:         []Item{{ReleaseID: 4, InstanceID: 40, FolderID: 1}},
+		Removed:       []Item{{ReleaseID: 3, InstanceID: 30, FolderID: 1}},
+		RatingChanged: []Change{{Local: local[1], Remote: remote[1]}},
+		FolderMoved:   []Change{{Local: local[1], Remote: remote[1]}},
+	}
+	if got := Compare(local, remote); !reflect.DeepEqual(got, want) {
+		t.Errorf("diff got=%+v; want=%+v", got, want)
+	}
+
+	if d := Compare(local, local); !d.Empty() {
+		t.Errorf("diff got=%+v; want empty", d)
+	}
+}
+
+func TestCollectionAndWantlist(t *testing.T) {
+	m := &discogstest.MockDiscogs{
+		CollectionItemsByFolderFunc: func(ctx context.Context, username string, folderID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.CollectionItems, error) {
+			return &discogs.CollectionItems{Items: []discogs.CollectionItemSource{
+				{ID: 1, InstanceID: 10, FolderID: 1, Rating: 5},
+			}}, nil
+		},
+		WantlistFunc: func(ctx context.Context, username string, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.Wantlist, error) {
+			return &discogs.Wantlist{Wants: []discogs.Want{{ID: 2, Rating: 3}}}, nil
+		},
+	}
+	ctx := context.Background()
+
+	collection, err := Collection(ctx, m, "test_user")
+	if err != nil {
+		t.Fatalf("failed to get collection: %s", err)
+	}
+	if want := []Item{{ReleaseID: 1, InstanceID: 10, FolderID: 1, Rating: 5}}; !reflect.DeepEqual(collection, want) {
+		t.Errorf("collection got=%+v; want=%+v", collection, want)
+	}
+
+	wantlist, err := Wantlist(ctx, m, "test_user")
+	if err != nil {
+		t.Fatalf("failed to get wantlist: %s", err)
+	}
+	if want := []Item{{ReleaseID: 2, Rating: 3}}; !reflect.DeepEqual(wantlist, want) {
+		t.Errorf("wantlist got=%+v; want=%+v", wantlist, want)
+	}
+}
+
+type recordingApplier struct {
+	calls []string
+	err   error
+}
+
+func (a *recordingApplier) record(op string, item Item) error {
+	a.calls = append(a.calls, fmt.Sprintf("%s %d", op, item.ReleaseID))
+	return a.err
+}
+
+func (a *recordingApplier) Add(ctx context.Context, item Item) error { return a.record("add", item) }
+func (a *recordingApplier) Remove(ctx context.Context, item Item) error {
+	return a.record("remove", item)
+}
+func (a *recordingApplier) SetRating(ctx context.Context, item Item) error {
+	return a.record(fmt.Sprintf("rate %d", item.Rating), item)
+}
+func (a *recordingApplier) Move(ctx context.Context, item Item) error {
+	return a.record(fmt.Sprintf("move %d", item.FolderID), item)
+}
+
+func TestApply(t *testing.T) {
+	d := Diff{
+		Added:         []Item{{ReleaseID: 4}},
+		Removed:       []Item{{ReleaseID: 3}},
+		RatingChanged: []Change{{Local: Item{ReleaseID: 2, Rating: 3}, Remote: Item{ReleaseID: 2, Rating: 4}}},
+		FolderMoved:   []Change{{Local: Item{ReleaseID: 2, FolderID: 1}, Remote: Item{ReleaseID: 2, FolderID: 2}}},
+	}
+
+	a := &recordingApplier{}
+	if err := Apply(context.Background(), a, d); err != nil {
+		t.Fatalf("failed to apply diff: %s", err)
+	}
+	want := []string{"remove 4", "add 3", "rate 3 2", "move 1 2"}
+	if !reflect.DeepEqual(a.calls, want) {
+		t.Errorf("calls got=%v; want=%v", a.calls, want)
+	}
+
+	failure := errors.New("failure")
+	a = &recordingApplier{err: failure}
+	if err := Apply(context.Background(), a, d); err != failure {
+		t.Errorf("err got=%v; want=%v", err, failure)
+	}
+	if len(a.calls) != 1 {
+		t.Errorf("calls got=%v; want=1 call", a.calls)
+	}
+}
+
+func TestApplyFolderAll(t *testing.T) {
+	tests := map[string]Diff{
+		"add":  {Removed: []Item{{ReleaseID: 3, InstanceID: 30, FolderID: discogs.FolderAll}}},
+		"move": {FolderMoved: []Change{{Local: Item{ReleaseID: 2, FolderID: discogs.FolderAll}, Remote: Item{ReleaseID: 2, FolderID: 2}}}},
+	}
+	for name, d := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &recordingApplier{}
+			if err := Apply(context.Background(), a, d); !errors.Is(err, discogs.ErrInvalidFolderID) {
+				t.Errorf("err got=%v; want=%v", err, discogs.ErrInvalidFolderID)
+			}
+			if len(a.calls) != 0 {
+				t.Errorf("calls got=%v; want=none", a.calls)
+			}
+		})
+	}
+
+	// Wantlist items have no folder.
+	a := &recordingApplier{}
+	if err := Apply(context.Background(), a, Diff{Removed: []Item{{ReleaseID: 3}}}); err != nil {
+		t.Errorf("err got=%v; want=nil", err)
+	}
+}
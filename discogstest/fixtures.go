@@ -0,0 +1,104 @@
+package discogstest
+
+import (
+	"embed"
+	"encoding/json"
+
+	"github.com/irlndts/go-discogs"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture returns the raw JSON of the named fixture, e.g. "release" for fixtures/release.json.
+func Fixture(name string) []byte {
+	b, err := fixtures.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		panic("discogstest: unknown fixture " + name)
+	}
+	return b
+}
+
+// decode decodes the named fixture into v.
+func decode(name string, v interface{}) {
+	if err := json.Unmarshal(Fixture(name), v); err != nil {
+		panic("discogstest: invalid fixture " + name + ": " + err.Error())
+	}
+}
+
+// Release returns release 8138518, St. Petersburg Ska-Jazz Review - Elephant Riddim.
+func Release() *discogs.Release {
+	var v *discogs.Release
+	decode("release", &v)
+	return v
+}
+
+// Master returns master release 718441, Eminem - Infinite.
+func Master() *discogs.Master {
+	var v *discogs.Master
+	decode("master", &v)
+	return v
+}
+
+// Artist returns artist 38661, Eminem.
+func Artist() *discogs.Artist {
+	var v *discogs.Artist
+	decode("artist", &v)
+	return v
+}
+
+// Label returns label 1, Planet E.
+func Label() *discogs.Label {
+	var v *discogs.Label
+	decode("label", &v)
+	return v
+}
+
+// Search returns a page of search results of mixed types.
+func Search() *discogs.Search {
+	var v *discogs.Search
+	decode("search", &v)
+	return v
+}
+
+// CollectionFolders returns the folders of a collection.
+func CollectionFolders() *discogs.CollectionFolders {
+	var v *discogs.CollectionFolders
+	decode("collection_folders", &v)
+	return v
+}
+
+// Folder returns folder 0 of a collection.
+func Folder() *discogs.Folder {
+	var v *discogs.Folder
+	decode("folder", &v)
+	return v
+}
+
+// CollectionItemsByFolder returns a page of items in folder 0 of a collection.
+func CollectionItemsByFolder() *discogs.CollectionItems {
+	var v *discogs.CollectionItems
+	decode("collection_items_by_folder", &v)
+	return v
+}
+
+// CollectionItemsByRelease returns the collection items of release 12934893.
+func CollectionItemsByRelease() *discogs.CollectionItems {
+	var v *discogs.CollectionItems
+	decode("collection_items_by_release", &v)
+	return v
+}
+
+// PriceSuggestions returns price suggestions for a release.
+func PriceSuggestions() *discogs.PriceListing {
+	var v *discogs.PriceListing
+	decode("price_suggestions", &v)
+	return v
+}
+
+// ReleaseStatistics returns marketplace statistics for a release.
+func ReleaseStatistics() *discogs.Stats {
+	var v *discogs.Stats
+	decode("release_stats", &v)
+	return v
+}
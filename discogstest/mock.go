@@ -0,0 +1,337 @@
+// Package discogstest provides utilities for testing code that uses the discogs package.
+package discogstest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/irlndts/go-discogs"
+)
+
+// ErrNotStubbed is returned by MockDiscogs methods whose function field is nil.
+var ErrNotStubbed = errors.New("discogstest: method not stubbed")
+
+// MockDiscogs implements discogs.Discogs by calling the function field corresponding to each method.
+// Methods whose function field is nil return ErrNotStubbed, except WithToken and WithOAuth which return the mock
+// itself.
+type MockDiscogs struct {
+	// CollectionService
+	CollectionFoldersFunc        func(ctx context.Context, username string, opts ...discogs.RequestOption) (*discogs.CollectionFolders, error)
+	CollectionItemsByFolderFunc  func(ctx context.Context, username string, folderID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.CollectionItems, error)
+	CollectionItemsByReleaseFunc func(ctx context.Context, username string, releaseID int, opts ...discogs.RequestOption) (*discogs.CollectionItems, error)
+	FolderFunc                   func(ctx context.Context, username string, folderID int, opts ...discogs.RequestOption) (*discogs.Folder, error)
+	RateCollectionInstanceFunc   func(ctx context.Context, username string, folderID, releaseID int, instanceID int64, rating int, opts ...discogs.RequestOption) error
+
+	// DatabaseService
+	ArtistFunc                func(ctx context.Context, artistID int, opts ...discogs.RequestOption) (*discogs.Artist, error)
+	ArtistReleasesFunc        func(ctx context.Context, artistID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.ArtistReleases, error)
+	LabelFunc                 func(ctx context.Context, labelID int, opts ...discogs.RequestOption) (*discogs.Label, error)
+	LabelReleasesFunc         func(ctx context.Context, labelID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.LabelReleases, error)
+	MasterFunc                func(ctx context.Context, masterID int, opts ...discogs.RequestOption) (*discogs.Master, error)
+	MasterVersionsFunc        func(ctx context.Context, masterID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.MasterVersions, error)
+	ReleaseFunc               func(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.Release, error)
+	ReleaseCommunityStatsFunc func(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.ReleaseCommunityStats, error)
+	ReleaseRatingFunc         func(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.ReleaseRating, error)
+
+	// FetchService
+	FetchFunc func(ctx context.Context, resourceURL string, v interface{}, opts ...discogs.RequestOption) error
+	DoFunc    func(ctx context.Context, method, path string, params url.Values, body, out interface{}, opts ...discogs.RequestOption) error
+
+	// ImagesService
+	ImageFunc func(ctx context.Context, imageURL string, opts ...discogs.RequestOption) (io.ReadCloser, error)
+
+	// InventoryExportService
+	ExportInventoryFunc         func(ctx context.Context, opts ...discogs.RequestOption) (int, error)
+	InventoryExportsFunc        func(ctx context.Context, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.InventoryExports, error)
+	InventoryExportFunc         func(ctx context.Context, exportID int, opts ...discogs.RequestOption) (*discogs.InventoryExport, error)
+	DownloadInventoryExportFunc func(ctx context.Context, exportID int, opts ...discogs.RequestOption) (io.ReadCloser, error)
+
+	// InventoryUploadService
+	UploadInventoryFunc  func(ctx context.Context, uploadType discogs.InventoryUploadType, csv io.Reader, opts ...discogs.RequestOption) (int, error)
+	InventoryUploadsFunc func(ctx context.Context, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.InventoryUploads, error)
+	InventoryUploadFunc  func(ctx context.Context, uploadID int, opts ...discogs.RequestOption) (*discogs.InventoryUpload, error)
+
+	// MarketPlaceService
+	InventoryFunc         func(ctx context.Context, username string, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.Inventory, error)
+	ListingFunc           func(ctx context.Context, listingID int64, opts ...discogs.RequestOption) (*discogs.MarketplaceListing, error)
+	OrderFunc             func(ctx context.Context, orderID string, opts ...discogs.RequestOption) (*discogs.Order, error)
+	OrdersFunc            func(ctx context.Context, status discogs.OrderStatus, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.Orders, error)
+	PriceSuggestionsFunc  func(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.PriceListing, error)
+	ReleaseStatisticsFunc func(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.Stats, error)
+
+	// SearchService
+	SearchFunc func(ctx context.Context, req discogs.SearchRequest, opts ...discogs.RequestOption) (*discogs.Search, error)
+
+	// WantlistService
+	WantlistFunc   func(ctx context.Context, username string, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.Wantlist, error)
+	AddWantFunc    func(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...discogs.RequestOption) (*discogs.Want, error)
+	EditWantFunc   func(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...discogs.RequestOption) (*discogs.Want, error)
+	DeleteWantFunc func(ctx context.Context, username string, releaseID int, opts ...discogs.RequestOption) error
+
+	// Client derivation
+	WithOAuthFunc func(creds discogs.OAuthCredentials) discogs.Discogs
+	WithTokenFunc func(token string) discogs.Discogs
+}
+
+var _ discogs.Discogs = &MockDiscogs{}
+
+func (m *MockDiscogs) CollectionFolders(ctx context.Context, username string, opts ...discogs.RequestOption) (*discogs.CollectionFolders, error) {
+	if m.CollectionFoldersFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.CollectionFoldersFunc(ctx, username, opts...)
+}
+
+func (m *MockDiscogs) CollectionItemsByFolder(ctx context.Context, username string, folderID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.CollectionItems, error) {
+	if m.CollectionItemsByFolderFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.CollectionItemsByFolderFunc(ctx, username, folderID, pagination, opts...)
+}
+
+func (m *MockDiscogs) CollectionItemsByRelease(ctx context.Context, username string, releaseID int, opts ...discogs.RequestOption) (*discogs.CollectionItems, error) {
+	if m.CollectionItemsByReleaseFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.CollectionItemsByReleaseFunc(ctx, username, releaseID, opts...)
+}
+
+func (m *MockDiscogs) Folder(ctx context.Context, username string, folderID int, opts ...discogs.RequestOption) (*discogs.Folder, error) {
+	if m.FolderFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.FolderFunc(ctx, username, folderID, opts...)
+}
+
+func (m *MockDiscogs) RateCollectionInstance(ctx context.Context, username string, folderID, releaseID int, instanceID int64, rating int, opts ...discogs.RequestOption) error {
+	if m.RateCollectionInstanceFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.RateCollectionInstanceFunc(ctx, username, folderID, releaseID, instanceID, rating, opts...)
+}
+
+func (m *MockDiscogs) Artist(ctx context.Context, artistID int, opts ...discogs.RequestOption) (*discogs.Artist, error) {
+	if m.ArtistFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ArtistFunc(ctx, artistID, opts...)
+}
+
+func (m *MockDiscogs) ArtistReleases(ctx context.Context, artistID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.ArtistReleases, error) {
+	if m.ArtistReleasesFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ArtistReleasesFunc(ctx, artistID, pagination, opts...)
+}
+
+func (m *MockDiscogs) Label(ctx context.Context, labelID int, opts ...discogs.RequestOption) (*discogs.Label, error) {
+	if m.LabelFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.LabelFunc(ctx, labelID, opts...)
+}
+
+func (m *MockDiscogs) LabelReleases(ctx context.Context, labelID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.LabelReleases, error) {
+	if m.LabelReleasesFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.LabelReleasesFunc(ctx, labelID, pagination, opts...)
+}
+
+func (m *MockDiscogs) Master(ctx context.Context, masterID int, opts ...discogs.RequestOption) (*discogs.Master, error) {
+	if m.MasterFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.MasterFunc(ctx, masterID, opts...)
+}
+
+func (m *MockDiscogs) MasterVersions(ctx context.Context, masterID int, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.MasterVersions, error) {
+	if m.MasterVersionsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.MasterVersionsFunc(ctx, masterID, pagination, opts...)
+}
+
+func (m *MockDiscogs) Release(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.Release, error) {
+	if m.ReleaseFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ReleaseFunc(ctx, releaseID, opts...)
+}
+
+func (m *MockDiscogs) ReleaseCommunityStats(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.ReleaseCommunityStats, error) {
+	if m.ReleaseCommunityStatsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ReleaseCommunityStatsFunc(ctx, releaseID, opts...)
+}
+
+func (m *MockDiscogs) ReleaseRating(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.ReleaseRating, error) {
+	if m.ReleaseRatingFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ReleaseRatingFunc(ctx, releaseID, opts...)
+}
+
+func (m *MockDiscogs) Fetch(ctx context.Context, resourceURL string, v interface{}, opts ...discogs.RequestOption) error {
+	if m.FetchFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.FetchFunc(ctx, resourceURL, v, opts...)
+}
+
+func (m *MockDiscogs) Do(ctx context.Context, method, path string, params url.Values, body, out interface{}, opts ...discogs.RequestOption) error {
+	if m.DoFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.DoFunc(ctx, method, path, params, body, out, opts...)
+}
+
+func (m *MockDiscogs) Image(ctx context.Context, imageURL string, opts ...discogs.RequestOption) (io.ReadCloser, error) {
+	if m.ImageFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ImageFunc(ctx, imageURL, opts...)
+}
+
+func (m *MockDiscogs) ExportInventory(ctx context.Context, opts ...discogs.RequestOption) (int, error) {
+	if m.ExportInventoryFunc == nil {
+		return 0, ErrNotStubbed
+	}
+	return m.ExportInventoryFunc(ctx, opts...)
+}
+
+func (m *MockDiscogs) InventoryExports(ctx context.Context, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.InventoryExports, error) {
+	if m.InventoryExportsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.InventoryExportsFunc(ctx, pagination, opts...)
+}
+
+func (m *MockDiscogs) InventoryExport(ctx context.Context, exportID int, opts ...discogs.RequestOption) (*discogs.InventoryExport, error) {
+	if m.InventoryExportFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.InventoryExportFunc(ctx, exportID, opts...)
+}
+
+func (m *MockDiscogs) DownloadInventoryExport(ctx context.Context, exportID int, opts ...discogs.RequestOption) (io.ReadCloser, error) {
+	if m.DownloadInventoryExportFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.DownloadInventoryExportFunc(ctx, exportID, opts...)
+}
+
+func (m *MockDiscogs) UploadInventory(ctx context.Context, uploadType discogs.InventoryUploadType, csv io.Reader, opts ...discogs.RequestOption) (int, error) {
+	if m.UploadInventoryFunc == nil {
+		return 0, ErrNotStubbed
+	}
+	return m.UploadInventoryFunc(ctx, uploadType, csv, opts...)
+}
+
+func (m *MockDiscogs) InventoryUploads(ctx context.Context, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.InventoryUploads, error) {
+	if m.InventoryUploadsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.InventoryUploadsFunc(ctx, pagination, opts...)
+}
+
+func (m *MockDiscogs) InventoryUpload(ctx context.Context, uploadID int, opts ...discogs.RequestOption) (*discogs.InventoryUpload, error) {
+	if m.InventoryUploadFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.InventoryUploadFunc(ctx, uploadID, opts...)
+}
+
+func (m *MockDiscogs) Inventory(ctx context.Context, username string, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.Inventory, error) {
+	if m.InventoryFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.InventoryFunc(ctx, username, pagination, opts...)
+}
+
+func (m *MockDiscogs) Listing(ctx context.Context, listingID int64, opts ...discogs.RequestOption) (*discogs.MarketplaceListing, error) {
+	if m.ListingFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ListingFunc(ctx, listingID, opts...)
+}
+
+func (m *MockDiscogs) Order(ctx context.Context, orderID string, opts ...discogs.RequestOption) (*discogs.Order, error) {
+	if m.OrderFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.OrderFunc(ctx, orderID, opts...)
+}
+
+func (m *MockDiscogs) Orders(ctx context.Context, status discogs.OrderStatus, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.Orders, error) {
+	if m.OrdersFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.OrdersFunc(ctx, status, pagination, opts...)
+}
+
+func (m *MockDiscogs) PriceSuggestions(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.PriceListing, error) {
+	if m.PriceSuggestionsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.PriceSuggestionsFunc(ctx, releaseID, opts...)
+}
+
+func (m *MockDiscogs) ReleaseStatistics(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.Stats, error) {
+	if m.ReleaseStatisticsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ReleaseStatisticsFunc(ctx, releaseID, opts...)
+}
+
+func (m *MockDiscogs) Search(ctx context.Context, req discogs.SearchRequest, opts ...discogs.RequestOption) (*discogs.Search, error) {
+	if m.SearchFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.SearchFunc(ctx, req, opts...)
+}
+
+func (m *MockDiscogs) Wantlist(ctx context.Context, username string, pagination *discogs.Pagination, opts ...discogs.RequestOption) (*discogs.Wantlist, error) {
+	if m.WantlistFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.WantlistFunc(ctx, username, pagination, opts...)
+}
+
+func (m *MockDiscogs) AddWant(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...discogs.RequestOption) (*discogs.Want, error) {
+	if m.AddWantFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.AddWantFunc(ctx, username, releaseID, notes, rating, opts...)
+}
+
+func (m *MockDiscogs) EditWant(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...discogs.RequestOption) (*discogs.Want, error) {
+	if m.EditWantFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.EditWantFunc(ctx, username, releaseID, notes, rating, opts...)
+}
+
+func (m *MockDiscogs) DeleteWant(ctx context.Context, username string, releaseID int, opts ...discogs.RequestOption) error {
+	if m.DeleteWantFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.DeleteWantFunc(ctx, username, releaseID, opts...)
+}
+
+func (m *MockDiscogs) WithOAuth(creds discogs.OAuthCredentials) discogs.Discogs {
+	if m.WithOAuthFunc == nil {
+		return m
+	}
+	return m.WithOAuthFunc(creds)
+}
+
+func (m *MockDiscogs) WithToken(token string) discogs.Discogs {
+	if m.WithTokenFunc == nil {
+		return m
+	}
+	return m.WithTokenFunc(token)
+}
@@ -0,0 +1,62 @@
+package discogstest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/irlndts/go-discogs"
+)
+
+func TestMockDiscogs(t *testing.T) {
+	m := &MockDiscogs{
+		ReleaseFunc: func(ctx context.Context, releaseID int, opts ...discogs.RequestOption) (*discogs.Release, error) {
+			return Release(), nil
+		},
+	}
+
+	release, err := m.Release(context.Background(), 8138518)
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if release.ID != 8138518 || release.Title != "Elephant Riddim" {
+		t.Errorf("release got=%d %q; want=8138518 %q", release.ID, release.Title, "Elephant Riddim")
+	}
+
+	if _, err := m.Master(context.Background(), 718441); !errors.Is(err, ErrNotStubbed) {
+		t.Errorf("err got=%v; want=%s", err, ErrNotStubbed)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	if Release().ID != 8138518 {
+		t.Errorf("unexpected release fixture")
+	}
+	if Master().ID != 718441 {
+		t.Errorf("unexpected master fixture")
+	}
+	if Artist().ID != 38661 {
+		t.Errorf("unexpected artist fixture")
+	}
+	if Label().ID != 1 {
+		t.Errorf("unexpected label fixture")
+	}
+	if len(Search().Results) != 3 {
+		t.Errorf("unexpected search fixture")
+	}
+	if len(CollectionFolders().Folders) == 0 {
+		t.Errorf("unexpected collection folders fixture")
+	}
+	if Folder().Name != "All" {
+		t.Errorf("unexpected folder fixture")
+	}
+	if len(CollectionItemsByFolder().Items) == 0 || len(CollectionItemsByRelease().Items) == 0 {
+		t.Errorf("unexpected collection items fixture")
+	}
+	if PriceSuggestions().NearMint == nil {
+		t.Errorf("unexpected price suggestions fixture")
+	}
+	if ReleaseStatistics().LowestPrice == nil {
+		t.Errorf("unexpected release statistics fixture")
+	}
+}
@@ -0,0 +1,159 @@
+package discogstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is the number of requests per minute a Server permits unless changed with SetRateLimit.
+const DefaultRateLimit = 60
+
+// Server is a fake Discogs API server serving the fixtures of this package. Use its URL as discogs.Options.URL.
+//
+// The database endpoints serve the fixtures for their IDs and respond with 404 for any other ID, the collection
+// endpoints serve the collection fixtures for any username and the marketplace endpoints serve the marketplace
+// fixtures for any release. Search and price suggestions require a token, as they do on Discogs. Responses carry
+// the Discogs rate limiting headers and requests beyond the rate limit are answered with 429.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string][]byte
+	failures  []int
+	rateLimit int
+	used      int
+	window    time.Time
+	requests  int
+}
+
+// routes maps request paths to the fixtures they serve.
+var routes = []struct {
+	path    *regexp.Regexp
+	fixture string
+	auth    bool
+}{
+	{regexp.MustCompile(`^/releases/8138518$`), "release", false},
+	{regexp.MustCompile(`^/masters/718441$`), "master", false},
+	{regexp.MustCompile(`^/artists/38661$`), "artist", false},
+	{regexp.MustCompile(`^/labels/1$`), "label", false},
+	{regexp.MustCompile(`^/database/search$`), "search", true},
+	{regexp.MustCompile(`^/users/[^/]+/collection/folders$`), "collection_folders", false},
+	{regexp.MustCompile(`^/users/[^/]+/collection/folders/0$`), "folder", false},
+	{regexp.MustCompile(`^/users/[^/]+/collection/folders/0/releases$`), "collection_items_by_folder", false},
+	{regexp.MustCompile(`^/users/[^/]+/collection/releases/12934893$`), "collection_items_by_release", false},
+	{regexp.MustCompile(`^/marketplace/price_suggestions/[0-9]+$`), "price_suggestions", true},
+	{regexp.MustCompile(`^/marketplace/stats/[0-9]+$`), "release_stats", false},
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		responses: make(map[string][]byte),
+		rateLimit: DefaultRateLimit,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Set makes the server respond to requests for path, e.g. "/releases/1", with body.
+func (s *Server) Set(path string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[path] = body
+}
+
+// Fail makes the server respond to the next n requests with the given status code, e.g. http.StatusUnauthorized or
+// http.StatusTooManyRequests.
+func (s *Server) Fail(status int, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// SetRateLimit sets the number of requests permitted per minute. A limit of zero disables rate limiting.
+func (s *Server) SetRateLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimit = limit
+}
+
+// Requests returns the number of requests the server has received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	status, body := s.respond(w, r)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// respond sets the rate limiting headers and returns the status and body to respond to r with.
+func (s *Server) respond(w http.ResponseWriter, r *http.Request) (int, []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+
+	if s.rateLimit > 0 {
+		if now := time.Now(); now.Sub(s.window) >= time.Minute {
+			s.window = now
+			s.used = 0
+		}
+		if s.used >= s.rateLimit {
+			s.rateLimitHeaders(w)
+			return http.StatusTooManyRequests, message("You are making requests too quickly.")
+		}
+		s.used++
+		s.rateLimitHeaders(w)
+	}
+
+	if len(s.failures) > 0 {
+		status := s.failures[0]
+		s.failures = s.failures[1:]
+		return status, message(http.StatusText(status))
+	}
+
+	if r.Method != http.MethodGet {
+		return http.StatusMethodNotAllowed, message("Method not allowed.")
+	}
+
+	if body, ok := s.responses[r.URL.Path]; ok {
+		return http.StatusOK, body
+	}
+
+	for _, route := range routes {
+		if !route.path.MatchString(r.URL.Path) {
+			continue
+		}
+		if route.auth && r.Header.Get("Authorization") == "" {
+			return http.StatusUnauthorized, message("You must authenticate to access this resource.")
+		}
+		return http.StatusOK, Fixture(route.fixture)
+	}
+	return http.StatusNotFound, message("The requested resource was not found.")
+}
+
+func (s *Server) rateLimitHeaders(w http.ResponseWriter) {
+	w.Header().Set("X-Discogs-Ratelimit", strconv.Itoa(s.rateLimit))
+	w.Header().Set("X-Discogs-Ratelimit-Used", strconv.Itoa(s.used))
+	w.Header().Set("X-Discogs-Ratelimit-Remaining", strconv.Itoa(s.rateLimit-s.used))
+}
+
+// message returns a Discogs error response body.
+func message(msg string) []byte {
+	return []byte(`{"message": ` + strconv.Quote(msg) + `}`)
+}
@@ -0,0 +1,93 @@
+package discogstest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/irlndts/go-discogs"
+)
+
+func newClient(t *testing.T, url, token string) discogs.Discogs {
+	client, err := discogs.New(&discogs.Options{
+		UserAgent: "UnitTestClient/0.0.2",
+		URL:       url,
+		Token:     token,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	return client
+}
+
+func TestServer(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	d := newClient(t, s.URL, "some token")
+	ctx := context.Background()
+
+	if release, err := d.Release(ctx, 8138518); err != nil || release.Title != "Elephant Riddim" {
+		t.Errorf("release got=%v, %v; want Elephant Riddim", release, err)
+	}
+	if _, err := d.Release(ctx, 1); !errors.Is(err, discogs.ErrNotFound) {
+		t.Errorf("err got=%v; want=%s", err, discogs.ErrNotFound)
+	}
+	if search, err := d.Search(ctx, discogs.SearchRequest{Q: "reggaenauts"}); err != nil || len(search.Results) != 3 {
+		t.Errorf("search got=%v, %v; want 3 results", search, err)
+	}
+	if items, err := d.CollectionItemsByFolder(ctx, "someone", 0, nil); err != nil || len(items.Items) == 0 {
+		t.Errorf("collection items got=%v, %v; want items", items, err)
+	}
+	if stats, err := d.ReleaseStatistics(ctx, 123); err != nil || stats.LowestPrice == nil {
+		t.Errorf("stats got=%v, %v; want stats", stats, err)
+	}
+
+	s.Set("/releases/1", Fixture("release"))
+	if _, err := d.Release(ctx, 1); err != nil {
+		t.Errorf("failed to get release set for path: %s", err)
+	}
+
+	if s.Requests() != 6 {
+		t.Errorf("requests got=%d; want=6", s.Requests())
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	d := newClient(t, s.URL, "")
+	if _, err := d.Search(context.Background(), discogs.SearchRequest{Q: "reggaenauts"}); !errors.Is(err, discogs.ErrUnauthorized) {
+		t.Errorf("err got=%v; want=%s", err, discogs.ErrUnauthorized)
+	}
+}
+
+func TestServerFailures(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	rl := &discogs.RateLimit{}
+	d, err := discogs.New(&discogs.Options{UserAgent: "UnitTestClient/0.0.2", URL: s.URL, RateLimit: rl})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	ctx := context.Background()
+
+	s.Fail(http.StatusTooManyRequests, 1)
+	if _, err := d.Master(ctx, 718441); !errors.Is(err, discogs.ErrTooManyRequests) {
+		t.Errorf("err got=%v; want=%s", err, discogs.ErrTooManyRequests)
+	}
+	if _, err := d.Master(ctx, 718441); err != nil {
+		t.Errorf("failed to get master: %s", err)
+	}
+	if total, used, remaining, _ := rl.Get(); total != DefaultRateLimit || used != 2 || remaining != DefaultRateLimit-2 {
+		t.Errorf("rate limit got=%d, %d, %d; want=%d, 2, %d", total, used, remaining, DefaultRateLimit, DefaultRateLimit-2)
+	}
+
+	s.SetRateLimit(2)
+	if _, err := d.Master(ctx, 718441); !errors.Is(err, discogs.ErrTooManyRequests) {
+		t.Errorf("err got=%v; want=%s", err, discogs.ErrTooManyRequests)
+	}
+}
@@ -0,0 +1,32 @@
+package discogs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// BuildRequest returns the request a call would send without sending it. call makes the call passing opts, e.g.
+//
+//	r, err := BuildRequest(func(opts ...RequestOption) error {
+//		_, err := client.Search(ctx, SearchRequest{Q: "river rock"}, opts...)
+//		return err
+//	})
+//
+// It returns the error of the call if it fails before building a request, e.g. when validating its arguments, and
+// ErrInvalidOptions if the call succeeds without one, e.g. served from a cache. The body of the request can be read
+// with its GetBody function too.
+func BuildRequest(call func(opts ...RequestOption) error) (*http.Request, error) {
+	var r *http.Request
+	err := call(WithDryRun(func(dr *http.Request) {
+		r = dr
+	}))
+	switch {
+	case r != nil && errors.Is(err, ErrDryRun):
+		return r, nil
+	case err != nil:
+		return nil, err
+	default:
+		return nil, fmt.Errorf("%w: the call sent no request", ErrInvalidOptions)
+	}
+}
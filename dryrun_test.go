@@ -0,0 +1,105 @@
+package discogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run sent request %s", r.URL)
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Token: "some token"})
+	ctx := context.Background()
+
+	r, err := BuildRequest(func(opts ...RequestOption) error {
+		_, err := d.Search(ctx, SearchRequest{Q: "river rock", Type: SearchTypeRelease, PerPage: 5}, opts...)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to build request: %s", err)
+	}
+	if r.Method != http.MethodGet || r.URL.Path != "/database/search" {
+		t.Errorf("request got=%s %s; want=GET /database/search", r.Method, r.URL.Path)
+	}
+	if q := r.URL.Query(); q.Get("q") != "river rock" || q.Get("type") != "release" || q.Get("per_page") != "5" {
+		t.Errorf("query got=%v", q)
+	}
+	if auth := r.Header.Get("Authorization"); auth != "Discogs token=some token" {
+		t.Errorf("authorization got=%q; want=%q", auth, "Discogs token=some token")
+	}
+	if ua := r.Header.Get("User-Agent"); ua != testUserAgent {
+		t.Errorf("user-agent got=%q; want=%q", ua, testUserAgent)
+	}
+
+	r, err = BuildRequest(func(opts ...RequestOption) error {
+		_, err := d.UploadInventory(ctx, UploadDelete, bytes.NewBufferString("listing_id\n1\n"), opts...)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to build request: %s", err)
+	}
+	if r.Method != http.MethodPost || r.URL.Path != "/inventory/upload/delete" {
+		t.Errorf("request got=%s %s; want=POST /inventory/upload/delete", r.Method, r.URL.Path)
+	}
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse content type: %s", err)
+	}
+	form, err := multipart.NewReader(r.Body, params["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("failed to read form: %s", err)
+	}
+	f, err := form.File["upload"][0].Open()
+	if err != nil {
+		t.Fatalf("failed to open upload: %s", err)
+	}
+	defer f.Close()
+	if b, _ := ioutil.ReadAll(f); string(b) != "listing_id\n1\n" {
+		t.Errorf("upload got=%q", b)
+	}
+
+	if _, err := BuildRequest(func(opts ...RequestOption) error {
+		_, err := d.Search(ctx, SearchRequest{Year: "96"}, opts...)
+		return err
+	}); err != ErrInvalidYear {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidYear)
+	}
+	if _, err := BuildRequest(func(opts ...RequestOption) error { return nil }); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidOptions)
+	}
+}
+
+func TestDryRunCircuitBreaker(t *testing.T) {
+	var changes []CircuitState
+	d := CircuitBreaker(initDiscogsClient(t, &Options{URL: "http://127.0.0.1:1"}), CircuitBreakerConfig{
+		FailureThreshold: 1,
+		OnStateChange:    func(from, to CircuitState) { changes = append(changes, to) },
+	})
+
+	var urls []string
+	dryRun := WithDryRun(func(r *http.Request) { urls = append(urls, r.URL.String()) })
+	for i := 0; i < 3; i++ {
+		if _, err := d.Release(context.Background(), 8138518, dryRun); err != ErrDryRun {
+			t.Fatalf("err got=%v; want=%s", err, ErrDryRun)
+		}
+	}
+	u := "http://127.0.0.1:1/releases/8138518?curr_abbr=USD"
+	if want := []string{u, u, u}; !cmp.Equal(urls, want) {
+		t.Errorf("urls got=%v; want=%v", urls, want)
+	}
+	if len(changes) != 0 {
+		t.Errorf("circuit state changes got=%v; want none", changes)
+	}
+}
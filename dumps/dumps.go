@@ -0,0 +1,105 @@
+// Package dumps decodes the monthly data dumps published at https://data.discogs.com into the types of the discogs
+// package. The dumps are decoded as streams, so files of any size can be processed; gzip compressed dumps are
+// decompressed transparently.
+package dumps
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/irlndts/go-discogs"
+)
+
+const apiURL = "https://api.discogs.com"
+
+// Releases decodes a releases dump, e.g. discogs_20240101_releases.xml.gz, calling fn for every release. Decoding
+// stops at the first error returned by fn, which is returned.
+func Releases(r io.Reader, fn func(*discogs.Release) error) error {
+	return each(r, "release", func(d *xml.Decoder, start *xml.StartElement) error {
+		var x xmlRelease
+		if err := d.DecodeElement(&x, start); err != nil {
+			return err
+		}
+		return fn(x.release())
+	})
+}
+
+// Artists decodes an artists dump, calling fn for every artist. Decoding stops at the first error returned by fn,
+// which is returned.
+func Artists(r io.Reader, fn func(*discogs.Artist) error) error {
+	return each(r, "artist", func(d *xml.Decoder, start *xml.StartElement) error {
+		var x xmlArtist
+		if err := d.DecodeElement(&x, start); err != nil {
+			return err
+		}
+		return fn(x.artist())
+	})
+}
+
+// Labels decodes a labels dump, calling fn for every label. Decoding stops at the first error returned by fn, which
+// is returned.
+func Labels(r io.Reader, fn func(*discogs.Label) error) error {
+	return each(r, "label", func(d *xml.Decoder, start *xml.StartElement) error {
+		var x xmlLabel
+		if err := d.DecodeElement(&x, start); err != nil {
+			return err
+		}
+		return fn(x.label())
+	})
+}
+
+// Masters decodes a masters dump, calling fn for every master release. Decoding stops at the first error returned
+// by fn, which is returned.
+func Masters(r io.Reader, fn func(*discogs.Master) error) error {
+	return each(r, "master", func(d *xml.Decoder, start *xml.StartElement) error {
+		var x xmlMaster
+		if err := d.DecodeElement(&x, start); err != nil {
+			return err
+		}
+		return fn(x.master())
+	})
+}
+
+// each calls fn for every element with the given name below the root element of the dump read from r.
+func each(r io.Reader, name string, fn func(d *xml.Decoder, start *xml.StartElement) error) error {
+	r, err := decompress(r)
+	if err != nil {
+		return err
+	}
+
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == name {
+			if err := fn(d, &start); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// decompress returns a reader decompressing r if it's gzip compressed, or r itself otherwise.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+func resourceURL(kind string, id int) string {
+	if id == 0 {
+		return ""
+	}
+	return apiURL + "/" + kind + "/" + strconv.Itoa(id)
+}
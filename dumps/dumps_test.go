@@ -0,0 +1,166 @@
+package dumps
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/irlndts/go-discogs"
+)
+
+func open(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to open %s: %s", name, err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestReleases(t *testing.T) {
+	var releases []*discogs.Release
+	err := Releases(open(t, "releases.xml"), func(r *discogs.Release) error {
+		releases = append(releases, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to decode releases: %s", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("releases got=%d; want=2", len(releases))
+	}
+
+	r := releases[0]
+	if r.ID != 1 || r.Title != "Stockholm" || r.Status != discogs.ReleaseStatusAccepted || r.Year != 1999 || r.MasterID != 5427 {
+		t.Errorf("release got=%+v", r)
+	}
+	if want := []discogs.LabelSource{{ID: 5, Name: "Svek", Catno: "SK032", ResourceURL: "https://api.discogs.com/labels/5"}}; !reflect.DeepEqual(r.Labels, want) {
+		t.Errorf("labels got=%+v; want=%+v", r.Labels, want)
+	}
+	if len(r.Formats) != 1 || r.Formats[0].Name != discogs.FormatVinyl || r.FormatQuantity != 2 || len(r.Formats[0].Descriptions) != 2 {
+		t.Errorf("formats got=%+v", r.Formats)
+	}
+	if len(r.Tracklist) != 2 || r.Tracklist[0].Title != "Östermalm" || len(r.Tracklist[1].SubTracks) != 1 || r.Tracklist[1].Type != "index" {
+		t.Errorf("tracklist got=%+v", r.Tracklist)
+	}
+	if len(r.ExtraArtists) != 1 || r.ExtraArtists[0].Role != "Music By [All Tracks By]" {
+		t.Errorf("extra artists got=%+v", r.ExtraArtists)
+	}
+	if len(r.Identifiers) != 1 || r.Identifiers[0].Value != "MPO SK 032 A1" {
+		t.Errorf("identifiers got=%+v", r.Identifiers)
+	}
+	if len(r.Videos) != 1 || !r.Videos[0].Embed || r.Videos[0].Duration != 290 {
+		t.Errorf("videos got=%+v", r.Videos)
+	}
+	if len(r.Companies) != 1 || r.Companies[0].EntityTypeName != "Recorded At" {
+		t.Errorf("companies got=%+v", r.Companies)
+	}
+	if releases[1].Status != discogs.ReleaseStatusDraft {
+		t.Errorf("status got=%s; want=%s", releases[1].Status, discogs.ReleaseStatusDraft)
+	}
+}
+
+func TestArtists(t *testing.T) {
+	var artists []*discogs.Artist
+	err := Artists(open(t, "artists.xml"), func(a *discogs.Artist) error {
+		artists = append(artists, a)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to decode artists: %s", err)
+	}
+	if len(artists) != 2 {
+		t.Fatalf("artists got=%d; want=2", len(artists))
+	}
+
+	a := artists[0]
+	if a.ID != 1 || a.Realname != "Jesper Dahlbäck" || len(a.URLs) != 1 || len(a.Namevariations) != 1 || len(a.Images) != 1 {
+		t.Errorf("artist got=%+v", a)
+	}
+	if want := []discogs.Alias{{ID: 239, Name: "Jesper Dahlbäck", ResourceURL: "https://api.discogs.com/artists/239"}}; !reflect.DeepEqual(a.Aliases, want) {
+		t.Errorf("aliases got=%+v; want=%+v", a.Aliases, want)
+	}
+	if len(a.Groups) != 1 || a.Groups[0].Name != "Dahlbäck & Dahlbäck" {
+		t.Errorf("groups got=%+v", a.Groups)
+	}
+	if m := artists[1].Members; len(m) != 2 || m[1].ID != 240 || m[1].Name != "John Dahlbäck" {
+		t.Errorf("members got=%+v", m)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	var labels []*discogs.Label
+	err := Labels(open(t, "labels.xml"), func(l *discogs.Label) error {
+		labels = append(labels, l)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to decode labels: %s", err)
+	}
+	if len(labels) != 2 {
+		t.Fatalf("labels got=%d; want=2", len(labels))
+	}
+
+	if l := labels[0]; l.Name != "Planet E" || l.ContactInfo != "Planet E Communications" || len(l.Sublabels) != 2 || l.Sublabels[1].ID != 41841 || l.ParentLabel != nil {
+		t.Errorf("label got=%+v", l)
+	}
+	if p := labels[1].ParentLabel; p == nil || p.ID != 1 || p.Name != "Planet E" {
+		t.Errorf("parent label got=%+v", p)
+	}
+}
+
+func TestMasters(t *testing.T) {
+	var masters []*discogs.Master
+	err := Masters(open(t, "masters.xml"), func(m *discogs.Master) error {
+		masters = append(masters, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to decode masters: %s", err)
+	}
+	if len(masters) != 1 {
+		t.Fatalf("masters got=%d; want=1", len(masters))
+	}
+	if m := masters[0]; m.ID != 5427 || m.MainRelease != 1 || m.Year != 1999 || len(m.Artists) != 1 || m.DataQuality != "Correct" {
+		t.Errorf("master got=%+v", m)
+	}
+}
+
+func TestGzip(t *testing.T) {
+	data, err := os.ReadFile("testdata/masters.xml")
+	if err != nil {
+		t.Fatalf("failed to read dump: %s", err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatalf("failed to compress dump: %s", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to compress dump: %s", err)
+	}
+
+	n := 0
+	if err := Masters(&buf, func(m *discogs.Master) error { n++; return nil }); err != nil {
+		t.Fatalf("failed to decode masters: %s", err)
+	}
+	if n != 1 {
+		t.Errorf("masters got=%d; want=1", n)
+	}
+}
+
+func TestStop(t *testing.T) {
+	stop := errors.New("stop")
+	n := 0
+	err := Releases(open(t, "releases.xml"), func(r *discogs.Release) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Errorf("got err=%v after %d releases; want=%v after 1", err, n, stop)
+	}
+}
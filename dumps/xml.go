@@ -0,0 +1,309 @@
+package dumps
+
+import (
+	"strconv"
+
+	"github.com/irlndts/go-discogs"
+)
+
+// The types below mirror the XML structure of the dumps and convert it to the types returned by the API.
+
+type xmlImage struct {
+	Height int    `xml:"height,attr"`
+	Width  int    `xml:"width,attr"`
+	Type   string `xml:"type,attr"`
+	URI    string `xml:"uri,attr"`
+	URI150 string `xml:"uri150,attr"`
+}
+
+func images(xs []xmlImage) []discogs.Image {
+	var images []discogs.Image
+	for _, x := range xs {
+		images = append(images, discogs.Image{Height: x.Height, Width: x.Width, Type: x.Type, URI: x.URI, URI150: x.URI150})
+	}
+	return images
+}
+
+type xmlArtistCredit struct {
+	ID     int    `xml:"id"`
+	Name   string `xml:"name"`
+	ANV    string `xml:"anv"`
+	Join   string `xml:"join"`
+	Role   string `xml:"role"`
+	Tracks string `xml:"tracks"`
+}
+
+func artistCredits(xs []xmlArtistCredit) []discogs.ArtistSource {
+	var artists []discogs.ArtistSource
+	for _, x := range xs {
+		artists = append(artists, discogs.ArtistSource{
+			ID:          x.ID,
+			Name:        x.Name,
+			Anv:         x.ANV,
+			Join:        x.Join,
+			Role:        x.Role,
+			Tracks:      x.Tracks,
+			ResourceURL: resourceURL("artists", x.ID),
+		})
+	}
+	return artists
+}
+
+type xmlLabelRef struct {
+	ID    int    `xml:"id,attr"`
+	Name  string `xml:"name,attr"`
+	Catno string `xml:"catno,attr"`
+}
+
+type xmlNameRef struct {
+	ID   int    `xml:"id,attr"`
+	Name string `xml:",chardata"`
+}
+
+type xmlFormat struct {
+	Name         string   `xml:"name,attr"`
+	Qty          string   `xml:"qty,attr"`
+	Text         string   `xml:"text,attr"`
+	Descriptions []string `xml:"descriptions>description"`
+}
+
+type xmlTrack struct {
+	Position     string            `xml:"position"`
+	Title        string            `xml:"title"`
+	Duration     string            `xml:"duration"`
+	Artists      []xmlArtistCredit `xml:"artists>artist"`
+	ExtraArtists []xmlArtistCredit `xml:"extraartists>artist"`
+	SubTracks    []xmlTrack        `xml:"sub_tracks>track"`
+}
+
+func tracks(xs []xmlTrack) []discogs.Track {
+	var list []discogs.Track
+	for _, x := range xs {
+		t := discogs.Track{
+			Position:     x.Position,
+			Title:        x.Title,
+			Duration:     x.Duration,
+			Type:         "track",
+			Artists:      artistCredits(x.Artists),
+			Extraartists: artistCredits(x.ExtraArtists),
+			SubTracks:    tracks(x.SubTracks),
+		}
+		if len(t.SubTracks) > 0 {
+			t.Type = "index"
+		}
+		list = append(list, t)
+	}
+	return list
+}
+
+type xmlIdentifier struct {
+	Type        string `xml:"type,attr"`
+	Value       string `xml:"value,attr"`
+	Description string `xml:"description,attr"`
+}
+
+type xmlVideo struct {
+	Src         string `xml:"src,attr"`
+	Duration    int    `xml:"duration,attr"`
+	Embed       bool   `xml:"embed,attr"`
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+}
+
+func videos(xs []xmlVideo) []discogs.Video {
+	var videos []discogs.Video
+	for _, x := range xs {
+		videos = append(videos, discogs.Video{URI: x.Src, Duration: x.Duration, Embed: x.Embed, Title: x.Title, Description: x.Description})
+	}
+	return videos
+}
+
+type xmlCompany struct {
+	ID             int    `xml:"id"`
+	Name           string `xml:"name"`
+	Catno          string `xml:"catno"`
+	EntityType     string `xml:"entity_type"`
+	EntityTypeName string `xml:"entity_type_name"`
+}
+
+type xmlRelease struct {
+	ID           int               `xml:"id,attr"`
+	Status       string            `xml:"status,attr"`
+	Images       []xmlImage        `xml:"images>image"`
+	Artists      []xmlArtistCredit `xml:"artists>artist"`
+	ExtraArtists []xmlArtistCredit `xml:"extraartists>artist"`
+	Title        string            `xml:"title"`
+	Labels       []xmlLabelRef     `xml:"labels>label"`
+	Series       []xmlLabelRef     `xml:"series>series"`
+	Formats      []xmlFormat       `xml:"formats>format"`
+	Genres       []string          `xml:"genres>genre"`
+	Styles       []string          `xml:"styles>style"`
+	Country      string            `xml:"country"`
+	Released     string            `xml:"released"`
+	Notes        string            `xml:"notes"`
+	DataQuality  string            `xml:"data_quality"`
+	MasterID     int               `xml:"master_id"`
+	Tracklist    []xmlTrack        `xml:"tracklist>track"`
+	Identifiers  []xmlIdentifier   `xml:"identifiers>identifier"`
+	Videos       []xmlVideo        `xml:"videos>video"`
+	Companies    []xmlCompany      `xml:"companies>company"`
+}
+
+func (x xmlRelease) release() *discogs.Release {
+	r := &discogs.Release{
+		ID:           x.ID,
+		Status:       discogs.ReleaseStatus(x.Status),
+		Images:       images(x.Images),
+		Artists:      artistCredits(x.Artists),
+		ExtraArtists: artistCredits(x.ExtraArtists),
+		Title:        x.Title,
+		Genres:       x.Genres,
+		Styles:       x.Styles,
+		Country:      x.Country,
+		Released:     x.Released,
+		Notes:        x.Notes,
+		DataQuality:  x.DataQuality,
+		MasterID:     x.MasterID,
+		MasterURL:    resourceURL("masters", x.MasterID),
+		Tracklist:    tracks(x.Tracklist),
+		Videos:       videos(x.Videos),
+		ResourceURL:  resourceURL("releases", x.ID),
+	}
+	if len(x.Released) >= 4 {
+		r.Year, _ = strconv.Atoi(x.Released[:4])
+	}
+	for _, l := range x.Labels {
+		r.Labels = append(r.Labels, discogs.LabelSource{ID: l.ID, Name: l.Name, Catno: l.Catno, ResourceURL: resourceURL("labels", l.ID)})
+	}
+	for _, s := range x.Series {
+		r.Series = append(r.Series, discogs.Series{ID: s.ID, Name: s.Name, Catno: s.Catno, ResourceURL: resourceURL("labels", s.ID)})
+	}
+	for _, f := range x.Formats {
+		r.Formats = append(r.Formats, discogs.Format{Name: discogs.FormatName(f.Name), Qty: f.Qty, Text: f.Text, Descriptions: f.Descriptions})
+		qty, _ := strconv.Atoi(f.Qty)
+		r.FormatQuantity += qty
+	}
+	for _, i := range x.Identifiers {
+		r.Identifiers = append(r.Identifiers, discogs.Identifier{Type: i.Type, Value: i.Value, Description: i.Description})
+	}
+	for _, c := range x.Companies {
+		r.Companies = append(r.Companies, discogs.Company{
+			ID:             c.ID,
+			Name:           c.Name,
+			Catno:          c.Catno,
+			EntityType:     c.EntityType,
+			EntityTypeName: c.EntityTypeName,
+			ResourceURL:    resourceURL("labels", c.ID),
+		})
+	}
+	return r
+}
+
+type xmlArtist struct {
+	ID             int          `xml:"id"`
+	Name           string       `xml:"name"`
+	RealName       string       `xml:"realname"`
+	Profile        string       `xml:"profile"`
+	DataQuality    string       `xml:"data_quality"`
+	Images         []xmlImage   `xml:"images>image"`
+	URLs           []string     `xml:"urls>url"`
+	NameVariations []string     `xml:"namevariations>name"`
+	Aliases        []xmlNameRef `xml:"aliases>name"`
+	Members        []xmlNameRef `xml:"members>name"`
+	Groups         []xmlNameRef `xml:"groups>name"`
+}
+
+func members(xs []xmlNameRef) []discogs.Member {
+	var members []discogs.Member
+	for _, x := range xs {
+		members = append(members, discogs.Member{ID: x.ID, Name: x.Name, ResourceURL: resourceURL("artists", x.ID)})
+	}
+	return members
+}
+
+func (x xmlArtist) artist() *discogs.Artist {
+	a := &discogs.Artist{
+		ID:             x.ID,
+		Name:           x.Name,
+		Realname:       x.RealName,
+		Profile:        x.Profile,
+		DataQuality:    x.DataQuality,
+		Images:         images(x.Images),
+		URLs:           x.URLs,
+		Namevariations: x.NameVariations,
+		Members:        members(x.Members),
+		Groups:         members(x.Groups),
+		ResourceURL:    resourceURL("artists", x.ID),
+		ReleasesURL:    resourceURL("artists", x.ID) + "/releases",
+	}
+	for _, alias := range x.Aliases {
+		a.Aliases = append(a.Aliases, discogs.Alias{ID: alias.ID, Name: alias.Name, ResourceURL: resourceURL("artists", alias.ID)})
+	}
+	return a
+}
+
+type xmlLabel struct {
+	ID          int          `xml:"id"`
+	Name        string       `xml:"name"`
+	ContactInfo string       `xml:"contactinfo"`
+	Profile     string       `xml:"profile"`
+	DataQuality string       `xml:"data_quality"`
+	Images      []xmlImage   `xml:"images>image"`
+	URLs        []string     `xml:"urls>url"`
+	Sublabels   []xmlNameRef `xml:"sublabels>label"`
+	ParentLabel *xmlNameRef  `xml:"parentLabel"`
+}
+
+func (x xmlLabel) label() *discogs.Label {
+	l := &discogs.Label{
+		ID:          x.ID,
+		Name:        x.Name,
+		ContactInfo: x.ContactInfo,
+		Profile:     x.Profile,
+		DataQuality: x.DataQuality,
+		Images:      images(x.Images),
+		URLs:        x.URLs,
+		ResourceURL: resourceURL("labels", x.ID),
+		ReleasesURL: resourceURL("labels", x.ID) + "/releases",
+	}
+	for _, s := range x.Sublabels {
+		l.Sublabels = append(l.Sublabels, discogs.Sublable{ID: s.ID, Name: s.Name, ResourceURL: resourceURL("labels", s.ID)})
+	}
+	if x.ParentLabel != nil {
+		l.ParentLabel = &discogs.Sublable{ID: x.ParentLabel.ID, Name: x.ParentLabel.Name, ResourceURL: resourceURL("labels", x.ParentLabel.ID)}
+	}
+	return l
+}
+
+type xmlMaster struct {
+	ID          int               `xml:"id,attr"`
+	MainRelease int               `xml:"main_release"`
+	Images      []xmlImage        `xml:"images>image"`
+	Artists     []xmlArtistCredit `xml:"artists>artist"`
+	Genres      []string          `xml:"genres>genre"`
+	Styles      []string          `xml:"styles>style"`
+	Year        int               `xml:"year"`
+	Title       string            `xml:"title"`
+	Notes       string            `xml:"notes"`
+	DataQuality string            `xml:"data_quality"`
+	Videos      []xmlVideo        `xml:"videos>video"`
+}
+
+func (x xmlMaster) master() *discogs.Master {
+	return &discogs.Master{
+		ID:             x.ID,
+		MainRelease:    x.MainRelease,
+		MainReleaseURL: resourceURL("releases", x.MainRelease),
+		Images:         images(x.Images),
+		Artists:        artistCredits(x.Artists),
+		Genres:         x.Genres,
+		Styles:         x.Styles,
+		Year:           x.Year,
+		Title:          x.Title,
+		Notes:          x.Notes,
+		DataQuality:    x.DataQuality,
+		Videos:         videos(x.Videos),
+		ResourceURL:    resourceURL("masters", x.ID),
+		VersionsURL:    resourceURL("masters", x.ID) + "/versions",
+	}
+}
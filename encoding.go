@@ -0,0 +1,75 @@
+package discogs
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is the Accept-Encoding header sent with every request. Discogs compresses large payloads such as
+// releases and search pages considerably.
+const acceptEncoding = "gzip, deflate"
+
+// decompress replaces the body of a compressed response with a reader of the decompressed body. Responses that were
+// already decompressed by the HTTP transport, or that aren't compressed, are left unchanged.
+func decompress(response *http.Response) error {
+	encoding := strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding")))
+	if response.Uncompressed || encoding == "" || encoding == "identity" {
+		return nil
+	}
+
+	var r io.Reader
+	switch encoding {
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(response.Body)
+		if err != nil {
+			response.Body.Close()
+			return err
+		}
+		r = gz
+	case "deflate":
+		// deflate is meant to be zlib wrapped, but some servers send raw deflate data
+		br := bufio.NewReader(response.Body)
+		if header, err := br.Peek(2); err == nil && isZlibHeader(header) {
+			zr, err := zlib.NewReader(br)
+			if err != nil {
+				response.Body.Close()
+				return err
+			}
+			r = zr
+		} else {
+			r = flate.NewReader(br)
+		}
+	default:
+		return nil
+	}
+
+	response.Body = &decompressedBody{Reader: r, body: response.Body}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	response.Uncompressed = true
+	return nil
+}
+
+// isZlibHeader reports whether b starts with a zlib header.
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
+
+// decompressedBody reads a decompressed response body and closes the underlying body.
+type decompressedBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+func (b *decompressedBody) Close() error {
+	if c, ok := b.Reader.(io.Closer); ok {
+		c.Close()
+	}
+	return b.body.Close()
+}
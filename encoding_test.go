@@ -0,0 +1,64 @@
+package discogs
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompressedResponses(t *testing.T) {
+	compressors := map[string]func(w io.Writer) io.WriteCloser{
+		"gzip":         func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate":      func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		"raw deflate":  func(w io.Writer) io.WriteCloser { fw, _ := flate.NewWriter(w, flate.DefaultCompression); return fw },
+		"uncompressed": nil,
+	}
+
+	for name, compress := range compressors {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept-Encoding") != acceptEncoding {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if compress == nil {
+					DatabaseServer(w, r)
+					return
+				}
+
+				var b bytes.Buffer
+				cw := compress(&b)
+				if _, err := io.WriteString(cw, releaseJson); err != nil {
+					t.Errorf("failed to compress response: %s", err)
+				}
+				cw.Close()
+
+				encoding := name
+				if name == "raw deflate" {
+					encoding = "deflate"
+				}
+				w.Header().Set("Content-Encoding", encoding)
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write(b.Bytes()); err != nil {
+					t.Errorf("failed to write response: %s", err)
+				}
+			}))
+			defer ts.Close()
+
+			d := initDiscogsClient(t, &Options{URL: ts.URL})
+			release, err := d.Release(context.Background(), 8138518)
+			if err != nil {
+				t.Fatalf("failed to get release: %s", err)
+			}
+			if release.ID != 8138518 || release.Title != "Elephant Riddim" {
+				t.Errorf("release got=%d %q; want=8138518 %q", release.ID, release.Title, "Elephant Riddim")
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package discogs
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Condition is the grade of a release's media or sleeve as used by the marketplace.
+// https://www.discogs.com/selling/resources/how-to-grade-items
+type Condition string
+
+// Media and sleeve conditions, best first.
+const (
+	ConditionMint         Condition = "Mint (M)"
+	ConditionNearMint     Condition = "Near Mint (NM or M-)"
+	ConditionVeryGoodPlus Condition = "Very Good Plus (VG+)"
+	ConditionVeryGood     Condition = "Very Good (VG)"
+	ConditionGoodPlus     Condition = "Good Plus (G+)"
+	ConditionGood         Condition = "Good (G)"
+	ConditionFair         Condition = "Fair (F)"
+	ConditionPoor         Condition = "Poor (P)"
+	ConditionGeneric      Condition = "Generic"    // sleeve only
+	ConditionNotGraded    Condition = "Not Graded" // sleeve only
+	ConditionNoCover      Condition = "No Cover"   // sleeve only
+)
+
+// conditionAbbreviations maps the abbreviations of the conditions to the conditions.
+var conditionAbbreviations = map[string]Condition{
+	"M":   ConditionMint,
+	"NM":  ConditionNearMint,
+	"M-":  ConditionNearMint,
+	"VG+": ConditionVeryGoodPlus,
+	"VG":  ConditionVeryGood,
+	"G+":  ConditionGoodPlus,
+	"G":   ConditionGood,
+	"F":   ConditionFair,
+	"P":   ConditionPoor,
+}
+
+var conditions = []Condition{
+	ConditionMint, ConditionNearMint, ConditionVeryGoodPlus, ConditionVeryGood, ConditionGoodPlus, ConditionGood,
+	ConditionFair, ConditionPoor, ConditionGeneric, ConditionNotGraded, ConditionNoCover,
+}
+
+// ParseCondition returns the condition with the given name or abbreviation, e.g. "Very Good Plus (VG+)" or "VG+",
+// ignoring case.
+func ParseCondition(s string) (Condition, error) {
+	s = strings.TrimSpace(s)
+	if c, ok := conditionAbbreviations[strings.ToUpper(s)]; ok {
+		return c, nil
+	}
+	for _, c := range conditions {
+		if strings.EqualFold(s, string(c)) {
+			return c, nil
+		}
+	}
+	return "", ErrInvalidCondition
+}
+
+// Abbreviation returns the abbreviation of the condition, e.g. "VG+", or the condition itself if it has none.
+func (c Condition) Abbreviation() string {
+	if i := strings.LastIndexByte(string(c), '('); i >= 0 && strings.HasSuffix(string(c), ")") {
+		abbr := string(c)[i+1 : len(c)-1]
+		return strings.TrimSuffix(abbr, " or M-")
+	}
+	return string(c)
+}
+
+// SleeveOnly reports whether the condition only applies to sleeves.
+func (c Condition) SleeveOnly() bool {
+	return c == ConditionGeneric || c == ConditionNotGraded || c == ConditionNoCover
+}
+
+func (c Condition) String() string {
+	return string(c)
+}
+
+// FormatName is the name of a release format.
+type FormatName string
+
+// Common format names.
+const (
+	FormatVinyl       FormatName = "Vinyl"
+	FormatAcetate     FormatName = "Acetate"
+	FormatFlexiDisc   FormatName = "Flexi-disc"
+	FormatLatheCut    FormatName = "Lathe Cut"
+	FormatShellac     FormatName = "Shellac"
+	FormatCassette    FormatName = "Cassette"
+	FormatEightTrack  FormatName = "8-Track Cartridge"
+	FormatReelToReel  FormatName = "Reel-To-Reel"
+	FormatCD          FormatName = "CD"
+	FormatCDr         FormatName = "CDr"
+	FormatSACD        FormatName = "SACD"
+	FormatMinidisc    FormatName = "Minidisc"
+	FormatDVD         FormatName = "DVD"
+	FormatDVDr        FormatName = "DVDr"
+	FormatBluray      FormatName = "Blu-ray"
+	FormatVHS         FormatName = "VHS"
+	FormatFile        FormatName = "File"
+	FormatMemoryStick FormatName = "Memory Stick"
+	FormatAllMedia    FormatName = "All Media"
+	FormatBoxSet      FormatName = "Box Set"
+)
+
+var formatNames = []FormatName{
+	FormatVinyl, FormatAcetate, FormatFlexiDisc, FormatLatheCut, FormatShellac, FormatCassette, FormatEightTrack,
+	FormatReelToReel, FormatCD, FormatCDr, FormatSACD, FormatMinidisc, FormatDVD, FormatDVDr, FormatBluray, FormatVHS,
+	FormatFile, FormatMemoryStick, FormatAllMedia, FormatBoxSet,
+}
+
+// ParseFormatName returns the format with the given name, ignoring case.
+func ParseFormatName(s string) (FormatName, error) {
+	s = strings.TrimSpace(s)
+	for _, f := range formatNames {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+	return "", ErrInvalidFormat
+}
+
+func (f FormatName) String() string {
+	return string(f)
+}
+
+// FormatFlags is a set of pressing attributes parsed from the descriptions of a format.
+type FormatFlags uint
+
+// Format flags, each matching a description of a format, e.g. "LP" or `7"`.
+const (
+	FlagLP FormatFlags = 1 << iota
+	Flag7Inch
+	Flag10Inch
+	Flag12Inch
+	Flag33RPM
+	Flag45RPM
+	FlagReissue
+	FlagRemastered
+	FlagLimitedEdition
+	// FlagColored marks colored media, which Discogs notes in the free text of a format, e.g. "Red Marbled".
+	FlagColored
+)
+
+// formatDescriptions holds the descriptions of the flags in flag order.
+var formatDescriptions = []struct {
+	flag        FormatFlags
+	description string
+}{
+	{FlagLP, "LP"},
+	{Flag7Inch, `7"`},
+	{Flag10Inch, `10"`},
+	{Flag12Inch, `12"`},
+	{Flag33RPM, "33 ⅓ RPM"},
+	{Flag45RPM, "45 RPM"},
+	{FlagReissue, "Reissue"},
+	{FlagRemastered, "Remastered"},
+	{FlagLimitedEdition, "Limited Edition"},
+	{FlagColored, "Colored"},
+}
+
+// colors are the words in the free text of a format marking colored media. "White" is left out as white labels are
+// promotional pressings.
+var colors = map[string]bool{
+	"colored": true, "coloured": true, "red": true, "orange": true, "yellow": true, "green": true, "blue": true,
+	"purple": true, "violet": true, "pink": true, "grey": true, "gray": true, "gold": true, "silver": true,
+	"clear": true, "transparent": true, "translucent": true, "marbled": true, "splatter": true, "swirl": true,
+}
+
+// Has reports whether all of the given flags are set.
+func (f FormatFlags) Has(flags FormatFlags) bool {
+	return f&flags == flags
+}
+
+// String returns the descriptions of the set flags, e.g. `LP, 12", Reissue`.
+func (f FormatFlags) String() string {
+	var descriptions []string
+	for _, d := range formatDescriptions {
+		if f.Has(d.flag) {
+			descriptions = append(descriptions, d.description)
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// Flags parses the descriptions and free text of the format into flags. Descriptions without a flag are ignored.
+func (f Format) Flags() FormatFlags {
+	var flags FormatFlags
+	for _, d := range formatDescriptions {
+		if f.Is(d.description) {
+			flags |= d.flag
+		}
+	}
+	if f.Name == FormatVinyl || f.Name == FormatCassette || f.Name == FormatFlexiDisc || f.Name == FormatShellac {
+		words := strings.FieldsFunc(strings.ToLower(f.Text), func(r rune) bool { return !unicode.IsLetter(r) })
+		for _, w := range words {
+			if colors[w] {
+				flags |= FlagColored
+				break
+			}
+		}
+	}
+	return flags
+}
+
+// Is reports whether the format has the given description, e.g. "Reissue" or `7"`, ignoring case.
+func (f Format) Is(desc string) bool {
+	desc = strings.TrimSpace(desc)
+	for _, d := range f.Descriptions {
+		if strings.EqualFold(d, desc) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleaseStatus is the status of a release's submission.
+type ReleaseStatus string
+
+// Release statuses.
+const (
+	ReleaseStatusAccepted ReleaseStatus = "Accepted"
+	ReleaseStatusDraft    ReleaseStatus = "Draft"
+	ReleaseStatusDeleted  ReleaseStatus = "Deleted"
+	ReleaseStatusRejected ReleaseStatus = "Rejected"
+)
+
+// ParseReleaseStatus returns the release status with the given name, ignoring case.
+func ParseReleaseStatus(s string) (ReleaseStatus, error) {
+	s = strings.TrimSpace(s)
+	for _, st := range []ReleaseStatus{ReleaseStatusAccepted, ReleaseStatusDraft, ReleaseStatusDeleted, ReleaseStatusRejected} {
+		if strings.EqualFold(s, string(st)) {
+			return st, nil
+		}
+	}
+	return "", ErrInvalidReleaseStatus
+}
+
+func (s ReleaseStatus) String() string {
+	return string(s)
+}
+
+// Accepted reports whether the release's submission was accepted into the database. Responses that don't report a
+// status are taken to be accepted.
+func (s ReleaseStatus) Accepted() bool {
+	return s == "" || s == ReleaseStatusAccepted
+}
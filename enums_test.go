@@ -0,0 +1,121 @@
+package discogs
+
+import (
+	"testing"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want Condition
+		err  error
+	}{
+		"name":               {"Very Good Plus (VG+)", ConditionVeryGoodPlus, nil},
+		"name ignoring case": {"near mint (nm or m-)", ConditionNearMint, nil},
+		"abbreviation":       {"vg+", ConditionVeryGoodPlus, nil},
+		"alternative":        {"M-", ConditionNearMint, nil},
+		"sleeve only":        {"Generic", ConditionGeneric, nil},
+		"invalid":            {"VG++", "", ErrInvalidCondition},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseCondition(tt.in)
+			if err != tt.err {
+				t.Fatalf("err got=%v; want=%v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("condition got=%s; want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionAbbreviation(t *testing.T) {
+	tests := map[Condition]string{
+		ConditionMint:         "M",
+		ConditionNearMint:     "NM",
+		ConditionVeryGoodPlus: "VG+",
+		ConditionPoor:         "P",
+		ConditionNotGraded:    "Not Graded",
+	}
+	for c, want := range tests {
+		if got := c.Abbreviation(); got != want {
+			t.Errorf("%s abbreviation got=%s; want=%s", c, got, want)
+		}
+		if parsed, err := ParseCondition(c.Abbreviation()); err != nil || parsed != c {
+			t.Errorf("%s parsed abbreviation got=%s, %v", c, parsed, err)
+		}
+	}
+	if !ConditionNoCover.SleeveOnly() || ConditionGood.SleeveOnly() {
+		t.Error("unexpected sleeve only conditions")
+	}
+}
+
+func TestParseFormatName(t *testing.T) {
+	if f, err := ParseFormatName("vinyl"); err != nil || f != FormatVinyl {
+		t.Errorf("format got=%s, %v; want=%s", f, err, FormatVinyl)
+	}
+	if f, err := ParseFormatName("Blu-Ray"); err != nil || f != FormatBluray {
+		t.Errorf("format got=%s, %v; want=%s", f, err, FormatBluray)
+	}
+	if _, err := ParseFormatName("Wax Cylinder"); err != ErrInvalidFormat {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidFormat)
+	}
+}
+
+func TestParseReleaseStatus(t *testing.T) {
+	if s, err := ParseReleaseStatus("accepted"); err != nil || s != ReleaseStatusAccepted {
+		t.Errorf("status got=%s, %v; want=%s", s, err, ReleaseStatusAccepted)
+	}
+	if _, err := ParseReleaseStatus("Pending"); err != ErrInvalidReleaseStatus {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidReleaseStatus)
+	}
+}
+
+func TestFormatFlags(t *testing.T) {
+	tests := map[string]struct {
+		format Format
+		want   FormatFlags
+	}{
+		"lp":      {Format{Name: FormatVinyl, Descriptions: []string{"LP", "Album", "Reissue", "Remastered"}}, FlagLP | FlagReissue | FlagRemastered},
+		"single":  {Format{Name: FormatVinyl, Descriptions: []string{`7"`, "45 RPM", "Single"}}, Flag7Inch | Flag45RPM},
+		"colored": {Format{Name: FormatVinyl, Descriptions: []string{`12"`, "limited edition"}, Text: "Red Marbled"}, Flag12Inch | FlagLimitedEdition | FlagColored},
+		"white":   {Format{Name: FormatVinyl, Descriptions: []string{`12"`, "Promo"}, Text: "White Label"}, Flag12Inch},
+		"cd":      {Format{Name: FormatCD, Descriptions: []string{"Album"}, Text: "Blue Box"}, 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.format.Flags(); got != tt.want {
+				t.Errorf("flags got=%s; want=%s", got, tt.want)
+			}
+		})
+	}
+
+	flags := FlagLP | Flag12Inch | FlagReissue
+	if !flags.Has(FlagLP|FlagReissue) || flags.Has(FlagLP|FlagColored) {
+		t.Errorf("unexpected flags of %s", flags)
+	}
+	if s := flags.String(); s != `LP, 12", Reissue` {
+		t.Errorf("flags got=%s; want=LP, 12\", Reissue", s)
+	}
+
+	f := Format{Name: FormatVinyl, Descriptions: []string{"LP", "Album"}}
+	if !f.Is("album") || f.Is("Single") {
+		t.Error("unexpected descriptions")
+	}
+}
+
+func TestReleaseStatusAccepted(t *testing.T) {
+	tests := map[ReleaseStatus]bool{
+		"":                    true,
+		ReleaseStatusAccepted: true,
+		ReleaseStatusDraft:    false,
+		ReleaseStatusDeleted:  false,
+		ReleaseStatusRejected: false,
+	}
+	for status, want := range tests {
+		if got := status.Accepted(); got != want {
+			t.Errorf("%q accepted got=%t; want=%t", status, got, want)
+		}
+	}
+}
@@ -1,26 +1,279 @@
 package discogs
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
 	"strings"
 )
 
-// Error represents a Discogs API error
+// Error represents a Discogs error
 type Error struct {
 	Message string
+
+	// kind is the category of the error, if any
+	kind *Error
 }
 
 func (e *Error) Error() string {
 	return fmt.Sprintf("discogs error: %s", strings.ToLower(e.Message))
 }
 
-// APIErrors
+// Is reports whether e falls into the category target, e.g. ErrValidation for ErrInvalidUsername.
+func (e *Error) Is(target error) bool {
+	return e.kind != nil && e.kind == target
+}
+
+// Error categories. Errors returned by the package fall into three categories, which errors.Is matches however an
+// error is wrapped:
+//
+//   - ErrValidation: the input of a call was rejected, either by the client before sending a request, e.g.
+//     ErrInvalidUsername, or by Discogs with a ValidationError.
+//   - ErrTransport: no usable response was received, e.g. a TransportError for network errors, ErrCircuitOpen or
+//     ErrResponseTooLarge.
+//   - ErrAPI: Discogs responded with an error, e.g. ErrNotFound, a StatusError or a ValidationError.
+//
+// A few errors, such as ErrDryRun or ErrNoMatchingVersion, report outcomes rather than failures and fall into none.
+var (
+	ErrAPI        = &Error{Message: "api error"}
+	ErrTransport  = &Error{Message: "transport error"}
+	ErrValidation = &Error{Message: "validation error"}
+)
+
+// StatusError is returned for unsuccessful responses which aren't represented by one of the API errors.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unknown error: %s", e.Status)
+}
+
+// Is reports whether target is ErrAPI.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrAPI
+}
+
+// ReleaseStatusError is returned for releases whose submission wasn't accepted, e.g. drafts or rejected ones, for
+// which Discogs returns only some of the fields. Release holds those fields.
+type ReleaseStatusError struct {
+	ReleaseID int
+	Status    ReleaseStatus
+	Release   *Release
+}
+
+func (e *ReleaseStatusError) Error() string {
+	return fmt.Sprintf("%s: release %d is %s", ErrReleaseNotAccepted, e.ReleaseID, strings.ToLower(string(e.Status)))
+}
+
+// Is reports whether target is ErrReleaseNotAccepted or ErrAPI.
+func (e *ReleaseStatusError) Is(target error) bool {
+	return target == ErrReleaseNotAccepted || target == ErrAPI
+}
+
+// TransportError is returned when a request couldn't be sent or its response couldn't be read, e.g. for network
+// errors. Err is the underlying error, such as a *url.Error.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTransport.
+func (e *TransportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// transportError returns err as a TransportError unless it falls into a category already, e.g. ErrQueueFull returned
+// by middleware.
+func transportError(err error) error {
+	if errors.Is(err, ErrTransport) || errors.Is(err, ErrValidation) || errors.Is(err, ErrAPI) {
+		return err
+	}
+	return &TransportError{Err: err}
+}
+
+// ValidationError is returned for 422 Unprocessable Entity responses, which Discogs sends when it rejects the values of
+// a request, e.g. a listing's price. Fields maps the rejected fields to the messages explaining why.
+type ValidationError struct {
+	Message string
+	Fields  map[string][]string
+}
+
+// Is reports whether target is ErrValidation or ErrAPI.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation || target == ErrAPI
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	msgs := make([]string, 0, len(fields)+1)
+	if e.Message != "" {
+		msgs = append(msgs, e.Message)
+	}
+	for _, field := range fields {
+		msgs = append(msgs, field+": "+strings.Join(e.Fields[field], ", "))
+	}
+	if len(msgs) == 0 {
+		return "validation failed"
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// parseValidationError parses the body of a 422 response. Discogs reports the rejected fields either as a list of
+// details locating the field, e.g. {"detail": [{"loc": ["body", "price"], "msg": "must be positive"}]}, or as a map,
+// e.g. {"errors": {"price": ["must be positive"]}}, alongside an optional message.
+func parseValidationError(body io.Reader) *ValidationError {
+	var v struct {
+		Message string                     `json:"message"`
+		Detail  json.RawMessage            `json:"detail"`
+		Errors  map[string]json.RawMessage `json:"errors"`
+	}
+	e := &ValidationError{Fields: make(map[string][]string)}
+	if err := json.NewDecoder(body).Decode(&v); err != nil {
+		return e
+	}
+	e.Message = v.Message
+
+	var details []struct {
+		Loc []interface{} `json:"loc"`
+		Msg string        `json:"msg"`
+	}
+	var detail string
+	switch {
+	case json.Unmarshal(v.Detail, &details) == nil:
+		for _, d := range details {
+			e.add(validationField(d.Loc), d.Msg)
+		}
+	case json.Unmarshal(v.Detail, &detail) == nil && e.Message == "":
+		e.Message = detail
+	}
+
+	for field, raw := range v.Errors {
+		var msgs []string
+		if err := json.Unmarshal(raw, &msgs); err != nil {
+			var msg string
+			if err := json.Unmarshal(raw, &msg); err != nil {
+				continue
+			}
+			msgs = []string{msg}
+		}
+		for _, msg := range msgs {
+			e.add(field, msg)
+		}
+	}
+	return e
+}
+
+func (e *ValidationError) add(field, msg string) {
+	e.Fields[field] = append(e.Fields[field], msg)
+}
+
+// validationField returns the name of the field located by loc, e.g. "release.id" for ["body", "release", "id"].
+func validationField(loc []interface{}) string {
+	var parts []string
+	for i, part := range loc {
+		s := fmt.Sprint(part)
+		if i == 0 && len(loc) > 1 && (s == "body" || s == "query" || s == "path") {
+			continue
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Validation errors
+var (
+	ErrCurrencyMismatch     = &Error{Message: "currency mismatch", kind: ErrValidation}
+	ErrCurrencyNotSupported = &Error{Message: "currency does not supported", kind: ErrValidation}
+	ErrInvalidBarcode       = &Error{Message: "invalid barcode", kind: ErrValidation}
+	ErrInvalidCatno         = &Error{Message: "invalid catalog number", kind: ErrValidation}
+	ErrInvalidCondition     = &Error{Message: "invalid condition", kind: ErrValidation}
+	ErrInvalidCredentials   = &Error{Message: "invalid credentials", kind: ErrValidation}
+	ErrInvalidCSV           = &Error{Message: "invalid csv", kind: ErrValidation}
+	ErrInvalidDuration      = &Error{Message: "invalid duration", kind: ErrValidation}
+	ErrInvalidExportID      = &Error{Message: "invalid export id", kind: ErrValidation}
+	ErrInvalidFolderID      = &Error{Message: "invalid folder id", kind: ErrValidation}
+	ErrInvalidFormat        = &Error{Message: "invalid format", kind: ErrValidation}
+	ErrInvalidGenre         = &Error{Message: "invalid genre", kind: ErrValidation}
+	ErrInvalidImageURL      = &Error{Message: "invalid image url", kind: ErrValidation}
+	ErrInvalidOptions       = &Error{Message: "invalid options", kind: ErrValidation}
+	ErrInvalidOrderID       = &Error{Message: "invalid order id", kind: ErrValidation}
+	ErrInvalidPagination    = &Error{Message: "invalid pagination", kind: ErrValidation}
+	ErrInvalidPosition      = &Error{Message: "invalid position", kind: ErrValidation}
+	ErrInvalidRating        = &Error{Message: "invalid rating", kind: ErrValidation}
+	ErrInvalidReleaseID     = &Error{Message: "invalid release id", kind: ErrValidation}
+	ErrInvalidReleaseStatus = &Error{Message: "invalid release status", kind: ErrValidation}
+	ErrInvalidSearchType    = &Error{Message: "invalid search type", kind: ErrValidation}
+	ErrInvalidSortKey       = &Error{Message: "invalid sort key", kind: ErrValidation}
+	ErrInvalidSortOrder     = &Error{Message: "invalid sort order", kind: ErrValidation}
+	ErrInvalidStyle         = &Error{Message: "invalid style", kind: ErrValidation}
+	ErrInvalidTrack         = &Error{Message: "invalid track", kind: ErrValidation}
+	ErrInvalidUploadID      = &Error{Message: "invalid upload id", kind: ErrValidation}
+	ErrInvalidUploadType    = &Error{Message: "invalid upload type", kind: ErrValidation}
+	ErrInvalidURL           = &Error{Message: "invalid url", kind: ErrValidation}
+	ErrInvalidUserAgent     = &Error{Message: "invalid user-agent", kind: ErrValidation}
+	ErrInvalidUsername      = &Error{Message: "invalid username", kind: ErrValidation}
+	ErrInvalidYear          = &Error{Message: "invalid year", kind: ErrValidation}
+
+	// Deprecated: use ErrInvalidUserAgent.
+	ErrUserAgentInvalid = ErrInvalidUserAgent
+)
+
+// Transport errors
+var (
+	ErrCircuitOpen        = &Error{Message: "circuit open", kind: ErrTransport}
+	ErrQueueFull          = &Error{Message: "queue full", kind: ErrTransport}
+	ErrRateLimitExceeded  = &Error{Message: "rate limit exceeded", kind: ErrTransport}
+	ErrResponseTooLarge   = &Error{Message: "response too large", kind: ErrTransport}
+	ErrUnexpectedResponse = &Error{Message: "unexpected response", kind: ErrTransport}
+	ErrUnknownField       = &Error{Message: "unknown field", kind: ErrTransport}
+)
+
+// API errors
 var (
-	ErrCurrencyNotSupported = &Error{"currency does not supported"}
-	ErrInvalidReleaseID     = &Error{"invalid release id"}
-	ErrInvalidSortKey       = &Error{"invalid sort key"}
-	ErrInvalidUsername      = &Error{"invalid username"}
-	ErrTooManyRequests      = &Error{"too many requests"}
-	ErrUnauthorized         = &Error{"authentication required"}
-	ErrUserAgentInvalid     = &Error{"invalid user-agent"}
+	ErrNotFound           = &Error{Message: "resource not found", kind: ErrAPI}
+	ErrReleaseNotAccepted = &Error{Message: "release not accepted", kind: ErrAPI}
+	ErrTooManyRequests    = &Error{Message: "too many requests", kind: ErrAPI}
+	ErrUnauthorized       = &Error{Message: "authentication required", kind: ErrAPI}
 )
+
+// Other errors
+var (
+	ErrDryRun            = &Error{Message: "dry run"}
+	ErrNoExchangeRate    = &Error{Message: "no exchange rate"}
+	ErrNoMatchingVersion = &Error{Message: "no matching version"}
+)
+
+// statusCode returns the status code of the unsuccessful response err was returned for, if any.
+func statusCode(err error) (int, bool) {
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode, true
+	case errors.As(err, new(*ValidationError)):
+		return http.StatusUnprocessableEntity, true
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized, true
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound, true
+	case errors.Is(err, ErrTooManyRequests):
+		return http.StatusTooManyRequests, true
+	}
+	return 0, false
+}
@@ -0,0 +1,130 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseValidationError(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		want *ValidationError
+	}{
+		"details": {
+			`{"detail": [{"loc": ["body", "price"], "msg": "must be positive"}, {"loc": ["body", "price"], "msg": "must be a number"}, {"loc": ["body", "release", "id"], "msg": "required"}]}`,
+			&ValidationError{Fields: map[string][]string{"price": {"must be positive", "must be a number"}, "release.id": {"required"}}},
+		},
+		"errors": {
+			`{"message": "Invalid listing.", "errors": {"condition": ["invalid value"], "status": "not allowed"}}`,
+			&ValidationError{Message: "Invalid listing.", Fields: map[string][]string{"condition": {"invalid value"}, "status": {"not allowed"}}},
+		},
+		"message only": {
+			`{"detail": "Invalid folder name."}`,
+			&ValidationError{Message: "Invalid folder name.", Fields: map[string][]string{}},
+		},
+		"invalid body": {
+			`<html>`,
+			&ValidationError{Fields: map[string][]string{}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, parseValidationError(strings.NewReader(tt.body))); diff != "" {
+				t.Errorf("(-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		if _, err := io.WriteString(w, `{"message": "Invalid request.", "errors": {"price": ["must be positive"], "condition": ["invalid value"]}}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	_, err := d.Listing(context.Background(), 172723812)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err got=%v; want a validation error", err)
+	}
+	if msgs := validationErr.Fields["price"]; len(msgs) != 1 || msgs[0] != "must be positive" {
+		t.Errorf("price got=%v; want=[must be positive]", msgs)
+	}
+	want := "validation failed: Invalid request.; condition: invalid value; price: must be positive"
+	if err.Error() != want {
+		t.Errorf("err got=%q; want=%q", err, want)
+	}
+	if code, ok := statusCode(err); !ok || code != http.StatusUnprocessableEntity {
+		t.Errorf("status code got=%d; want=%d", code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestErrorCategories(t *testing.T) {
+	categories := []error{ErrValidation, ErrTransport, ErrAPI}
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"validation":            {err: ErrInvalidUsername, want: ErrValidation},
+		"wrapped validation":    {err: fmt.Errorf("%w: missing release_id column", ErrInvalidCSV), want: ErrValidation},
+		"transport":             {err: ErrCircuitOpen, want: ErrTransport},
+		"transport error":       {err: &TransportError{Err: io.ErrUnexpectedEOF}, want: ErrTransport},
+		"api":                   {err: fmt.Errorf("%w: release not found", ErrNotFound), want: ErrAPI},
+		"status error":          {err: &StatusError{StatusCode: http.StatusBadGateway}, want: ErrAPI},
+		"uncategorized":         {err: ErrDryRun},
+		"foreign":               {err: io.EOF},
+		"deprecated user-agent": {err: ErrUserAgentInvalid, want: ErrValidation},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, category := range categories {
+				if got, want := errors.Is(tt.err, category), category == tt.want; got != want {
+					t.Errorf("errors.Is(%v, %v) got=%t; want=%t", tt.err, category, got, want)
+				}
+			}
+		})
+	}
+
+	validationErr := &ValidationError{}
+	if !errors.Is(validationErr, ErrValidation) || !errors.Is(validationErr, ErrAPI) || errors.Is(validationErr, ErrTransport) {
+		t.Errorf("expected a validation error to be a validation and an api error")
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ts.Close()
+
+	_, err := d.Release(context.Background(), 8138518)
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) || !errors.Is(err, ErrTransport) {
+		t.Fatalf("err got=%v; want a transport error", err)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("err got=%v; want a *url.Error", err)
+	}
+
+	// errors of middleware falling into a category are returned unchanged
+	d = initDiscogsClient(t, &Options{URL: ts.URL, Middleware: []Middleware{func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) { return nil, ErrQueueFull }
+	}}})
+	if _, err := d.Release(context.Background(), 8138518); err != ErrQueueFull {
+		t.Errorf("err got=%v; want=%s", err, ErrQueueFull)
+	}
+}
@@ -0,0 +1,244 @@
+package discogs
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Columns of the CSV files produced by the Discogs collection and wantlist exports.
+var (
+	collectionCSVHeader = []string{
+		"Catalog#", "Artist", "Title", "Label", "Format", "Rating", "Released", "release_id", "CollectionFolder",
+		"Date Added", "Collection Media Condition", "Collection Sleeve Condition", "Collection Notes",
+	}
+	wantlistCSVHeader = []string{
+		"Catalog#", "Artist", "Title", "Label", "Format", "Rating", "Released", "release_id", "Notes",
+	}
+)
+
+// ordersCSVHeader holds the columns of the sales ledger written by ExportOrdersCSV.
+var ordersCSVHeader = []string{
+	"order_id", "date", "buyer", "items", "subtotal", "shipping", "fee", "total", "currency", "status",
+}
+
+// Field IDs of the default notes fields of collection items.
+const (
+	mediaConditionField  = 1
+	sleeveConditionField = 2
+	notesField           = 3
+)
+
+// csvDateFormat is the format of the dates in the Discogs exports.
+const csvDateFormat = "2006-01-02 15:04:05"
+
+// ExportCollectionCSV writes the items in a folder of the user's collection to w as CSV, in the column layout of the
+// collection export offered by Discogs. All pages of the folder are requested; FolderAll holds all items.
+func ExportCollectionCSV(ctx context.Context, w io.Writer, s CollectionService, username string, folderID int, opts ...RequestOption) error {
+	folders, err := s.CollectionFolders(ctx, username, opts...)
+	if err != nil {
+		return err
+	}
+	folderNames := map[int]string{}
+	for _, f := range folders.Folders {
+		folderNames[f.ID] = f.Name
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(collectionCSVHeader); err != nil {
+		return err
+	}
+
+	it := CollectionItemsByFolderIter(ctx, s, username, folderID, nil, opts...)
+	for it.Next() {
+		item := it.Item()
+		folder := item.FolderID
+		if folder == 0 {
+			folder = folderID
+		}
+		var dateAdded string
+		if !item.DateAdded.IsZero() {
+			dateAdded = item.DateAdded.Format(csvDateFormat)
+		}
+
+		record := append(basicInformationCSV(item.BasicInformation, item.Rating),
+			folderNames[folder],
+			dateAdded,
+			collectionNote(item.Notes, mediaConditionField),
+			collectionNote(item.Notes, sleeveConditionField),
+			collectionNote(item.Notes, notesField),
+		)
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportWantlistCSV writes the releases in the user's wantlist to w as CSV, in the column layout of the wantlist
+// export offered by Discogs. All pages of the wantlist are requested.
+func ExportWantlistCSV(ctx context.Context, w io.Writer, s WantlistService, username string, opts ...RequestOption) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(wantlistCSVHeader); err != nil {
+		return err
+	}
+
+	it := WantlistIter(ctx, s, username, nil, opts...)
+	for it.Next() {
+		want := it.Item()
+		if err := cw.Write(append(basicInformationCSV(want.BasicInformation, want.Rating), want.Notes)); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// OrdersExportOptions selects the orders written by ExportOrdersCSV. Zero values select all orders.
+type OrdersExportOptions struct {
+	// Status selects the orders with the status, e.g. OrderShipped.
+	Status OrderStatus
+	// From and To select the orders created at or after From and before To.
+	From time.Time
+	To   time.Time
+}
+
+// ExportOrdersCSV writes the orders of the authenticated seller to w as a sales ledger CSV, oldest first, with a row
+// per order: its ID, creation date, buyer, the descriptions of the items sold, the subtotal of the items, shipping,
+// Discogs fee, total, currency and status. Amounts are written in the currency's major units, e.g. "18.07". The
+// orders are listed newest first and paging stops at the first order created before From.
+func ExportOrdersCSV(ctx context.Context, w io.Writer, s MarketPlaceService, o OrdersExportOptions, opts ...RequestOption) error {
+	if !o.From.IsZero() && !o.To.IsZero() && !o.From.Before(o.To) {
+		return fmt.Errorf("%w: empty date range", ErrInvalidOptions)
+	}
+
+	var orders []Order
+	it := OrdersIter(ctx, s, o.Status, &Pagination{Sort: SortCreated, SortOrder: SortDesc, PerPage: maxPerPage}, opts...)
+	for it.Next() {
+		order := it.Item()
+		if !o.From.IsZero() && order.Created.Before(o.From) {
+			break
+		}
+		if o.To.IsZero() || order.Created.Before(o.To) {
+			orders = append(orders, order)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ordersCSVHeader); err != nil {
+		return err
+	}
+	for i := len(orders) - 1; i >= 0; i-- {
+		if err := cw.Write(orderCSV(orders[i])); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// orderCSV returns the ledger row of the order.
+func orderCSV(o Order) []string {
+	currency := o.Total.Currency
+	subtotal := Price{Currency: currency}
+	items := make([]string, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, item.Release.Description)
+		// items are priced in the currency of the order
+		subtotal.Amount += item.Price.Amount
+	}
+	shipping := NewPrice(o.Shipping.Value, currency)
+
+	return []string{
+		o.ID,
+		o.Created.Format(csvDateFormat),
+		o.Buyer.Username,
+		strings.Join(items, "; "),
+		csvAmount(subtotal),
+		csvAmount(shipping),
+		csvAmount(o.Fee),
+		csvAmount(o.Total),
+		currency,
+		string(o.Status),
+	}
+}
+
+// csvAmount formats the amount of the price in the currency's major units, e.g. "18.07".
+func csvAmount(p Price) string {
+	return strconv.FormatFloat(p.Value(), 'f', decimals(p.Currency), 64)
+}
+
+// basicInformationCSV returns the columns shared by the collection and wantlist exports, from Catalog# to release_id.
+func basicInformationCSV(info BasicInformation, rating int) []string {
+	var catnos, labels []string
+	for _, l := range info.Labels {
+		catnos = append(catnos, l.Catno)
+		labels = append(labels, l.Name)
+	}
+
+	var ratingCol, released string
+	if rating > 0 {
+		ratingCol = strconv.Itoa(rating)
+	}
+	if info.Year > 0 {
+		released = strconv.Itoa(info.Year)
+	}
+
+	return []string{
+		strings.Join(catnos, ", "),
+		artistCredit(info.Artists),
+		info.Title,
+		strings.Join(labels, ", "),
+		formatDescription(info.Formats),
+		ratingCol,
+		released,
+		strconv.Itoa(info.ID),
+	}
+}
+
+// artistCredit joins the artists' names as listed in the Discogs export, e.g. "Artist A & Artist B".
+func artistCredit(artists []ArtistSource) string {
+	return joinArtists(artists, func(a ArtistSource) string { return a.Name })
+}
+
+// formatDescription describes the formats as the Discogs export does, e.g. "2xLP, Album" or "LP + CD".
+func formatDescription(formats []Format) string {
+	var parts []string
+	for _, f := range formats {
+		desc := append([]string{}, f.Descriptions...)
+		if len(desc) == 0 {
+			desc = []string{string(f.Name)}
+		}
+		if f.Qty != "" && f.Qty != "1" {
+			desc[0] = f.Qty + "x" + desc[0]
+		}
+		parts = append(parts, strings.Join(desc, ", "))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// collectionNote returns the value of the notes field of a collection item.
+func collectionNote(notes []Notes, fieldID int) string {
+	for _, n := range notes {
+		if n.FieldID == fieldID {
+			return n.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package discogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExportCollectionCSV(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/users/" + testUsername + "/collection/folders":
+			body = collectionJson
+		case "/users/" + testUsername + "/collection/folders/0/releases":
+			body = collectionItemsByRelease
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := io.WriteString(w, body); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	var buf bytes.Buffer
+	if err := ExportCollectionCSV(context.Background(), &buf, d, testUsername, 0); err != nil {
+		t.Fatalf("failed to export collection: %s", err)
+	}
+
+	want := "Catalog#,Artist,Title,Label,Format,Rating,Released,release_id,CollectionFolder,Date Added,Collection Media Condition,Collection Sleeve Condition,Collection Notes\n" +
+		"PR014,Zoo Lake,Zonk,Permanent Record,\"LP, Album\",,2018,12934893,All,2020-01-19 14:19:11,,,\n"
+	if buf.String() != want {
+		t.Errorf("csv got=%q; want=%q", buf.String(), want)
+	}
+}
+
+func TestExportWantlistCSV(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(WantlistServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	var buf bytes.Buffer
+	if err := ExportWantlistCSV(context.Background(), &buf, d, testUsername); err != nil {
+		t.Fatalf("failed to export wantlist: %s", err)
+	}
+
+	want := "Catalog#,Artist,Title,Label,Format,Rating,Released,release_id,Notes\n" +
+		"B0008764-01,Nine Inch Nails,Year Zero,Interscope Records,\"2xLP, Album\",4,2007,1867708,Original pressing only\n" +
+		"541125-1,LCD Soundsystem,The Long Goodbye,DFA,5xLP,,2014,5610049,\n"
+	if buf.String() != want {
+		t.Errorf("csv got=%q; want=%q", buf.String(), want)
+	}
+
+	if err := ExportWantlistCSV(context.Background(), &buf, d, ""); err != ErrInvalidUsername {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUsername)
+	}
+}
+
+func TestExportOrdersCSV(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(MarketplaceServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+	created := time.Date(2011, 10, 21, 16, 25, 17, 0, time.UTC)
+
+	header := "order_id,date,buyer,items,subtotal,shipping,fee,total,currency,status\n"
+	tests := map[string]struct {
+		from, to time.Time
+		want     string
+	}{
+		"all": {want: header + "1-1,2011-10-21 09:25:17,example_buyer,\"Persuader, The - Stockholm (2x12\"\")\",42.00,0.00,2.52,42.00,USD,New Order\n"},
+		"in range": {
+			from: created, to: created.Add(time.Second),
+			want: header + "1-1,2011-10-21 09:25:17,example_buyer,\"Persuader, The - Stockholm (2x12\"\")\",42.00,0.00,2.52,42.00,USD,New Order\n",
+		},
+		"before range": {from: created.Add(time.Second), want: header},
+		"after range":  {to: created, want: header},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			o := OrdersExportOptions{Status: OrderNewOrder, From: tt.from, To: tt.to}
+			if err := ExportOrdersCSV(ctx, &buf, d, o); err != nil {
+				t.Fatalf("failed to export orders: %s", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("csv got=%q; want=%q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	o := OrdersExportOptions{From: created, To: created}
+	if err := ExportOrdersCSV(ctx, io.Discard, d, o); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidOptions)
+	}
+}
+
+func TestArtistCreditAndFormatDescription(t *testing.T) {
+	artists := []ArtistSource{{Name: "A", Join: "&"}, {Name: "B", Join: ","}, {Name: "C"}}
+	if got := artistCredit(artists); got != "A & B, C" {
+		t.Errorf("artist credit got=%q; want=%q", got, "A & B, C")
+	}
+
+	formats := []Format{{Name: "Vinyl", Qty: "1", Descriptions: []string{"LP"}}, {Name: "CD", Qty: "2"}}
+	if got := formatDescription(formats); got != "LP + 2xCD" {
+		t.Errorf("format got=%q; want=%q", got, "LP + 2xCD")
+	}
+}
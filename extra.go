@@ -0,0 +1,244 @@
+package discogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The major resources keep the fields of API responses they don't map in their Extra field, so fields Discogs adds
+// can be read before the library maps them. Extra is encoded along with the mapped fields, so it survives caching.
+
+// UnmarshalJSON decodes a release, keeping the fields it doesn't map in Extra.
+func (r *Release) UnmarshalJSON(b []byte) error {
+	type release Release
+	var v release
+	extra, err := decodeExtra(b, &v)
+	*r = Release(v)
+	r.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a release along with the fields in Extra.
+func (r Release) MarshalJSON() ([]byte, error) {
+	type release Release
+	return encodeExtra(release(r), r.Extra)
+}
+
+// UnmarshalJSON decodes an artist, keeping the fields it doesn't map in Extra.
+func (a *Artist) UnmarshalJSON(b []byte) error {
+	type artist Artist
+	var v artist
+	extra, err := decodeExtra(b, &v)
+	*a = Artist(v)
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes an artist along with the fields in Extra.
+func (a Artist) MarshalJSON() ([]byte, error) {
+	type artist Artist
+	return encodeExtra(artist(a), a.Extra)
+}
+
+// UnmarshalJSON decodes a label, keeping the fields it doesn't map in Extra.
+func (l *Label) UnmarshalJSON(b []byte) error {
+	type label Label
+	var v label
+	extra, err := decodeExtra(b, &v)
+	*l = Label(v)
+	l.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a label along with the fields in Extra.
+func (l Label) MarshalJSON() ([]byte, error) {
+	type label Label
+	return encodeExtra(label(l), l.Extra)
+}
+
+// UnmarshalJSON decodes a master release, keeping the fields it doesn't map in Extra.
+func (m *Master) UnmarshalJSON(b []byte) error {
+	type master Master
+	var v master
+	extra, err := decodeExtra(b, &v)
+	*m = Master(v)
+	m.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a master release along with the fields in Extra.
+func (m Master) MarshalJSON() ([]byte, error) {
+	type master Master
+	return encodeExtra(master(m), m.Extra)
+}
+
+// UnmarshalJSON decodes a collection item, keeping the fields it doesn't map in Extra.
+func (c *CollectionItemSource) UnmarshalJSON(b []byte) error {
+	type item CollectionItemSource
+	var v item
+	extra, err := decodeExtra(b, &v)
+	*c = CollectionItemSource(v)
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a collection item along with the fields in Extra.
+func (c CollectionItemSource) MarshalJSON() ([]byte, error) {
+	type item CollectionItemSource
+	return encodeExtra(item(c), c.Extra)
+}
+
+// decodeExtra decodes b into v, a pointer to a struct, and returns the fields of b that v doesn't map.
+func decodeExtra(b []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil || fields == nil {
+		return nil, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	for name := range fields {
+		if _, ok := known[strings.ToLower(name)]; ok {
+			delete(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// encodeExtra encodes v, a struct, along with the extra fields.
+func encodeExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+var jsonFieldsCache sync.Map
+
+// jsonFields returns the fields of the struct type t by their lower-cased JSON names, including those of embedded
+// structs.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.(map[string]reflect.StructField)
+	}
+
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for n, sf := range jsonFields(ft) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = sf
+					}
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f
+	}
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the paths of the fields of the JSON document b that aren't mapped by the type of v, e.g.
+// "tracklist[0].new_field". Values of types decoding themselves are only checked if they keep an Extra map.
+func unknownFields(b []byte, v interface{}) ([]string, error) {
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	var paths []string
+	collectUnknownFields(doc, reflect.TypeOf(v), "", &paths)
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func collectUnknownFields(doc interface{}, t reflect.Type, path string, paths *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		if f, ok := t.FieldByName("Extra"); !ok || f.Tag.Get("json") != "-" {
+			return
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for name, value := range obj {
+			p := name
+			if path != "" {
+				p = path + "." + name
+			}
+			f, ok := fields[strings.ToLower(name)]
+			if !ok {
+				*paths = append(*paths, p)
+				continue
+			}
+			collectUnknownFields(value, f.Type, p, paths)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			return
+		}
+		for i, value := range arr {
+			collectUnknownFields(value, t.Elem(), fmt.Sprintf("%s[%d]", path, i), paths)
+		}
+	case reflect.Map:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for name, value := range obj {
+			p := name
+			if path != "" {
+				p = path + "." + name
+			}
+			collectUnknownFields(value, t.Elem(), p, paths)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package discogs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExtraFields(t *testing.T) {
+	data := `{"id": 8138518, "title": "Elephant Riddim", "new_field": {"a": 1}, "new_list": [1, 2]}`
+
+	var release Release
+	if err := json.Unmarshal([]byte(data), &release); err != nil {
+		t.Fatalf("failed to decode release: %s", err)
+	}
+	if release.ID != 8138518 || release.Title != "Elephant Riddim" {
+		t.Errorf("release got=%d %q; want=8138518 %q", release.ID, release.Title, "Elephant Riddim")
+	}
+	want := map[string]json.RawMessage{"new_field": json.RawMessage(`{"a": 1}`), "new_list": json.RawMessage(`[1, 2]`)}
+	if !reflect.DeepEqual(release.Extra, want) {
+		t.Errorf("extra got=%s; want=%s", release.Extra, want)
+	}
+
+	b, err := json.Marshal(release)
+	if err != nil {
+		t.Fatalf("failed to encode release: %s", err)
+	}
+	var decoded Release
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("failed to decode release: %s", err)
+	}
+	if len(decoded.Extra) != 2 || string(decoded.Extra["new_list"]) != "[1,2]" {
+		t.Errorf("extra got=%s; want new_field and new_list", decoded.Extra)
+	}
+
+	var artist Artist
+	if err := json.Unmarshal([]byte(artistJson), &artist); err != nil {
+		t.Fatalf("failed to decode artist: %s", err)
+	}
+	if artist.Extra != nil {
+		t.Errorf("extra got=%s; want=nil", artist.Extra)
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	data := `{"id": 1, "NEW": true, "tracklist": [{"title": "A", "new_track_field": 1}], "community": {"rating": {"average": 1, "count": 2, "median": 1}}, "date_added": "2016-02-19T01:49:21-08:00"}`
+
+	var release *Release
+	paths, err := unknownFields([]byte(data), &release)
+	if err != nil {
+		t.Fatalf("failed to check fields: %s", err)
+	}
+	want := []string{"NEW", "community.rating.median", "tracklist[0].new_track_field"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths got=%v; want=%v", paths, want)
+	}
+
+	paths, err = unknownFields([]byte(releaseJson), &release)
+	if err != nil || len(paths) != 0 {
+		t.Errorf("paths got=%v, %v; want none", paths, err)
+	}
+}
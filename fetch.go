@@ -0,0 +1,80 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FetchService is an interface to dereference resource URLs and call endpoints the other services don't cover.
+type FetchService interface {
+	// Fetch requests resourceURL, such as Release.MasterURL or ArtistSource.ResourceURL, using the client's
+	// user-agent and authentication and decodes the JSON response into v. The URL must point to the API the client
+	// was created for.
+	Fetch(ctx context.Context, resourceURL string, v interface{}, opts ...RequestOption) error
+	// Do calls an endpoint of the API the client was created for with the client's user-agent, authentication and
+	// error handling, e.g. one the other services don't cover yet. path is relative to the client's endpoint, e.g.
+	// "/users/{username}/collection/value", or a URL of the API. The JSON encoding of body, if not nil, is sent as the
+	// request body and the JSON response is decoded into out, if not nil and the response has content.
+	Do(ctx context.Context, method, path string, params url.Values, body, out interface{}, opts ...RequestOption) error
+}
+
+type callFunc func(ctx context.Context, method, path string, params url.Values, body, resp interface{}, opts ...RequestOption) error
+
+type fetchService struct {
+	request requestFunc
+	call    callFunc
+	apiURL  string
+	url     *url.URL
+}
+
+func newFetchService(req requestFunc, call callFunc, apiURL string) FetchService {
+	u, _ := url.Parse(apiURL)
+	return &fetchService{
+		request: req,
+		call:    call,
+		apiURL:  apiURL,
+		url:     u,
+	}
+}
+
+func (s *fetchService) Fetch(ctx context.Context, resourceURL string, v interface{}, opts ...RequestOption) error {
+	u, err := url.Parse(resourceURL)
+	if err != nil || s.url == nil || !strings.EqualFold(u.Scheme, s.url.Scheme) || !strings.EqualFold(u.Host, s.url.Host) {
+		return ErrInvalidURL
+	}
+	params := u.Query()
+	u.RawQuery = ""
+	return s.request(ctx, u.String(), params, v, opts...)
+}
+
+func (s *fetchService) Do(ctx context.Context, method, path string, params url.Values, body, out interface{}, opts ...RequestOption) error {
+	if method == "" {
+		return fmt.Errorf("%w: no method", ErrInvalidOptions)
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return ErrInvalidURL
+	}
+	if u.IsAbs() {
+		if s.url == nil || !strings.EqualFold(u.Scheme, s.url.Scheme) || !strings.EqualFold(u.Host, s.url.Host) {
+			return ErrInvalidURL
+		}
+	} else {
+		query := u.RawQuery
+		u, err = url.Parse(joinURL(s.apiURL, u.EscapedPath()))
+		if err != nil {
+			return ErrInvalidURL
+		}
+		u.RawQuery = query
+	}
+
+	// parameters in the path are sent along with params
+	query := u.Query()
+	for key, values := range params {
+		query[key] = append(query[key], values...)
+	}
+	u.RawQuery = ""
+	return s.call(ctx, strings.ToUpper(method), u.String(), query, body, out, opts...)
+}
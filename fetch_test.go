@@ -0,0 +1,117 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFetch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	var release Release
+	if err := d.Fetch(ctx, ts.URL+"/releases/8138518", &release); err != nil {
+		t.Fatalf("failed to fetch release: %s", err)
+	}
+	if release.ID != 8138518 {
+		t.Errorf("release got=%d; want=8138518", release.ID)
+	}
+
+	var master Master
+	if err := d.Fetch(ctx, ts.URL+"/masters/718441?curr_abbr=USD", &master); err != nil {
+		t.Fatalf("failed to fetch master: %s", err)
+	}
+	if master.ID != 718441 {
+		t.Errorf("master got=%d; want=718441", master.ID)
+	}
+
+	for _, u := range []string{"https://api.discogs.com/releases/8138518", "://invalid", ""} {
+		if err := d.Fetch(ctx, u, &release); err != ErrInvalidURL {
+			t.Errorf("%s: err got=%v; want=%s", u, err, ErrInvalidURL)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/users/test_user/collection/value":
+			if q := r.URL.Query(); q.Get("a") != "1" || q.Get("b") != "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			io.WriteString(w, `{"maximum": "$120.00", "median": "$60.00", "minimum": "$30.00"}`)
+		case r.Method == "POST" && r.URL.Path == "/users/test_user/collection/folders":
+			var folder struct{ Name string }
+			if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&folder) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id": 3, "name": "`+folder.Name+`", "count": 0}`)
+		case r.Method == "DELETE" && r.URL.Path == "/users/test_user/collection/folders/3":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	var value struct{ Maximum, Median, Minimum string }
+	if err := d.Do(ctx, "GET", "/users/test_user/collection/value?a=1", url.Values{"b": {"2"}}, nil, &value); err != nil {
+		t.Fatalf("failed to get collection value: %s", err)
+	}
+	if value.Median != "$60.00" {
+		t.Errorf("median got=%s; want=$60.00", value.Median)
+	}
+
+	var folder Folder
+	if err := d.Do(ctx, "post", ts.URL+"/users/test_user/collection/folders", nil, map[string]string{"name": "Singles"}, &folder); err != nil {
+		t.Fatalf("failed to create folder: %s", err)
+	}
+	if folder.ID != 3 || folder.Name != "Singles" {
+		t.Errorf("folder got=%+v", folder)
+	}
+
+	if err := d.Do(ctx, "DELETE", "users/test_user/collection/folders/3", nil, nil, &folder); err != nil {
+		t.Errorf("failed to delete folder: %s", err)
+	}
+
+	var status *StatusError
+	if err := d.Do(ctx, "GET", "/unknown", nil, nil, nil); !errors.As(err, &status) || status.StatusCode != http.StatusInternalServerError {
+		t.Errorf("err got=%v; want=status 500", err)
+	}
+	if err := d.Do(ctx, "GET", "https://example.com/users/test_user", nil, nil, nil); err != ErrInvalidURL {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidURL)
+	}
+	if err := d.Do(ctx, "", "/users/test_user", nil, nil, nil); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidOptions)
+	}
+
+	// only requests with idempotent methods are retried
+	requests = nil
+	r := Retry(d, RetryPolicy{MaxAttempts: 2, InitialDelay: time.Millisecond})
+	r.Do(ctx, "GET", "/unknown", nil, nil, nil)
+	r.Do(ctx, "POST", "/unknown", nil, nil, nil)
+	want := []string{"GET /unknown", "GET /unknown", "POST /unknown"}
+	if !cmp.Equal(requests, want) {
+		t.Errorf("requests got=%v; want=%v", requests, want)
+	}
+}
@@ -0,0 +1,208 @@
+//go:build ignore
+// +build ignore
+
+// gen_intercepted generates intercepted.go, the methods of interceptedDiscogs passing the calls of every service of
+// the Discogs interface through its interceptor. All decorators, such as RateLimited, Retry, Cached, Logged and
+// Traced, are interceptors, so a method added to a service is decorated by all of them once this is run with
+// go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// reserved are the names the generated methods use besides the parameters, by whether the method returns a value.
+var reserved = map[bool]map[string]bool{
+	false: {"r": true},
+	true:  {"r": true, "v": true, "e": true, "err": true},
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != "intercepted.go"
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["discogs"]
+	if !ok {
+		log.Fatal("package discogs not found")
+	}
+
+	interfaces := map[string]*ast.InterfaceType{}
+	importPaths := map[string]string{}
+	for _, f := range pkg.Files {
+		for _, spec := range f.Imports {
+			path := strings.Trim(spec.Path.Value, `"`)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			importPaths[name] = path
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				if it, ok := ts.Type.(*ast.InterfaceType); ok {
+					interfaces[ts.Name.Name] = it
+				}
+			}
+			return true
+		})
+	}
+	discogs, ok := interfaces["Discogs"]
+	if !ok {
+		log.Fatal("interface Discogs not found")
+	}
+
+	var services []string
+	for _, field := range discogs.Methods.List {
+		if ident, ok := field.Type.(*ast.Ident); ok && len(field.Names) == 0 {
+			services = append(services, ident.Name)
+		}
+	}
+	sort.Strings(services)
+
+	var methods bytes.Buffer
+	imports := map[string]bool{}
+	for _, s := range services {
+		it, ok := interfaces[s]
+		if !ok {
+			log.Fatalf("interface %s not found", s)
+		}
+		for _, m := range it.Methods.List {
+			ast.Inspect(m.Type, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if pkg, ok := sel.X.(*ast.Ident); ok {
+						path, ok := importPaths[pkg.Name]
+						if !ok {
+							log.Fatalf("%s.%s: unknown package %s", s, m.Names[0].Name, pkg.Name)
+						}
+						imports[path] = true
+					}
+				}
+				return true
+			})
+			writeMethod(&methods, fset, s, m.Names[0].Name, m.Type.(*ast.FuncType))
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_intercepted.go; DO NOT EDIT.\n\npackage discogs\n\nimport (\n")
+	var paths []string
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&b, "%q\n", path)
+	}
+	b.WriteString(")\n\n// Services of the Discogs interface.\nconst (\n")
+	for _, s := range services {
+		fmt.Fprintf(&b, "%s Service = %q\n", constant(s), s)
+	}
+	b.WriteString(")\n")
+	b.Write(methods.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format the generated code: %s\n%s", err, b.Bytes())
+	}
+	if err := ioutil.WriteFile("intercepted.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// constant returns the name of the constant of a service, e.g. ServiceDatabase for DatabaseService.
+func constant(service string) string {
+	return "Service" + strings.TrimSuffix(service, "Service")
+}
+
+// writeMethod writes the method of interceptedDiscogs passing the calls of the service method through the
+// interceptor.
+func writeMethod(b *bytes.Buffer, fset *token.FileSet, service, name string, ft *ast.FuncType) {
+	var results []string
+	for _, r := range ft.Results.List {
+		results = append(results, expr(fset, r.Type))
+	}
+	if len(results) == 0 || results[len(results)-1] != "error" || len(results) > 2 {
+		log.Fatalf("%s.%s: methods must return an error, optionally preceded by a value", service, name)
+	}
+
+	// the context, the request options and any other arguments, which the call records
+	var params, args, callArgs []string
+	ctx, opts, httpMethod := "", "nil", ""
+	for _, p := range ft.Params.List {
+		typ := expr(fset, p.Type)
+		var names []string
+		for _, n := range p.Names {
+			if reserved[len(results) == 2][n.Name] {
+				log.Fatalf("%s.%s: parameter %s conflicts with the generated code", service, name, n.Name)
+			}
+			names = append(names, n.Name)
+			arg := n.Name
+			switch {
+			case typ == "context.Context":
+				ctx = n.Name
+			case typ == "...RequestOption":
+				opts = n.Name
+				arg += "..."
+			default:
+				if _, ok := p.Type.(*ast.Ellipsis); ok {
+					arg += "..."
+				}
+				callArgs = append(callArgs, n.Name)
+			}
+			args = append(args, arg)
+			// a string parameter named method is the HTTP method of the call
+			if n.Name == "method" && typ == "string" {
+				httpMethod = n.Name
+			}
+		}
+		params = append(params, strings.Join(names, ", ")+" "+typ)
+	}
+	if ctx == "" {
+		log.Fatalf("%s.%s: methods must take a context", service, name)
+	}
+
+	result := "nil"
+	if len(results) == 2 {
+		result = "&v"
+	}
+	call := fmt.Sprintf("newCall(%s, %q, %s, %s", constant(service), name, result, opts)
+	for _, arg := range callArgs {
+		call += ", " + arg
+	}
+	call += ")"
+	if httpMethod != "" {
+		call += ".withHTTPMethod(" + httpMethod + ")"
+	}
+	invoke := fmt.Sprintf("r.d.%s(%s)", name, strings.Join(args, ", "))
+
+	if len(results) == 1 {
+		fmt.Fprintf(b, "\nfunc (r *interceptedDiscogs) %s(%s) error {\n", name, strings.Join(params, ", "))
+		fmt.Fprintf(b, "return r.i(%s, %s, func(%s context.Context) error {\nreturn %s\n})\n}\n", ctx, call, ctx, invoke)
+		return
+	}
+	fmt.Fprintf(b, "\nfunc (r *interceptedDiscogs) %s(%s) (v %s, e error) {\n", name, strings.Join(params, ", "), results[0])
+	fmt.Fprintf(b, "e = r.i(%s, %s, func(%s context.Context) error {\nvar err error\nv, err = %s\nreturn err\n})\nreturn\n}\n", ctx, call, ctx, invoke)
+}
+
+func expr(fset *token.FileSet, e ast.Expr) string {
+	var b bytes.Buffer
+	if err := printer.Fprint(&b, fset, e); err != nil {
+		log.Fatal(err)
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package discogs
+
+import (
+	"strings"
+)
+
+// Genre is one of the genres Discogs files releases under. Unlike styles, the genres are a fixed list.
+// https://www.discogs.com/help/doc/submission-guidelines-release-genres-styles
+type Genre string
+
+// Genres of releases.
+const (
+	GenreBlues            Genre = "Blues"
+	GenreBrassMilitary    Genre = "Brass & Military"
+	GenreChildrens        Genre = "Children's"
+	GenreClassical        Genre = "Classical"
+	GenreElectronic       Genre = "Electronic"
+	GenreFolkWorldCountry Genre = "Folk, World, & Country"
+	GenreFunkSoul         Genre = "Funk / Soul"
+	GenreHipHop           Genre = "Hip Hop"
+	GenreJazz             Genre = "Jazz"
+	GenreLatin            Genre = "Latin"
+	GenreNonMusic         Genre = "Non-Music"
+	GenrePop              Genre = "Pop"
+	GenreReggae           Genre = "Reggae"
+	GenreRock             Genre = "Rock"
+	GenreStageScreen      Genre = "Stage & Screen"
+)
+
+var genres = []Genre{
+	GenreBlues, GenreBrassMilitary, GenreChildrens, GenreClassical, GenreElectronic, GenreFolkWorldCountry,
+	GenreFunkSoul, GenreHipHop, GenreJazz, GenreLatin, GenreNonMusic, GenrePop, GenreReggae, GenreRock,
+	GenreStageScreen,
+}
+
+// Genres returns all genres in alphabetical order.
+func Genres() []Genre {
+	return append([]Genre(nil), genres...)
+}
+
+// ParseGenre returns the genre with the given name, ignoring case.
+func ParseGenre(s string) (Genre, error) {
+	s = strings.TrimSpace(s)
+	for _, g := range genres {
+		if strings.EqualFold(s, string(g)) {
+			return g, nil
+		}
+	}
+	return "", ErrInvalidGenre
+}
+
+// Styles returns the known styles of the genre in alphabetical order.
+func (g Genre) Styles() []Style {
+	return append([]Style(nil), genreStyles[g]...)
+}
+
+func (g Genre) String() string {
+	return string(g)
+}
+
+// Style is a style of releases within a genre, e.g. "Deep House" in Electronic. Discogs adds styles over time, so the
+// known styles are a snapshot and styles missing from it may still be valid.
+type Style string
+
+// genreStyles holds the known styles by genre. Some styles belong to several genres, e.g. "Electro".
+var genreStyles = map[Genre][]Style{
+	GenreBlues: {
+		"Boogie Woogie", "Chicago Blues", "Country Blues", "Delta Blues", "East Coast Blues",
+		"Electric Blues", "Harmonica Blues", "Hill Country Blues", "Jump Blues", "Louisiana Blues",
+		"Memphis Blues", "Modern Electric Blues", "Piano Blues", "Piedmont Blues", "Rhythm & Blues",
+		"Texas Blues",
+	},
+	GenreBrassMilitary: {
+		"Brass Band", "Marches", "Military", "Pipe & Drum",
+	},
+	GenreChildrens: {
+		"Educational", "Nursery Rhymes", "Story",
+	},
+	GenreClassical: {
+		"Baroque", "Choral", "Classical", "Contemporary", "Early", "Impressionist", "Medieval", "Modern",
+		"Neo-Classical", "Neo-Romantic", "Opera", "Operetta", "Oratorio", "Post-Modern", "Renaissance",
+		"Romantic",
+	},
+	GenreElectronic: {
+		"Abstract", "Acid", "Acid House", "Acid Jazz", "Ambient", "Bassline", "Beatdown", "Berlin-School",
+		"Big Beat", "Bleep", "Breakbeat", "Breakcore", "Breaks", "Broken Beat", "Chillwave", "Chiptune",
+		"Dance-pop", "Dark Ambient", "Darkwave", "Deep House", "Deep Techno", "Disco", "Disco Polo", "Donk",
+		"Downtempo", "Drone", "Drum n Bass", "Dub", "Dub Techno", "Dubstep", "Dungeon Synth", "EBM",
+		"Electro", "Electro House", "Electroclash", "Euro House", "Euro-Disco", "Eurobeat", "Eurodance",
+		"Experimental", "Footwork", "Freestyle", "Future Jazz", "Gabber", "Garage House", "Ghetto",
+		"Ghetto House", "Glitch", "Goa Trance", "Grime", "Hands Up", "Happy Hardcore", "Hard Beat",
+		"Hard House", "Hard Techno", "Hard Trance", "Hardcore", "Hardstyle", "Hi NRG", "Hip Hop", "Hip-House",
+		"House", "IDM", "Illbient", "Industrial", "Italo House", "Italo-Disco", "Italodance", "Jazzdance",
+		"Juke", "Jumpstyle", "Jungle", "Latin", "Leftfield", "Makina", "Minimal", "Minimal Techno",
+		"Modern Classical", "Musique Concrète", "Neofolk", "New Age", "New Beat", "New Wave", "Noise",
+		"Nu-Disco", "Power Electronics", "Progressive Breaks", "Progressive House", "Progressive Trance",
+		"Psy-Trance", "Rhythmic Noise", "Schranz", "Sound Collage", "Speed Garage", "Speedcore", "Synth-pop",
+		"Synthwave", "Tech House", "Tech Trance", "Techno", "Trance", "Tribal", "Tribal House", "Trip Hop",
+		"Tropical House", "UK Garage", "Vaporwave",
+	},
+	GenreFolkWorldCountry: {
+		"Aboriginal", "African", "Andalusian Classical", "Appalachian Music", "Bhangra", "Bluegrass", "Cajun",
+		"Celtic", "Country", "Fado", "Flamenco", "Folk", "Gospel", "Highlife", "Hillbilly", "Hindustani",
+		"Honky Tonk", "Indian Classical", "Juju", "Klezmer", "Mbalax", "Nordic", "Pacific", "Polka",
+		"Rebetiko", "Schlager", "Soukous", "Volksmusik", "Zouk", "Zydeco",
+	},
+	GenreFunkSoul: {
+		"Afrobeat", "Boogie", "Contemporary R&B", "Disco", "Funk", "Gospel", "Minneapolis Sound", "Neo Soul",
+		"New Jack Swing", "P.Funk", "Psychedelic", "Rhythm & Blues", "Soul", "Swingbeat", "UK Street Soul",
+	},
+	GenreHipHop: {
+		"Bass Music", "Boom Bap", "Bounce", "Britcore", "Cloud Rap", "Conscious", "Crunk", "Cut-up/DJ",
+		"DJ Battle Tool", "Drill", "Electro", "G-Funk", "Gangsta", "Grime", "Hardcore Hip-Hop", "Horrorcore",
+		"Instrumental", "Jazzy Hip-Hop", "Miami Bass", "Pop Rap", "Ragga HipHop", "RnB/Swing", "Screw",
+		"Thug Rap", "Trap", "Trip Hop", "Turntablism",
+	},
+	GenreJazz: {
+		"Afro-Cuban Jazz", "Afrobeat", "Avant-garde Jazz", "Big Band", "Bop", "Bossa Nova",
+		"Contemporary Jazz", "Cool Jazz", "Dixieland", "Easy Listening", "Free Funk", "Free Improvisation",
+		"Free Jazz", "Fusion", "Gypsy Jazz", "Hard Bop", "Jazz-Funk", "Jazz-Rock", "Latin Jazz", "Modal",
+		"Post Bop", "Ragtime", "Smooth Jazz", "Soul-Jazz", "Space-Age", "Swing",
+	},
+	GenreLatin: {
+		"Bachata", "Baião", "Batucada", "Beguine", "Bolero", "Boogaloo", "Bossanova", "Cha-Cha", "Charanga",
+		"Compas", "Cubano", "Cumbia", "Danzon", "Descarga", "Forró", "Guaracha", "Latin Jazz", "Mambo",
+		"Mariachi", "Merengue", "MPB", "Norteño", "Nueva Cancion", "Pachanga", "Porro", "Ranchera",
+		"Reggaeton", "Rumba", "Salsa", "Samba", "Son", "Son Montuno", "Tango", "Tejano", "Vallenato",
+	},
+	GenreNonMusic: {
+		"Audiobook", "Comedy", "Dialogue", "Education", "Field Recording", "Interview", "Monolog", "Poetry",
+		"Political", "Promotional", "Radioplay", "Religious", "Spoken Word",
+	},
+	GenrePop: {
+		"Ballad", "Bollywood", "Bubblegum", "Chanson", "City Pop", "Europop", "Indie Pop", "J-pop", "K-pop",
+		"Kayōkyoku", "Light Music", "Music Hall", "Novelty", "Parody", "Schlager", "Vocal",
+	},
+	GenreReggae: {
+		"Calypso", "Dancehall", "Dub", "Dub Poetry", "Lovers Rock", "Mento", "Ragga", "Reggae", "Reggae-Pop",
+		"Rocksteady", "Roots Reggae", "Ska", "Soca",
+	},
+	GenreRock: {
+		"Acid Rock", "Alternative Rock", "AOR", "Art Rock", "Black Metal", "Blues Rock", "Britpop",
+		"Classic Rock", "Coldwave", "Country Rock", "Crust", "Death Metal", "Deathcore", "Doom Metal",
+		"Dream Pop", "Emo", "Ethereal", "Experimental", "Folk Metal", "Folk Rock", "Garage Rock", "Glam",
+		"Goth Rock", "Gothic Metal", "Grindcore", "Grunge", "Hard Rock", "Hardcore", "Heavy Metal",
+		"Indie Rock", "Industrial", "Krautrock", "Lo-Fi", "Math Rock", "Metalcore", "Mod", "New Wave",
+		"Noise", "Nu Metal", "Oi", "Pop Punk", "Pop Rock", "Post Rock", "Post-Hardcore", "Post-Punk",
+		"Power Metal", "Power Pop", "Prog Rock", "Psychedelic Rock", "Psychobilly", "Pub Rock", "Punk",
+		"Rock & Roll", "Rockabilly", "Shoegaze", "Ska", "Sludge Metal", "Soft Rock", "Southern Rock",
+		"Space Rock", "Speed Metal", "Stoner Rock", "Surf", "Symphonic Rock", "Thrash", "Twist",
+		"Viking Metal",
+	},
+	GenreStageScreen: {
+		"Musical", "Score", "Soundtrack", "Theme",
+	},
+}
+
+// styles maps the lower case names of the known styles to the styles.
+var styles = func() map[string]Style {
+	m := make(map[string]Style)
+	for _, ss := range genreStyles {
+		for _, s := range ss {
+			m[strings.ToLower(string(s))] = s
+		}
+	}
+	return m
+}()
+
+// ParseStyle returns the known style with the given name, ignoring case, or ErrInvalidStyle if it isn't known.
+func ParseStyle(s string) (Style, error) {
+	if st, ok := styles[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return st, nil
+	}
+	return "", ErrInvalidStyle
+}
+
+// Genres returns the genres the style is known in, in alphabetical order.
+func (s Style) Genres() []Genre {
+	var gs []Genre
+	for _, g := range genres {
+		for _, st := range genreStyles[g] {
+			if st == s {
+				gs = append(gs, g)
+				break
+			}
+		}
+	}
+	return gs
+}
+
+func (s Style) String() string {
+	return string(s)
+}
@@ -0,0 +1,52 @@
+package discogs
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGenre(t *testing.T) {
+	if g, err := ParseGenre("funk / soul"); err != nil || g != GenreFunkSoul {
+		t.Errorf("genre got=%s, %v; want=%s", g, err, GenreFunkSoul)
+	}
+	if _, err := ParseGenre("Techno"); err != ErrInvalidGenre {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidGenre)
+	}
+	if n := len(Genres()); n != 15 {
+		t.Errorf("genres got=%d; want=15", n)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	if s, err := ParseStyle(" deep house "); err != nil || s != "Deep House" {
+		t.Errorf("style got=%s, %v; want=Deep House", s, err)
+	}
+	if _, err := ParseStyle("Rock"); err != ErrInvalidStyle {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidStyle)
+	}
+
+	if got, want := Style("Electro").Genres(), []Genre{GenreElectronic, GenreHipHop}; !cmp.Equal(got, want) {
+		t.Errorf("genres got=%v; want=%v", got, want)
+	}
+	if got := Style("Unknown").Genres(); len(got) != 0 {
+		t.Errorf("genres of unknown style got=%v; want none", got)
+	}
+}
+
+func TestGenreStylesSorted(t *testing.T) {
+	for _, g := range Genres() {
+		styles := g.Styles()
+		if len(styles) == 0 {
+			t.Errorf("%s: no styles", g)
+		}
+		sorted := sort.SliceIsSorted(styles, func(i, j int) bool {
+			return strings.ToLower(string(styles[i])) < strings.ToLower(string(styles[j]))
+		})
+		if !sorted {
+			t.Errorf("%s: styles not sorted", g)
+		}
+	}
+}
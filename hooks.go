@@ -0,0 +1,39 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestHook is called before every API request is sent.
+type RequestHook func(ctx context.Context, method, url string)
+
+// ResponseHook is called after every API request with the response status code, which is zero if no response was
+// received, the time taken and the rate limiting parameters reported by the response.
+type ResponseHook func(ctx context.Context, status int, duration time.Duration, rl RateLimitSnapshot)
+
+// hooks returns middleware invoking onRequest and onResponse, either of which may be nil.
+func hooks(onRequest RequestHook, onResponse ResponseHook) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			if onRequest != nil {
+				onRequest(r.Context(), r.Method, r.URL.String())
+			}
+
+			start := time.Now()
+			resp, err := next(r)
+
+			if onResponse != nil {
+				var status int
+				var rl RateLimitSnapshot
+				if resp != nil {
+					status = resp.StatusCode
+					rl = rateLimitSnapshot(resp.Header)
+				}
+				onResponse(r.Context(), status, time.Since(start), rl)
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHooks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Discogs-Ratelimit", "60")
+		w.Header().Set("X-Discogs-Ratelimit-Used", "2")
+		w.Header().Set("X-Discogs-Ratelimit-Remaining", "58")
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	var method, url string
+	var status int
+	var rl RateLimitSnapshot
+	d := initDiscogsClient(t, &Options{
+		URL: ts.URL,
+		OnRequest: func(ctx context.Context, m, u string) {
+			method, url = m, u
+		},
+		OnResponse: func(ctx context.Context, s int, d time.Duration, r RateLimitSnapshot) {
+			status, rl = s, r
+		},
+	})
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+
+	if method != "GET" || !strings.HasPrefix(url, ts.URL+"/releases/8138518") {
+		t.Errorf("request got=%s %s; want=GET %s/releases/8138518", method, url, ts.URL)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status got=%d; want=%d", status, http.StatusOK)
+	}
+	if want := (RateLimitSnapshot{Total: 60, Used: 2, Remaining: 58}); rl != want {
+		t.Errorf("rate limit got=%+v; want=%+v", rl, want)
+	}
+}
@@ -0,0 +1,61 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// ImagesService is an interface to download images.
+type ImagesService interface {
+	// Image downloads an image, such as Image.URI or Release.Thumb, using the client's user-agent. The client's
+	// authentication is only sent to the API the client was created for and over https to the Discogs image hosts,
+	// i.discogs.com and img.discogs.com; images elsewhere are downloaded without it. The caller must close the
+	// returned reader.
+	Image(ctx context.Context, imageURL string, opts ...RequestOption) (io.ReadCloser, error)
+}
+
+type downloadFunc func(ctx context.Context, path string, opts ...RequestOption) (io.ReadCloser, error)
+
+type imagesService struct {
+	download downloadFunc
+	url      *url.URL
+}
+
+func newImagesService(download downloadFunc, apiURL string) ImagesService {
+	u, _ := url.Parse(apiURL)
+	return &imagesService{
+		download: download,
+		url:      u,
+	}
+}
+
+// imageHosts are the hosts Discogs serves images from.
+var imageHosts = map[string]bool{
+	"i.discogs.com":   true,
+	"img.discogs.com": true,
+}
+
+func (s *imagesService) Image(ctx context.Context, imageURL string, opts ...RequestOption) (io.ReadCloser, error) {
+	if imageURL == "" {
+		return nil, ErrInvalidImageURL
+	}
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Host == "" || (!strings.EqualFold(u.Scheme, "https") && !strings.EqualFold(u.Scheme, "http")) {
+		return nil, ErrInvalidImageURL
+	}
+	if !s.trusted(u) {
+		opts = append(append([]RequestOption{}, opts...), withoutCredentials())
+	}
+	return s.download(ctx, imageURL, opts...)
+}
+
+// trusted reports whether the client's credentials may be sent to u, i.e. whether it points to the API the client
+// was created for or to a Discogs image host over https.
+func (s *imagesService) trusted(u *url.URL) bool {
+	if s.url != nil && strings.EqualFold(u.Scheme, s.url.Scheme) && strings.EqualFold(u.Host, s.url.Host) {
+		return true
+	}
+	return strings.EqualFold(u.Scheme, "https") && imageHosts[strings.ToLower(u.Hostname())] && u.Port() == ""
+}
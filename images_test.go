@@ -0,0 +1,160 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const testImage = "\x89PNG\r\n\x1a\n"
+
+func ImagesServer(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("User-Agent") != testUserAgent || r.Header.Get("Authorization") != "Discogs token=some token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/images/R-8138518.png":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, testImage); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestImagesServiceImage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(ImagesServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Token: "some token"})
+
+	image, err := d.Image(context.Background(), ts.URL+"/images/R-8138518.png")
+	if err != nil {
+		t.Fatalf("failed to get image: %s", err)
+	}
+	defer image.Close()
+
+	b, err := ioutil.ReadAll(image)
+	if err != nil {
+		t.Fatalf("failed to read image: %s", err)
+	}
+	if string(b) != testImage {
+		t.Errorf("image got=%q; want=%q", b, testImage)
+	}
+}
+
+func TestImagesServiceImageErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(ImagesServer))
+	defer ts.Close()
+
+	tests := map[string]struct {
+		token    string
+		imageURL string
+		err      error
+	}{
+		"empty url":       {"some token", "", ErrInvalidImageURL},
+		"relative url":    {"some token", "/images/R-8138518.png", ErrInvalidImageURL},
+		"other scheme":    {"some token", "ftp://i.discogs.com/R-8138518.png", ErrInvalidImageURL},
+		"unauthorized":    {"", ts.URL + "/images/R-8138518.png", ErrUnauthorized},
+		"missing picture": {"some token", ts.URL + "/images/R-1.png", ErrNotFound},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			d := initDiscogsClient(t, &Options{URL: ts.URL, Token: tt.token})
+			if _, err := d.Image(context.Background(), tt.imageURL); !errors.Is(err, tt.err) {
+				t.Fatalf("err got=%v; want=%s", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestImagesServiceImageForeignHost(t *testing.T) {
+	var auth []string
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		if _, err := io.WriteString(w, testImage); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer foreign.Close()
+
+	tests := map[string]*Options{
+		"token":        {URL: "https://api.discogs.com", Token: "some token"},
+		"consumer key": {URL: "https://api.discogs.com", ConsumerKey: "some key", ConsumerSecret: "some secret"},
+		"oauth":        {URL: "https://api.discogs.com", OAuth: &OAuthCredentials{ConsumerKey: "some key", ConsumerSecret: "some secret", Token: "some token", TokenSecret: "some token secret"}},
+	}
+	for name, o := range tests {
+		t.Run(name, func(t *testing.T) {
+			auth = nil
+			d := initDiscogsClient(t, o)
+			image, err := d.Image(context.Background(), foreign.URL+"/images/R-8138518.png")
+			if err != nil {
+				t.Fatalf("failed to get image: %s", err)
+			}
+			image.Close()
+			if len(auth) != 1 || auth[0] != "" {
+				t.Errorf("authorization got=%q; want none", auth)
+			}
+		})
+	}
+
+	s := &imagesService{}
+	for imageURL, want := range map[string]bool{
+		"https://i.discogs.com/R-8138518.png":      true,
+		"https://img.discogs.com/R-8138518.png":    true,
+		"http://i.discogs.com/R-8138518.png":       false,
+		"https://i.discogs.com:8443/R-8138518.png": false,
+		"https://i.discogs.com.evil/R-8138518.png": false,
+	} {
+		u, _ := url.Parse(imageURL)
+		if got := s.trusted(u); got != want {
+			t.Errorf("%s trusted got=%t; want=%t", imageURL, got, want)
+		}
+	}
+}
+
+func TestImagesServiceImageRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(ImagesServer))
+	defer ts.Close()
+
+	rl := NewRateLimit(RateLimitOptions{})
+	rl.Update(60, 1, 59)
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Token: "some token", RateLimit: rl})
+
+	image, err := d.Image(context.Background(), ts.URL+"/images/R-8138518.png")
+	if err != nil {
+		t.Fatalf("failed to get image: %s", err)
+	}
+	image.Close()
+
+	if total, used, remaining, _ := rl.Get(); total != 60 || used != 1 || remaining != 59 {
+		t.Errorf("rate limit got=%d, %d, %d; want=60, 1, 59", total, used, remaining)
+	}
+
+	slept := time.Duration(0)
+	sleep := func(ctx context.Context, duration time.Duration) error {
+		slept += duration
+		return nil
+	}
+	request := func() error {
+		return nil
+	}
+	if err := rl.call(WithPriority(context.Background(), PriorityBackground), request, sleep); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if slept != 0 {
+		t.Errorf("delay after downloading an image got=%v; want=0", slept)
+	}
+}
@@ -0,0 +1,140 @@
+package discogs
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVItem is a row of a collection or wantlist export produced by Discogs or by ExportCollectionCSV and
+// ExportWantlistCSV. Columns missing from the export are left empty.
+type CSVItem struct {
+	CatalogNumber    string
+	Artist           string
+	Title            string
+	Label            string
+	Format           string
+	Rating           int
+	Released         string
+	ReleaseID        int
+	CollectionFolder string    // collection exports only
+	DateAdded        time.Time // collection exports only
+	MediaCondition   Condition // collection exports only
+	SleeveCondition  Condition // collection exports only
+	Notes            string
+}
+
+// ParseCSV reads a collection or wantlist export. The columns are identified by the header row, so exports with
+// additional custom fields can be read as well; only the release_id column is required.
+func ParseCSV(r io.Reader) ([]CSVItem, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCSV, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+	if _, ok := columns["release_id"]; !ok {
+		return nil, fmt.Errorf("%w: missing release_id column", ErrInvalidCSV)
+	}
+
+	var items []CSVItem
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return items, fmt.Errorf("%w: %s", ErrInvalidCSV, err)
+		}
+
+		col := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		item := CSVItem{
+			CatalogNumber:    col("Catalog#"),
+			Artist:           col("Artist"),
+			Title:            col("Title"),
+			Label:            col("Label"),
+			Format:           col("Format"),
+			Released:         col("Released"),
+			CollectionFolder: col("CollectionFolder"),
+			Notes:            col("Collection Notes"),
+		}
+		if item.Notes == "" {
+			item.Notes = col("Notes")
+		}
+
+		if item.ReleaseID, err = strconv.Atoi(col("release_id")); err != nil {
+			return items, fmt.Errorf("%w: line %d: invalid release_id %q", ErrInvalidCSV, line, col("release_id"))
+		}
+		if v := col("Rating"); v != "" {
+			if item.Rating, err = strconv.Atoi(v); err != nil {
+				return items, fmt.Errorf("%w: line %d: invalid rating %q", ErrInvalidCSV, line, v)
+			}
+		}
+		if v := col("Date Added"); v != "" {
+			if item.DateAdded, err = time.Parse(csvDateFormat, v); err != nil {
+				return items, fmt.Errorf("%w: line %d: invalid date %q", ErrInvalidCSV, line, v)
+			}
+		}
+		if v := col("Collection Media Condition"); v != "" {
+			if item.MediaCondition, err = ParseCondition(v); err != nil {
+				return items, fmt.Errorf("%w: line %d: invalid media condition %q", ErrInvalidCSV, line, v)
+			}
+		}
+		if v := col("Collection Sleeve Condition"); v != "" {
+			if item.SleeveCondition, err = ParseCondition(v); err != nil {
+				return items, fmt.Errorf("%w: line %d: invalid sleeve condition %q", ErrInvalidCSV, line, v)
+			}
+		}
+
+		items = append(items, item)
+	}
+}
+
+// CollectionItemSource returns the row as a collection item with the details available in the export. The folder
+// ID and instance ID are unknown and left zero.
+func (i CSVItem) CollectionItemSource() CollectionItemSource {
+	item := CollectionItemSource{
+		ID:        i.ReleaseID,
+		DateAdded: Time{i.DateAdded},
+		Rating:    i.Rating,
+		BasicInformation: BasicInformation{
+			ID:    i.ReleaseID,
+			Title: i.Title,
+		},
+	}
+	item.BasicInformation.Year, _ = strconv.Atoi(i.Released)
+	if i.Artist != "" {
+		item.BasicInformation.Artists = []ArtistSource{{Name: i.Artist}}
+	}
+	if i.Label != "" || i.CatalogNumber != "" {
+		item.BasicInformation.Labels = []LabelSource{{Name: i.Label, Catno: i.CatalogNumber}}
+	}
+
+	for _, n := range []Notes{
+		{FieldID: mediaConditionField, Value: string(i.MediaCondition)},
+		{FieldID: sleeveConditionField, Value: string(i.SleeveCondition)},
+		{FieldID: notesField, Value: i.Notes},
+	} {
+		if n.Value != "" {
+			item.Notes = append(item.Notes, n)
+		}
+	}
+	return item
+}
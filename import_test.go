@@ -0,0 +1,91 @@
+package discogs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCSV(t *testing.T) {
+	const data = "\ufeffCatalog#,Artist,Title,Label,Format,Rating,Released,release_id,CollectionFolder,Date Added,Collection Media Condition,Collection Sleeve Condition,Collection Notes,Custom\n" +
+		"PR014,Zoo Lake,Zonk,Permanent Record,\"LP, Album\",4,2018,12934893,Uncategorized,2020-01-19 14:19:11,Near Mint (NM or M-),Very Good Plus (VG+),Purple vinyl,x\n" +
+		"STUMM 12,Yazoo,You And Me Both,Mute,\"LP, Album\",,1983,4825435,Uncategorized,2015-11-08 14:42:02,,,,\n"
+
+	items, err := ParseCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to parse csv: %s", err)
+	}
+
+	want := []CSVItem{
+		{
+			CatalogNumber:    "PR014",
+			Artist:           "Zoo Lake",
+			Title:            "Zonk",
+			Label:            "Permanent Record",
+			Format:           "LP, Album",
+			Rating:           4,
+			Released:         "2018",
+			ReleaseID:        12934893,
+			CollectionFolder: "Uncategorized",
+			DateAdded:        time.Date(2020, 1, 19, 14, 19, 11, 0, time.UTC),
+			MediaCondition:   ConditionNearMint,
+			SleeveCondition:  ConditionVeryGoodPlus,
+			Notes:            "Purple vinyl",
+		},
+		{
+			CatalogNumber:    "STUMM 12",
+			Artist:           "Yazoo",
+			Title:            "You And Me Both",
+			Label:            "Mute",
+			Format:           "LP, Album",
+			Released:         "1983",
+			ReleaseID:        4825435,
+			CollectionFolder: "Uncategorized",
+			DateAdded:        time.Date(2015, 11, 8, 14, 42, 2, 0, time.UTC),
+		},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("items got=%+v; want=%+v", items, want)
+	}
+
+	source := items[0].CollectionItemSource()
+	if source.ID != 12934893 || source.BasicInformation.Year != 2018 || len(source.Notes) != 3 || source.Notes[0].Value != string(ConditionNearMint) {
+		t.Errorf("collection item got=%+v", source)
+	}
+}
+
+func TestParseCSVWantlist(t *testing.T) {
+	const data = "Catalog#,Artist,Title,Label,Format,Rating,Released,release_id,Notes\n" +
+		"541125-1,LCD Soundsystem,The Long Goodbye,DFA,5xLP,,2014,5610049,Box set only\n"
+
+	items, err := ParseCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to parse csv: %s", err)
+	}
+	if len(items) != 1 || items[0].ReleaseID != 5610049 || items[0].Notes != "Box set only" {
+		t.Errorf("items got=%+v", items)
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing release_id column": "Catalog#,Artist\nPR014,Zoo Lake\n",
+		"invalid release_id":        "release_id\nabc\n",
+		"invalid rating":            "release_id,Rating\n1,five\n",
+		"invalid date":              "release_id,Date Added\n1,yesterday\n",
+		"invalid condition":         "release_id,Collection Media Condition\n1,Shiny\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseCSV(strings.NewReader(data)); !errors.Is(err, ErrInvalidCSV) {
+				t.Errorf("err got=%v; want=%s", err, ErrInvalidCSV)
+			}
+		})
+	}
+
+	if items, err := ParseCSV(strings.NewReader("")); err != nil || items != nil {
+		t.Errorf("items got=%v, %v; want=nil", items, err)
+	}
+}
@@ -0,0 +1,139 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:generate go run gen_intercepted.go
+
+// Service identifies one of the services composing Discogs, e.g. ServiceDatabase for DatabaseService.
+type Service string
+
+// Call describes a call of a Discogs method to an Interceptor.
+type Call struct {
+	Service Service
+	// Method is the name of the called method, e.g. "Release".
+	Method string
+	// Idempotent reports whether repeating the call has no further effects. Uploads, export requests and Do calls
+	// with methods other than GET, HEAD, OPTIONS, PUT and DELETE aren't, as a failed attempt may still have made
+	// changes.
+	Idempotent bool
+	// Args holds the arguments of the call other than the context and the request options.
+	Args []interface{}
+	// Opts holds the request options of the call.
+	Opts []RequestOption
+	// Result points to the value returned by the call, e.g. a **Release, or is nil if the call returns only an
+	// error. Interceptors may fill it in instead of invoking the call, e.g. from a cache.
+	Result interface{}
+}
+
+// Interceptor intercepts the calls of a Discogs client. It runs invoke to make the call, e.g. after waiting for a rate
+// limit or repeatedly to retry it, and returns its error, or fails the call without running invoke. The call is made
+// with the context passed to invoke, which derives from ctx.
+type Interceptor func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error
+
+// Intercept returns d with all functions replaced with versions passing their calls through i, which makes decorators
+// such as RateLimited, Retry and CircuitBreaker a single function. Clients derived with WithToken or WithOAuth are
+// intercepted by i as well.
+func Intercept(d Discogs, i Interceptor) Discogs {
+	return intercept(d, i, func(d Discogs) Discogs { return Intercept(d, i) })
+}
+
+// intercept returns d intercepted by i. Clients derived from it are decorated by derive, or left alone if it's nil.
+func intercept(d Discogs, i Interceptor, derive func(d Discogs) Discogs) *interceptedDiscogs {
+	return &interceptedDiscogs{d: d, i: i, derive: derive}
+}
+
+// interceptedDiscogs implements Discogs passing all calls through an interceptor. Its service methods are generated
+// into intercepted.go by gen_intercepted.go.
+type interceptedDiscogs struct {
+	d      Discogs
+	i      Interceptor
+	derive func(d Discogs) Discogs
+}
+
+func (r *interceptedDiscogs) WithToken(token string) Discogs {
+	if r.derive == nil {
+		return r.d.WithToken(token)
+	}
+	return r.derive(r.d.WithToken(token))
+}
+
+func (r *interceptedDiscogs) WithOAuth(creds OAuthCredentials) Discogs {
+	if r.derive == nil {
+		return r.d.WithOAuth(creds)
+	}
+	return r.derive(r.d.WithOAuth(creds))
+}
+
+// ForServices returns an interceptor passing the calls of the given services through i and making all others
+// directly, e.g. to only retry searches.
+func ForServices(i Interceptor, services ...Service) Interceptor {
+	set := make(map[Service]bool, len(services))
+	for _, s := range services {
+		set[s] = true
+	}
+	return func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		if !set[call.Service] {
+			return invoke(ctx)
+		}
+		return i(ctx, call, invoke)
+	}
+}
+
+// ChainInterceptors returns an interceptor passing calls through the given interceptors, the first one being the outermost.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next, interceptor := invoke, interceptors[i]
+			invoke = func(ctx context.Context) error { return interceptor(ctx, call, next) }
+		}
+		return invoke(ctx)
+	}
+}
+
+// nonIdempotent holds the methods whose calls have further effects when repeated.
+var nonIdempotent = map[string]bool{
+	"ExportInventory": true,
+	"UploadInventory": true,
+}
+
+func newCall(service Service, method string, result interface{}, opts []RequestOption, args ...interface{}) Call {
+	return Call{
+		Service:    service,
+		Method:     method,
+		Idempotent: !nonIdempotent[method],
+		Args:       args,
+		Opts:       opts,
+		Result:     result,
+	}
+}
+
+// withHTTPMethod returns the call made with the given HTTP method, e.g. by Do.
+func (c Call) withHTTPMethod(method string) Call {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		c.Idempotent = true
+	default:
+		c.Idempotent = false
+	}
+	return c
+}
+
+// key returns a key identifying the response of the call, e.g. "Release/8138518#USD".
+func (c Call) key() string {
+	var b strings.Builder
+	b.WriteString(c.Method)
+	for _, arg := range c.Args {
+		if p, ok := arg.(*Pagination); ok {
+			b.WriteString(paginationKey(p, c.Opts))
+			continue
+		}
+		fmt.Fprintf(&b, "/%v", arg)
+	}
+	b.WriteString(currencyKey(c.Opts))
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var _ Discogs = (*interceptedDiscogs)(nil)
+
+func TestIntercept(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	var calls []Call
+	d := Intercept(initDiscogsClient(t, &Options{URL: ts.URL}), func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		calls = append(calls, Call{Service: call.Service, Method: call.Method, Idempotent: call.Idempotent, Args: call.Args})
+		return invoke(ctx)
+	})
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if _, err := d.WithToken("some token").Artist(context.Background(), 38661); err != nil {
+		t.Fatalf("failed to get artist: %s", err)
+	}
+	want := []Call{
+		{Service: ServiceDatabase, Method: "Release", Idempotent: true, Args: []interface{}{8138518}},
+		{Service: ServiceDatabase, Method: "Artist", Idempotent: true, Args: []interface{}{38661}},
+	}
+	if !cmp.Equal(calls, want) {
+		t.Errorf("calls: %s", cmp.Diff(calls, want))
+	}
+}
+
+func TestInterceptFail(t *testing.T) {
+	errIntercepted := errors.New("intercepted")
+	d := Intercept(initDiscogsClient(t, &Options{URL: "http://127.0.0.1:0"}), func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		return errIntercepted
+	})
+	if _, err := d.Release(context.Background(), 8138518); !errors.Is(err, errIntercepted) {
+		t.Errorf("err got=%v; want=%v", err, errIntercepted)
+	}
+}
+
+func TestInterceptIdempotent(t *testing.T) {
+	var calls []Call
+	d := Intercept(initDiscogsClient(t, &Options{URL: "http://127.0.0.1:0"}), func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		calls = append(calls, Call{Service: call.Service, Method: call.Method, Idempotent: call.Idempotent})
+		return nil
+	})
+
+	ctx := context.Background()
+	_, _ = d.UploadInventory(ctx, UploadAdd, strings.NewReader(""))
+	_, _ = d.ExportInventory(ctx)
+	_ = d.Do(ctx, "get", "/releases/8138518", nil, nil, nil)
+	_ = d.Do(ctx, http.MethodPost, "/inventory/export", nil, nil, nil)
+	want := []Call{
+		{Service: ServiceInventoryUpload, Method: "UploadInventory", Idempotent: false},
+		{Service: ServiceInventoryExport, Method: "ExportInventory", Idempotent: false},
+		{Service: ServiceFetch, Method: "Do", Idempotent: true},
+		{Service: ServiceFetch, Method: "Do", Idempotent: false},
+	}
+	if !cmp.Equal(calls, want) {
+		t.Errorf("calls: %s", cmp.Diff(calls, want))
+	}
+}
+
+func TestInterceptResult(t *testing.T) {
+	type key struct{}
+	d := Intercept(initDiscogsClient(t, &Options{URL: "http://127.0.0.1:0"}), func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		if call.Method == "Artist" {
+			*call.Result.(**Artist) = &Artist{ID: 38661, Name: "Eminem"}
+			return nil
+		}
+		return invoke(context.WithValue(ctx, key{}, "value"))
+	})
+
+	artist, err := d.Artist(context.Background(), 38661)
+	if err != nil {
+		t.Fatalf("failed to get artist: %s", err)
+	}
+	if artist.Name != "Eminem" {
+		t.Errorf("artist name got=%q; want=%q", artist.Name, "Eminem")
+	}
+
+	var value interface{}
+	err = d.Do(context.Background(), http.MethodGet, "/", nil, nil, nil, WithDryRun(func(r *http.Request) {
+		value = r.Context().Value(key{})
+	}))
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("err got=%v; want=%s", err, ErrDryRun)
+	}
+	if value != "value" {
+		t.Errorf("context value got=%v; want=value", value)
+	}
+}
+
+func TestForServices(t *testing.T) {
+	intercepted := 0
+	i := ForServices(func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		intercepted++
+		return invoke(ctx)
+	}, ServiceSearch, ServiceWantlist)
+
+	tests := map[string]struct {
+		service Service
+		want    int
+	}{
+		"search":   {service: ServiceSearch, want: 1},
+		"wantlist": {service: ServiceWantlist, want: 1},
+		"database": {service: ServiceDatabase, want: 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			intercepted = 0
+			invoked := false
+			if err := i(context.Background(), Call{Service: tt.service}, func(ctx context.Context) error {
+				invoked = true
+				return nil
+			}); err != nil {
+				t.Fatalf("failed to intercept: %s", err)
+			}
+			if !invoked {
+				t.Errorf("call not invoked")
+			}
+			if intercepted != tt.want {
+				t.Errorf("intercepted got=%d; want=%d", intercepted, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainInterceptors(t *testing.T) {
+	var order []string
+	interceptor := func(name string) Interceptor {
+		return func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+			order = append(order, name+" before")
+			err := invoke(ctx)
+			order = append(order, name+" after")
+			return err
+		}
+	}
+
+	i := ChainInterceptors(interceptor("outer"), interceptor("inner"))
+	for n := 0; n < 2; n++ {
+		order = nil
+		if err := i(context.Background(), Call{}, func(ctx context.Context) error {
+			order = append(order, "call")
+			return nil
+		}); err != nil {
+			t.Fatalf("failed to intercept: %s", err)
+		}
+		want := []string{"outer before", "inner before", "call", "inner after", "outer after"}
+		if !cmp.Equal(order, want) {
+			t.Errorf("order got=%v; want=%v", order, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package discogs
+
+import (
+	"strings"
+)
+
+// Identifier types as reported in Identifier.Type.
+const (
+	IdentifierBarcode      = "Barcode"
+	IdentifierMatrixRunout = "Matrix / Runout"
+	IdentifierLabelCode    = "Label Code"
+	IdentifierASIN         = "ASIN"
+	IdentifierISRC         = "ISRC"
+)
+
+// ExternalIDs holds the identifiers of a release that are used to match it with entries in other services such as
+// MusicBrainz.
+type ExternalIDs struct {
+	Barcodes       []string // Barcodes reduced to their digits.
+	CatalogNumbers []string // Catalog numbers of all labels the release is on.
+	MatrixRunouts  []string // Matrix and runout etchings.
+}
+
+// ReleaseExternalIDs extracts the barcodes, catalog numbers and matrix/runout identifiers of r, skipping duplicates
+// and the catalog number "none" Discogs uses for releases without one.
+func ReleaseExternalIDs(r *Release) ExternalIDs {
+	var ids ExternalIDs
+	seen := map[string]struct{}{}
+	add := func(list *[]string, kind, v string) {
+		if v == "" {
+			return
+		}
+		if _, ok := seen[kind+v]; ok {
+			return
+		}
+		seen[kind+v] = struct{}{}
+		*list = append(*list, v)
+	}
+
+	for _, id := range r.Identifiers {
+		switch id.Type {
+		case IdentifierBarcode:
+			add(&ids.Barcodes, id.Type, digits(id.Value))
+		case IdentifierMatrixRunout:
+			add(&ids.MatrixRunouts, id.Type, strings.TrimSpace(id.Value))
+		}
+	}
+	for _, l := range r.Labels {
+		if catno := strings.TrimSpace(l.Catno); !strings.EqualFold(catno, "none") {
+			add(&ids.CatalogNumbers, "catno", catno)
+		}
+	}
+	return ids
+}
+
+// digits returns the decimal digits contained in s.
+func digits(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// MusicBrainzQuery returns a query for the MusicBrainz release search matching r by title and artist and, if r has
+// any, by one of its barcodes or catalog numbers.
+func MusicBrainzQuery(r *Release) string {
+	var terms []string
+	if r.Title != "" {
+		terms = append(terms, "release:"+luceneQuote(r.Title))
+	}
+	if len(r.Artists) > 0 && r.Artists[0].Name != "" {
+		terms = append(terms, "artist:"+luceneQuote(StripNameNumber(r.Artists[0].Name)))
+	}
+
+	ids := ReleaseExternalIDs(r)
+	var alternatives []string
+	for _, barcode := range ids.Barcodes {
+		alternatives = append(alternatives, "barcode:"+barcode)
+	}
+	for _, catno := range ids.CatalogNumbers {
+		alternatives = append(alternatives, "catno:"+luceneQuote(catno))
+	}
+	if len(alternatives) > 0 {
+		terms = append(terms, "("+strings.Join(alternatives, " OR ")+")")
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// luceneQuote returns s as a quoted Lucene phrase.
+func luceneQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
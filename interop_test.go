@@ -0,0 +1,41 @@
+package discogs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReleaseExternalIDs(t *testing.T) {
+	var release Release
+	if err := json.Unmarshal([]byte(releaseJson), &release); err != nil {
+		t.Fatalf("failed to decode release: %s", err)
+	}
+	release.Identifiers = append(release.Identifiers,
+		Identifier{Type: IdentifierBarcode, Value: "4 607053 460238"},
+		Identifier{Type: IdentifierBarcode, Value: "4607053460238", Description: "Scanned"},
+	)
+	release.Labels = append(release.Labels, LabelSource{Name: "Not On Label", Catno: "none"})
+
+	got := ReleaseExternalIDs(&release)
+	want := ExternalIDs{
+		Barcodes:       []string{"4607053460238"},
+		CatalogNumbers: []string{"MLR-007"},
+		MatrixRunouts:  []string{"134985E1/A", "134985E2/A"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ids got=%+v; want=%+v", got, want)
+	}
+
+	query := MusicBrainzQuery(&release)
+	wantQuery := `release:"Elephant Riddim" AND artist:"St. Petersburg Ska-Jazz Review" AND (barcode:4607053460238 OR catno:"MLR-007")`
+	if query != wantQuery {
+		t.Errorf("query got=%s; want=%s", query, wantQuery)
+	}
+
+	query = MusicBrainzQuery(&Release{Title: `The "Best"`, Artists: []ArtistSource{{Name: "Proof (3)"}}})
+	wantQuery = `release:"The \"Best\"" AND artist:"Proof"`
+	if query != wantQuery {
+		t.Errorf("query got=%s; want=%s", query, wantQuery)
+	}
+}
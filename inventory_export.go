@@ -0,0 +1,135 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+)
+
+// InventoryExportService is an interface to export a seller's inventory as CSV files.
+type InventoryExportService interface {
+	// ExportInventory requests an export of the inventory of the authenticated seller and returns the ID of the
+	// export. Discogs writes exports in the background; poll their status with InventoryExport or
+	// WaitInventoryExport before downloading them.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:inventory-export,header:inventory-export-export-your-inventory
+	ExportInventory(ctx context.Context, opts ...RequestOption) (int, error)
+	// InventoryExports returns the recent exports of the authenticated seller.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:inventory-export,header:inventory-export-get-recent-exports
+	InventoryExports(ctx context.Context, pagination *Pagination, opts ...RequestOption) (*InventoryExports, error)
+	// InventoryExport returns an export of the authenticated seller.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:inventory-export,header:inventory-export-get-an-export
+	InventoryExport(ctx context.Context, exportID int, opts ...RequestOption) (*InventoryExport, error)
+	// DownloadInventoryExport downloads the CSV file of a finished export of the authenticated seller. The caller
+	// must close the returned reader.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:inventory-export,header:inventory-export-download-an-export
+	DownloadInventoryExport(ctx context.Context, exportID int, opts ...RequestOption) (io.ReadCloser, error)
+}
+
+type postFunc func(ctx context.Context, path, contentType string, body io.Reader, opts ...RequestOption) (string, error)
+
+type inventoryExportService struct {
+	request  requestFunc
+	post     postFunc
+	download downloadFunc
+	url      string
+}
+
+func newInventoryExportService(req requestFunc, post postFunc, download downloadFunc, apiURL string) InventoryExportService {
+	return &inventoryExportService{
+		request:  req,
+		post:     post,
+		download: download,
+		url:      joinURL(apiURL, "inventory/export"),
+	}
+}
+
+// InventoryExport is an inventory export and the state of writing it.
+type InventoryExport struct {
+	ID       int    `json:"id"`
+	Filename string `json:"filename"`
+	// Status is the state of writing the export, e.g. "success".
+	Status      string `json:"status"`
+	DownloadURL string `json:"download_url"`
+	Created     Time   `json:"created_ts"`
+	Finished    Time   `json:"finished_ts"`
+}
+
+// Done reports whether Discogs finished writing the export.
+func (e *InventoryExport) Done() bool {
+	return !e.Finished.IsZero()
+}
+
+// Succeeded reports whether the export was written successfully and can be downloaded.
+func (e *InventoryExport) Succeeded() bool {
+	return e.Status == "success"
+}
+
+// InventoryExports is a list of a seller's inventory exports.
+type InventoryExports struct {
+	Pagination Page              `json:"pagination"`
+	Items      []InventoryExport `json:"items"`
+}
+
+func (s *inventoryExportService) ExportInventory(ctx context.Context, opts ...RequestOption) (int, error) {
+	location, err := s.post(ctx, s.url, "", nil, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return locationID(location)
+}
+
+func (s *inventoryExportService) InventoryExports(ctx context.Context, pagination *Pagination, opts ...RequestOption) (*InventoryExports, error) {
+	if err := pagination.validate(nil); err != nil {
+		return nil, err
+	}
+	var exports *InventoryExports
+	err := s.request(ctx, s.url, pagination.params(), &exports, opts...)
+	return exports, err
+}
+
+func (s *inventoryExportService) InventoryExport(ctx context.Context, exportID int, opts ...RequestOption) (*InventoryExport, error) {
+	if exportID <= 0 {
+		return nil, ErrInvalidExportID
+	}
+	var export *InventoryExport
+	err := s.request(ctx, joinURL(s.url, strconv.Itoa(exportID)), nil, &export, opts...)
+	return export, err
+}
+
+func (s *inventoryExportService) DownloadInventoryExport(ctx context.Context, exportID int, opts ...RequestOption) (io.ReadCloser, error) {
+	if exportID <= 0 {
+		return nil, ErrInvalidExportID
+	}
+	return s.download(ctx, joinURL(s.url, strconv.Itoa(exportID), "download"), opts...)
+}
+
+// WaitInventoryExport polls the export every interval (default 10 seconds) until Discogs finished writing it and
+// returns it, or the error of ctx if it is done first.
+func WaitInventoryExport(ctx context.Context, s InventoryExportService, exportID int, interval time.Duration, opts ...RequestOption) (*InventoryExport, error) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		export, err := s.InventoryExport(ctx, exportID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if export.Done() {
+			return export, nil
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
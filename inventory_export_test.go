@@ -0,0 +1,103 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const inventoryExportCSV = "listing_id,artist,title,label,catno,format,release_id,status,price\n" +
+	"172723812,Eminem,Infinite,Web Entertainment,WEB-1,LP,3221262,For Sale,42.00\n"
+
+func InventoryExportServer(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/inventory/export":
+		w.Header().Set("Location", "https://api.discogs.com/inventory/export/599632")
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == "GET" && r.URL.Path == "/inventory/export":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, inventoryExportsJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	case r.Method == "GET" && r.URL.Path == "/inventory/export/599632":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, inventoryExportJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	case r.Method == "GET" && r.URL.Path == "/inventory/export/599632/download":
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, inventoryExportCSV); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestInventoryExport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(InventoryExportServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	id, err := d.ExportInventory(ctx)
+	if err != nil {
+		t.Fatalf("failed to export inventory: %s", err)
+	}
+	if id != 599632 {
+		t.Errorf("id got=%d; want=599632", id)
+	}
+
+	export, err := WaitInventoryExport(ctx, d, id, time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to wait for export: %s", err)
+	}
+	want := time.Date(2018, 9, 27, 12, 59, 2, 0, time.UTC)
+	if !export.Succeeded() || export.Filename != "cburmeister-inventory-20180927-1259.csv" || !export.Finished.Equal(want) {
+		t.Errorf("export got=%+v", export)
+	}
+	if _, err := WaitInventoryExport(ctx, d, id, 0); err != nil {
+		t.Errorf("failed to wait for export with the default interval: %s", err)
+	}
+
+	exports, err := d.InventoryExports(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to get exports: %s", err)
+	}
+	if len(exports.Items) != 1 || exports.Items[0].ID != id {
+		t.Errorf("exports got=%+v", exports)
+	}
+
+	rc, err := d.DownloadInventoryExport(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to download export: %s", err)
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read export: %s", err)
+	}
+	if string(b) != inventoryExportCSV {
+		t.Errorf("csv got=%q; want=%q", b, inventoryExportCSV)
+	}
+
+	if _, err := d.InventoryExport(ctx, 0); err != ErrInvalidExportID {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidExportID)
+	}
+	if _, err := d.DownloadInventoryExport(ctx, -1); err != ErrInvalidExportID {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidExportID)
+	}
+}
@@ -0,0 +1,218 @@
+package discogs
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"time"
+)
+
+// InventoryUploadService is an interface to manage a seller's inventory with CSV uploads.
+type InventoryUploadService interface {
+	// UploadInventory uploads a CSV file of listings to add, change or delete, as written by WriteInventoryCSV, and
+	// returns the ID of the upload. Discogs processes uploads in the background; poll their status with
+	// InventoryUpload or WaitInventoryUpload.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:inventory-upload
+	UploadInventory(ctx context.Context, uploadType InventoryUploadType, csv io.Reader, opts ...RequestOption) (int, error)
+	// InventoryUploads returns the recent uploads of the authenticated seller.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:inventory-upload,header:inventory-upload-get-recent-uploads
+	InventoryUploads(ctx context.Context, pagination *Pagination, opts ...RequestOption) (*InventoryUploads, error)
+	// InventoryUpload returns an upload of the authenticated seller.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:inventory-upload,header:inventory-upload-get-an-upload
+	InventoryUpload(ctx context.Context, uploadID int, opts ...RequestOption) (*InventoryUpload, error)
+}
+
+type uploadFunc func(ctx context.Context, path, filename string, body io.Reader, opts ...RequestOption) (string, error)
+
+type inventoryUploadService struct {
+	request requestFunc
+	upload  uploadFunc
+	url     string
+}
+
+func newInventoryUploadService(req requestFunc, upload uploadFunc, apiURL string) InventoryUploadService {
+	return &inventoryUploadService{
+		request: req,
+		upload:  upload,
+		url:     joinURL(apiURL, "inventory/upload"),
+	}
+}
+
+// InventoryUploadType is the kind of changes an inventory upload makes.
+type InventoryUploadType string
+
+// Inventory upload types.
+const (
+	UploadAdd    InventoryUploadType = "add"
+	UploadChange InventoryUploadType = "change"
+	UploadDelete InventoryUploadType = "delete"
+)
+
+// InventoryUpload is an inventory upload and the results of processing it.
+type InventoryUpload struct {
+	ID       int                 `json:"id"`
+	Type     InventoryUploadType `json:"type"`
+	Filename string              `json:"filename"`
+	// Status is the state of processing, e.g. "success".
+	Status string `json:"status"`
+	// Results summarize the processing, e.g. the number of records processed and the errors of rejected ones.
+	Results  string `json:"results"`
+	Created  Time   `json:"created_ts"`
+	Finished Time   `json:"finished_ts"`
+}
+
+// Done reports whether Discogs finished processing the upload.
+func (u *InventoryUpload) Done() bool {
+	return !u.Finished.IsZero()
+}
+
+// Succeeded reports whether the upload was processed successfully. Single records may still have been rejected, as
+// reported in Results.
+func (u *InventoryUpload) Succeeded() bool {
+	return u.Status == "success"
+}
+
+// InventoryUploads is a list of a seller's inventory uploads.
+type InventoryUploads struct {
+	Pagination Page              `json:"pagination"`
+	Items      []InventoryUpload `json:"items"`
+}
+
+func (s *inventoryUploadService) UploadInventory(ctx context.Context, uploadType InventoryUploadType, csv io.Reader, opts ...RequestOption) (int, error) {
+	switch uploadType {
+	case UploadAdd, UploadChange, UploadDelete:
+	default:
+		return 0, ErrInvalidUploadType
+	}
+	location, err := s.upload(ctx, joinURL(s.url, string(uploadType)), string(uploadType)+".csv", csv, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return locationID(location)
+}
+
+// locationID returns the ID of the resource created by a request from the Location header of the response, which
+// ends with it, e.g. "https://api.discogs.com/inventory/upload/599632".
+func locationID(location string) (int, error) {
+	id, err := strconv.Atoi(path.Base(location))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: location %q", ErrUnexpectedResponse, location)
+	}
+	return id, nil
+}
+
+func (s *inventoryUploadService) InventoryUploads(ctx context.Context, pagination *Pagination, opts ...RequestOption) (*InventoryUploads, error) {
+	if err := pagination.validate(nil); err != nil {
+		return nil, err
+	}
+	var uploads *InventoryUploads
+	err := s.request(ctx, s.url, pagination.params(), &uploads, opts...)
+	return uploads, err
+}
+
+func (s *inventoryUploadService) InventoryUpload(ctx context.Context, uploadID int, opts ...RequestOption) (*InventoryUpload, error) {
+	if uploadID <= 0 {
+		return nil, ErrInvalidUploadID
+	}
+	var upload *InventoryUpload
+	err := s.request(ctx, joinURL(s.url, strconv.Itoa(uploadID)), nil, &upload, opts...)
+	return upload, err
+}
+
+// Columns of the inventory upload CSV files by upload type.
+var inventoryCSVHeaders = map[InventoryUploadType][]string{
+	UploadAdd:    {"release_id", "price", "media_condition", "sleeve_condition", "comments", "accept_offer"},
+	UploadChange: {"listing_id", "price", "media_condition", "sleeve_condition", "comments", "accept_offer"},
+	UploadDelete: {"listing_id"},
+}
+
+// WriteInventoryCSV writes the listings to w as a CSV file for an upload of the given type. Additions take the
+// release ID, price, conditions, comments and whether offers are allowed of the listings, changes the listing ID
+// instead of the release ID and deletions only the listing ID. Prices are written without currency, as Discogs takes
+// them in the seller's currency. It returns ErrInvalidCSV for listings missing the IDs or, for additions and changes,
+// the price or media condition.
+func WriteInventoryCSV(w io.Writer, uploadType InventoryUploadType, listings []MarketplaceListing) error {
+	header, ok := inventoryCSVHeaders[uploadType]
+	if !ok {
+		return ErrInvalidUploadType
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i, l := range listings {
+		record, err := inventoryCSV(uploadType, l)
+		if err != nil {
+			return fmt.Errorf("%w: listing %d: %s", ErrInvalidCSV, i+1, err)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// inventoryCSV returns the record of the listing in an upload of the given type.
+func inventoryCSV(uploadType InventoryUploadType, l MarketplaceListing) ([]string, error) {
+	var id string
+	switch {
+	case uploadType == UploadAdd && l.Release.ID <= 0:
+		return nil, errors.New("no release id")
+	case uploadType == UploadAdd:
+		id = strconv.Itoa(l.Release.ID)
+	case l.ID <= 0:
+		return nil, errors.New("no listing id")
+	default:
+		id = strconv.FormatInt(l.ID, 10)
+	}
+	if uploadType == UploadDelete {
+		return []string{id}, nil
+	}
+
+	if l.Price.Amount <= 0 {
+		return nil, errors.New("no price")
+	}
+	if l.Condition == "" || l.Condition.SleeveOnly() {
+		return nil, fmt.Errorf("invalid media condition %q", l.Condition)
+	}
+	acceptOffer := "N"
+	if l.AllowOffers {
+		acceptOffer = "Y"
+	}
+	return []string{id, csvAmount(l.Price), string(l.Condition), string(l.SleeveCondition), l.Comments, acceptOffer}, nil
+}
+
+// WaitInventoryUpload polls the upload every interval (default 10 seconds) until Discogs finished processing it and
+// returns it, or the error of ctx if it is done first.
+func WaitInventoryUpload(ctx context.Context, s InventoryUploadService, uploadID int, interval time.Duration, opts ...RequestOption) (*InventoryUpload, error) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		upload, err := s.InventoryUpload(ctx, uploadID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if upload.Done() {
+			return upload, nil
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
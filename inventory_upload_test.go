@@ -0,0 +1,198 @@
+package discogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func InventoryUploadServer(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/inventory/upload/add":
+		f, header, err := r.FormFile("upload")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		b, err := ioutil.ReadAll(f)
+		if err != nil || header.Filename != "add.csv" || !strings.HasPrefix(string(b), "release_id,") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Location", "https://api.discogs.com/inventory/upload/599632")
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == "GET" && r.URL.Path == "/inventory/upload":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, inventoryUploadsJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	case r.Method == "GET" && r.URL.Path == "/inventory/upload/599632":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, inventoryUploadJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestInventoryUpload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(InventoryUploadServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	var csv bytes.Buffer
+	listings := []MarketplaceListing{{Release: ListingRelease{ID: 1}, Price: NewPrice(10, "USD"), Condition: ConditionMint}}
+	if err := WriteInventoryCSV(&csv, UploadAdd, listings); err != nil {
+		t.Fatalf("failed to write csv: %s", err)
+	}
+	id, err := d.UploadInventory(ctx, UploadAdd, &csv)
+	if err != nil {
+		t.Fatalf("failed to upload inventory: %s", err)
+	}
+	if id != 599632 {
+		t.Errorf("id got=%d; want=599632", id)
+	}
+
+	upload, err := d.InventoryUpload(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get upload: %s", err)
+	}
+	want := time.Date(2017, 12, 18, 9, 22, 4, 0, time.UTC)
+	if !upload.Done() || !upload.Succeeded() || upload.Type != UploadAdd || !upload.Finished.Equal(want) {
+		t.Errorf("upload got=%+v", upload)
+	}
+
+	uploads, err := d.InventoryUploads(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to get uploads: %s", err)
+	}
+	if len(uploads.Items) != 1 || uploads.Items[0].ID != id {
+		t.Errorf("uploads got=%+v", uploads)
+	}
+
+	if _, err := d.UploadInventory(ctx, "update", &csv); err != ErrInvalidUploadType {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUploadType)
+	}
+	if _, err := d.InventoryUpload(ctx, 0); err != ErrInvalidUploadID {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUploadID)
+	}
+}
+
+func TestWriteInventoryCSV(t *testing.T) {
+	listing := MarketplaceListing{
+		ID:              172723812,
+		Release:         ListingRelease{ID: 1},
+		Price:           NewPrice(12.5, "USD"),
+		Condition:       ConditionVeryGoodPlus,
+		SleeveCondition: ConditionGeneric,
+		Comments:        "Shrink, \"hype\" sticker",
+		AllowOffers:     true,
+	}
+
+	tests := map[string]struct {
+		uploadType InventoryUploadType
+		listing    MarketplaceListing
+		want       string
+		err        error
+	}{
+		"add": {
+			uploadType: UploadAdd,
+			listing:    listing,
+			want: "release_id,price,media_condition,sleeve_condition,comments,accept_offer\n" +
+				"1,12.50,Very Good Plus (VG+),Generic,\"Shrink, \"\"hype\"\" sticker\",Y\n",
+		},
+		"change": {
+			uploadType: UploadChange,
+			listing:    listing,
+			want: "listing_id,price,media_condition,sleeve_condition,comments,accept_offer\n" +
+				"172723812,12.50,Very Good Plus (VG+),Generic,\"Shrink, \"\"hype\"\" sticker\",Y\n",
+		},
+		"delete": {
+			uploadType: UploadDelete,
+			listing:    listing,
+			want:       "listing_id\n172723812\n",
+		},
+		"no release id": {
+			uploadType: UploadAdd,
+			listing:    MarketplaceListing{ID: 1, Price: listing.Price, Condition: ConditionMint},
+			err:        ErrInvalidCSV,
+		},
+		"no price": {
+			uploadType: UploadChange,
+			listing:    MarketplaceListing{ID: 1, Condition: ConditionMint},
+			err:        ErrInvalidCSV,
+		},
+		"sleeve condition": {
+			uploadType: UploadAdd,
+			listing:    MarketplaceListing{Release: ListingRelease{ID: 1}, Price: listing.Price, Condition: ConditionNoCover},
+			err:        ErrInvalidCSV,
+		},
+		"invalid type": {
+			uploadType: "update",
+			listing:    listing,
+			err:        ErrInvalidUploadType,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var b strings.Builder
+			err := WriteInventoryCSV(&b, tt.uploadType, []MarketplaceListing{tt.listing})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err got=%v; want=%v", err, tt.err)
+			}
+			if tt.err == nil && b.String() != tt.want {
+				t.Errorf("got=%q; want=%q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitInventoryUpload(t *testing.T) {
+	polls := 0
+	s := newInventoryUploadService(func(ctx context.Context, path string, params url.Values, resp interface{}, opts ...RequestOption) error {
+		polls++
+		upload := resp.(**InventoryUpload)
+		*upload = &InventoryUpload{ID: 1, Status: "processing"}
+		if polls == 3 {
+			(*upload).Status = "success"
+			(*upload).Finished = Time{Time: time.Now()}
+		}
+		return nil
+	}, nil, "")
+
+	upload, err := WaitInventoryUpload(context.Background(), s, 1, time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to wait for upload: %s", err)
+	}
+	if !upload.Succeeded() || polls != 3 {
+		t.Errorf("upload got=%+v, polls got=%d; want=3", upload, polls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	polls = -10
+	if _, err := WaitInventoryUpload(ctx, s, 1, time.Hour); err != context.Canceled {
+		t.Errorf("err got=%v; want=%s", err, context.Canceled)
+	}
+	if _, err := WaitInventoryUpload(ctx, s, 1, 0); err != context.Canceled {
+		t.Errorf("zero interval err got=%v; want=%s", err, context.Canceled)
+	}
+}
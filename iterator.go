@@ -0,0 +1,334 @@
+package discogs
+
+import (
+	"context"
+	"time"
+)
+
+// iterator holds the paging state shared by the typed iterators. fetch retrieves the requested page, stores its
+// items in the typed iterator and returns the page's pagination details along with the number of items it held.
+type iterator struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page int) (Page, int, error)
+
+	page    int  // number of the most recently fetched page
+	current Page // pagination details of the most recently fetched page
+	n       int  // number of items on the current page
+	i       int  // index of the current item on the current page
+	err     error
+	done    bool
+
+	progress  ProgressFunc // set by WithProgress
+	startPage int
+	started   time.Time // time the first page was requested
+	pages     int       // number of pages fetched
+	fetched   int       // number of items on the pages fetched
+}
+
+func newIterator(ctx context.Context, startPage int, opts []RequestOption, fetch func(ctx context.Context, page int) (Page, int, error)) iterator {
+	if startPage < 1 {
+		startPage = 1
+	}
+	return iterator{
+		ctx:       ctx,
+		fetch:     fetch,
+		page:      startPage - 1,
+		i:         -1,
+		progress:  newRequestOptions(opts).progress,
+		startPage: startPage,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page when the current one is exhausted. It returns
+// false when there are no more items or an error occurred; use Err to tell the two apart.
+func (it *iterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	it.i++
+	for it.i >= it.n {
+		if it.i > 0 && !it.current.HasNext() {
+			it.done = true
+			return false
+		}
+
+		if it.started.IsZero() {
+			it.started = time.Now()
+		}
+		page := it.nextPage()
+		current, n, err := it.fetch(it.ctx, page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+		it.pages++
+		it.current = current
+		it.n = n
+		it.i = 0
+		it.fetched += n
+		if it.progress != nil {
+			it.progress(it.Progress())
+		}
+
+		if n == 0 {
+			it.done = true
+			return false
+		}
+	}
+	return true
+}
+
+// nextPage returns the number of the page to fetch next: the one the most recently fetched page links to as the next
+// page, or else the one following it. Links that don't lead further are ignored, so that they can't loop.
+func (it *iterator) nextPage() int {
+	if it.current.URLs.Next != "" {
+		if next, err := ParsePageURL(it.current.URLs.Next); err == nil && next.Page > it.page {
+			return next.Page
+		}
+	}
+	return it.page + 1
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// Progress returns the pages and items fetched so far. The totals exclude the pages before the first one requested.
+func (it *iterator) Progress() Progress {
+	p := Progress{
+		Pages: it.pages,
+		Items: it.fetched,
+	}
+	if !it.started.IsZero() {
+		p.Elapsed = time.Since(it.started)
+	}
+	if it.current.Pages > 0 {
+		p.TotalPages = it.current.Pages - it.startPage + 1
+		p.TotalItems = it.current.Items - (it.startPage-1)*it.current.PerPage
+	}
+	return p
+}
+
+// Page returns the pagination details of the most recently fetched page.
+func (it *iterator) Page() Page {
+	return it.current
+}
+
+// withPage returns a copy of p requesting the given page.
+func (p *Pagination) withPage(page int) *Pagination {
+	var c Pagination
+	if p != nil {
+		c = *p
+	}
+	c.Page = page
+	return &c
+}
+
+// startPage returns the first page requested by p.
+func (p *Pagination) startPage() int {
+	if p == nil {
+		return 1
+	}
+	return p.Page
+}
+
+// ReleaseSourceIterator iterates over the releases of an artist or label.
+type ReleaseSourceIterator struct {
+	iterator
+	items []ReleaseSource
+}
+
+// Item returns the current release.
+func (it *ReleaseSourceIterator) Item() ReleaseSource {
+	return it.items[it.i]
+}
+
+// ArtistReleasesIter returns an iterator over all releases associated with the artist, starting at the page
+// requested by pagination and following the pages until the last one.
+func ArtistReleasesIter(ctx context.Context, s DatabaseService, artistID int, pagination *Pagination, opts ...RequestOption) *ReleaseSourceIterator {
+	it := &ReleaseSourceIterator{}
+	it.iterator = newIterator(ctx, pagination.startPage(), opts, func(ctx context.Context, page int) (Page, int, error) {
+		releases, err := s.ArtistReleases(ctx, artistID, pagination.withPage(page), opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = releases.Releases
+		return releases.Pagination, len(it.items), nil
+	})
+	return it
+}
+
+// LabelReleasesIter returns an iterator over all releases associated with the label, starting at the page
+// requested by pagination and following the pages until the last one.
+func LabelReleasesIter(ctx context.Context, s DatabaseService, labelID int, pagination *Pagination, opts ...RequestOption) *ReleaseSourceIterator {
+	it := &ReleaseSourceIterator{}
+	it.iterator = newIterator(ctx, pagination.startPage(), opts, func(ctx context.Context, page int) (Page, int, error) {
+		releases, err := s.LabelReleases(ctx, labelID, pagination.withPage(page), opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = releases.Releases
+		return releases.Pagination, len(it.items), nil
+	})
+	return it
+}
+
+// VersionIterator iterates over the versions of a master release.
+type VersionIterator struct {
+	iterator
+	items []Version
+}
+
+// Item returns the current version.
+func (it *VersionIterator) Item() Version {
+	return it.items[it.i]
+}
+
+// MasterVersionsIter returns an iterator over all versions of the master release, starting at the page requested by
+// pagination and following the pages until the last one.
+func MasterVersionsIter(ctx context.Context, s DatabaseService, masterID int, pagination *Pagination, opts ...RequestOption) *VersionIterator {
+	it := &VersionIterator{}
+	it.iterator = newIterator(ctx, pagination.startPage(), opts, func(ctx context.Context, page int) (Page, int, error) {
+		versions, err := s.MasterVersions(ctx, masterID, pagination.withPage(page), opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = versions.Versions
+		return versions.Pagination, len(it.items), nil
+	})
+	return it
+}
+
+// CollectionItemIterator iterates over the items in a user's collection.
+type CollectionItemIterator struct {
+	iterator
+	items []CollectionItemSource
+}
+
+// Item returns the current collection item.
+func (it *CollectionItemIterator) Item() CollectionItemSource {
+	return it.items[it.i]
+}
+
+// CollectionItemsByFolderIter returns an iterator over all items in a folder of the user's collection, starting at
+// the page requested by pagination and following the pages until the last one.
+func CollectionItemsByFolderIter(ctx context.Context, s CollectionService, username string, folderID int, pagination *Pagination, opts ...RequestOption) *CollectionItemIterator {
+	it := &CollectionItemIterator{}
+	it.iterator = newIterator(ctx, pagination.startPage(), opts, func(ctx context.Context, page int) (Page, int, error) {
+		items, err := s.CollectionItemsByFolder(ctx, username, folderID, pagination.withPage(page), opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = items.Items
+		return items.Pagination, len(it.items), nil
+	})
+	return it
+}
+
+// WantIterator iterates over the releases in a user's wantlist.
+type WantIterator struct {
+	iterator
+	items []Want
+}
+
+// Item returns the current want.
+func (it *WantIterator) Item() Want {
+	return it.items[it.i]
+}
+
+// WantlistIter returns an iterator over all releases in the user's wantlist, starting at the page requested by
+// pagination and following the pages until the last one.
+func WantlistIter(ctx context.Context, s WantlistService, username string, pagination *Pagination, opts ...RequestOption) *WantIterator {
+	it := &WantIterator{}
+	it.iterator = newIterator(ctx, pagination.startPage(), opts, func(ctx context.Context, page int) (Page, int, error) {
+		wantlist, err := s.Wantlist(ctx, username, pagination.withPage(page), opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = wantlist.Wants
+		return wantlist.Pagination, len(it.items), nil
+	})
+	return it
+}
+
+// ListingIterator iterates over marketplace listings.
+type ListingIterator struct {
+	iterator
+	items []MarketplaceListing
+}
+
+// Item returns the current listing.
+func (it *ListingIterator) Item() MarketplaceListing {
+	return it.items[it.i]
+}
+
+// InventoryIter returns an iterator over all listings in the seller's inventory, starting at the page requested by
+// pagination and following the pages until the last one.
+func InventoryIter(ctx context.Context, s MarketPlaceService, username string, pagination *Pagination, opts ...RequestOption) *ListingIterator {
+	it := &ListingIterator{}
+	it.iterator = newIterator(ctx, pagination.startPage(), opts, func(ctx context.Context, page int) (Page, int, error) {
+		inventory, err := s.Inventory(ctx, username, pagination.withPage(page), opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = inventory.Listings
+		return inventory.Pagination, len(it.items), nil
+	})
+	return it
+}
+
+// OrderIterator iterates over a seller's orders.
+type OrderIterator struct {
+	iterator
+	items []Order
+}
+
+// Item returns the current order.
+func (it *OrderIterator) Item() Order {
+	return it.items[it.i]
+}
+
+// OrdersIter returns an iterator over all orders of the authenticated seller with the given status, or all orders if
+// it is empty, starting at the page requested by pagination and following the pages until the last one.
+func OrdersIter(ctx context.Context, s MarketPlaceService, status OrderStatus, pagination *Pagination, opts ...RequestOption) *OrderIterator {
+	it := &OrderIterator{}
+	it.iterator = newIterator(ctx, pagination.startPage(), opts, func(ctx context.Context, page int) (Page, int, error) {
+		orders, err := s.Orders(ctx, status, pagination.withPage(page), opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = orders.Orders
+		return orders.Pagination, len(it.items), nil
+	})
+	return it
+}
+
+// ResultIterator iterates over search results.
+type ResultIterator struct {
+	iterator
+	items []Result
+}
+
+// Item returns the current search result.
+func (it *ResultIterator) Item() Result {
+	return it.items[it.i]
+}
+
+// SearchIter returns an iterator over all results of the search request, starting at the page requested by req and
+// following the pages until the last one.
+func SearchIter(ctx context.Context, s SearchService, req SearchRequest, opts ...RequestOption) *ResultIterator {
+	it := &ResultIterator{}
+	it.iterator = newIterator(ctx, req.Page, opts, func(ctx context.Context, page int) (Page, int, error) {
+		req.Page = page
+		search, err := s.Search(ctx, req, opts...)
+		if err != nil {
+			return Page{}, 0, err
+		}
+		it.items = search.Results
+		return search.Pagination, len(it.items), nil
+	})
+	return it
+}
@@ -0,0 +1,232 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// PagedServer serves artist releases split over pages of two items each, with the release IDs numbered from 1.
+func PagedServer(total int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/artists/1/releases" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		const perPage = 2
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages := (total + perPage - 1) / perPage
+
+		resp := ArtistReleases{Pagination: Page{Page: page, Pages: pages, PerPage: perPage, Items: total}}
+		if page < pages {
+			resp.Pagination.URLs.Next = "next"
+		}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= total; id++ {
+			resp.Releases = append(resp.Releases, ReleaseSource{ID: id})
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestArtistReleasesIter(t *testing.T) {
+	tests := map[string]struct {
+		total     int
+		startPage int
+		want      []int
+	}{
+		"empty":          {0, 0, nil},
+		"single page":    {2, 0, []int{1, 2}},
+		"multiple pages": {5, 0, []int{1, 2, 3, 4, 5}},
+		"start page":     {5, 2, []int{3, 4, 5}},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(PagedServer(tt.total))
+			defer ts.Close()
+
+			d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+			var got []int
+			it := ArtistReleasesIter(context.Background(), d, 1, &Pagination{Page: tt.startPage})
+			for it.Next() {
+				got = append(got, it.Item().ID)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("failed to iterate releases: %s", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("ids got=%v; want=%v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ids got=%v; want=%v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestArtistReleasesIterError(t *testing.T) {
+	ts := httptest.NewServer(PagedServer(5))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	it := ArtistReleasesIter(context.Background(), d, 2, nil)
+	if it.Next() {
+		t.Fatalf("expected iteration to stop")
+	}
+	if it.Err() == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestArtistReleasesIterNextLinks(t *testing.T) {
+	// each page holds the release with its number as ID, and links to the next page given here
+	next := map[int]string{
+		1: "https://api.discogs.com/artists/1/releases?page=3&per_page=1",
+		3: "https://api.discogs.com/artists/1/releases?page=2&per_page=1", // leads back, so page 4 follows
+		4: "%zz",                                                          // malformed, so page 5 follows
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		resp := ArtistReleases{
+			Pagination: Page{Page: page, Pages: 5, PerPage: 1, Items: 5},
+			Releases:   []ReleaseSource{{ID: page}},
+		}
+		resp.Pagination.URLs.Next = next[page]
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	var got []int
+	var progress Progress
+	it := ArtistReleasesIter(context.Background(), d, 1, nil, WithProgress(func(p Progress) { progress = p }))
+	for it.Next() {
+		got = append(got, it.Item().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("failed to iterate releases: %s", err)
+	}
+	if want := []int{1, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ids got=%v; want=%v", got, want)
+	}
+	if progress.Pages != 4 {
+		t.Errorf("pages got=%d; want=4", progress.Pages)
+	}
+}
+
+func TestAllArtistReleases(t *testing.T) {
+	ts := httptest.NewServer(PagedServer(5))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	tests := map[string]struct {
+		limit int
+		want  int
+	}{
+		"unlimited":       {0, 5},
+		"capped":          {3, 3},
+		"cap above total": {10, 5},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			releases, err := AllArtistReleases(context.Background(), d, 1, nil, tt.limit)
+			if err != nil {
+				t.Fatalf("failed to get releases: %s", err)
+			}
+			if len(releases) != tt.want {
+				t.Errorf("releases got=%d; want=%d", len(releases), tt.want)
+			}
+		})
+	}
+}
+
+func TestIteratorProgress(t *testing.T) {
+	ts := httptest.NewServer(PagedServer(5))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	tests := map[string]struct {
+		startPage int
+		want      [][4]int // pages, total pages, items, total items
+	}{
+		"first page": {0, [][4]int{{1, 3, 2, 5}, {2, 3, 4, 5}, {3, 3, 5, 5}}},
+		"start page": {2, [][4]int{{1, 2, 2, 3}, {2, 2, 3, 3}}},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			var got [][4]int
+			progress := WithProgress(func(p Progress) {
+				got = append(got, [4]int{p.Pages, p.TotalPages, p.Items, p.TotalItems})
+			})
+			if _, err := AllArtistReleases(context.Background(), d, 1, &Pagination{Page: tt.startPage}, 0, progress); err != nil {
+				t.Fatalf("failed to get releases: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("progress got=%v; want=%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageNext(t *testing.T) {
+	var items CollectionItems
+	if err := json.Unmarshal([]byte(collectionItemsByFolderJson), &items); err != nil {
+		t.Fatalf("failed to decode items: %s", err)
+	}
+	page := items.Pagination
+	if !page.HasNext() || page.TotalItems() != 95 {
+		t.Errorf("page got=%v, %d; want=true, 95", page.HasNext(), page.TotalItems())
+	}
+	want := Pagination{Sort: SortArtist, SortOrder: SortDesc, Page: 2, PerPage: 2}
+	if next := page.Next(); next == nil || *next != want {
+		t.Errorf("next got=%+v; want=%+v", next, want)
+	}
+
+	page = Page{Page: 2, Pages: 3, PerPage: 50}
+	want = Pagination{Page: 3, PerPage: 50}
+	if next := page.Next(); next == nil || *next != want {
+		t.Errorf("next got=%+v; want=%+v", next, want)
+	}
+
+	page = Page{Page: 3, Pages: 3}
+	if page.HasNext() || page.Next() != nil {
+		t.Errorf("expected no next page")
+	}
+
+	var p *Pagination
+	if next := p.NextPage(); next.Page != 1 {
+		t.Errorf("next page got=%d; want=1", next.Page)
+	}
+	p = &Pagination{Sort: SortYear, Page: 4}
+	if next := p.NextPage(); next.Page != 5 || next.Sort != SortYear || p.Page != 4 {
+		t.Errorf("next page got=%+v; want page 5 sorted by year", next)
+	}
+
+	if _, err := ParsePageURL("https://api.discogs.com/users/test_user/wants?page=x"); err != ErrInvalidURL {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidURL)
+	}
+}
@@ -0,0 +1,182 @@
+package discogs
+
+import (
+	"context"
+)
+
+// SubLabels returns the sublabels of the label, resolving each of them with a separate request. When recursive is
+// set, the sublabels of the sublabels are resolved as well, depth first, so the whole label hierarchy below the label
+// is returned.
+func SubLabels(ctx context.Context, s DatabaseService, labelID int, recursive bool, opts ...RequestOption) ([]*Label, error) {
+	label, err := s.Label(ctx, labelID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []*Label
+	seen := map[int]bool{labelID: true}
+	var resolve func(label *Label) error
+	resolve = func(label *Label) error {
+		for _, sub := range label.Sublabels {
+			if seen[sub.ID] {
+				continue
+			}
+			seen[sub.ID] = true
+
+			l, err := s.Label(ctx, sub.ID, opts...)
+			if err != nil {
+				return err
+			}
+			labels = append(labels, l)
+			if recursive {
+				if err := resolve(l); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	err = resolve(label)
+	return labels, err
+}
+
+// LabelDiscographyOptions configures LabelDiscographyIter.
+type LabelDiscographyOptions struct {
+	// Sublabels includes the releases of the sublabels of the label and of their sublabels, depth first.
+	Sublabels bool
+	// ByMaster skips the releases of masters already returned, leaving one release per master. The label releases
+	// don't report their masters, so every release is resolved with a separate request.
+	ByMaster bool
+	// Pagination selects the sort order and page size of the releases of each label (optional).
+	Pagination *Pagination
+}
+
+// LabelRelease is a release of a label's discography.
+type LabelRelease struct {
+	ReleaseSource
+	// LabelID is the ID of the label or sublabel listing the release.
+	LabelID int
+	// MasterID is the ID of the master of the release, only resolved with LabelDiscographyOptions.ByMaster and zero
+	// if the release has no master.
+	MasterID int
+}
+
+// LabelDiscographyIterator iterates over the releases of a label's discography.
+type LabelDiscographyIterator struct {
+	ctx  context.Context
+	s    DatabaseService
+	o    LabelDiscographyOptions
+	opts []RequestOption
+
+	labels   []int // labels to walk, the next one last
+	label    int
+	releases *ReleaseSourceIterator
+	seen     map[int]bool // labels queued
+	listed   map[int]bool // releases returned
+	masters  map[int]bool // masters returned
+	item     LabelRelease
+	err      error
+}
+
+// LabelDiscographyIter returns an iterator over the releases of the label, following all pages and optionally the
+// sublabels. Every release is returned once, even if several labels list it.
+func LabelDiscographyIter(ctx context.Context, s DatabaseService, labelID int, o LabelDiscographyOptions, opts ...RequestOption) *LabelDiscographyIterator {
+	return &LabelDiscographyIterator{
+		ctx:     ctx,
+		s:       s,
+		o:       o,
+		opts:    opts,
+		labels:  []int{labelID},
+		seen:    map[int]bool{labelID: true},
+		listed:  make(map[int]bool),
+		masters: make(map[int]bool),
+	}
+}
+
+// Next advances the iterator to the next release. It returns false when there are no more releases or an error
+// occurred; use Err to tell the two apart.
+func (it *LabelDiscographyIterator) Next() bool {
+	for it.err == nil {
+		if it.releases == nil && !it.nextLabel() {
+			return false
+		}
+		if !it.releases.Next() {
+			it.err = it.releases.Err()
+			it.releases = nil
+			continue
+		}
+
+		r := it.releases.Item()
+		if it.listed[r.ID] {
+			continue
+		}
+		it.listed[r.ID] = true
+
+		item := LabelRelease{ReleaseSource: r, LabelID: it.label}
+		if it.o.ByMaster {
+			release, err := it.s.Release(it.ctx, r.ID, it.opts...)
+			if err != nil {
+				it.err = err
+				return false
+			}
+			item.MasterID = release.MasterID
+			if item.MasterID != 0 {
+				if it.masters[item.MasterID] {
+					continue
+				}
+				it.masters[item.MasterID] = true
+			}
+		}
+		it.item = item
+		return true
+	}
+	return false
+}
+
+// nextLabel starts walking the releases of the next label, queueing its sublabels if requested. It returns false when
+// all labels were walked or an error occurred.
+func (it *LabelDiscographyIterator) nextLabel() bool {
+	if len(it.labels) == 0 {
+		return false
+	}
+	it.label = it.labels[len(it.labels)-1]
+	it.labels = it.labels[:len(it.labels)-1]
+
+	if it.o.Sublabels {
+		label, err := it.s.Label(it.ctx, it.label, it.opts...)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		// queue the sublabels in reverse, so they are walked in the order listed
+		for i := len(label.Sublabels) - 1; i >= 0; i-- {
+			if id := label.Sublabels[i].ID; !it.seen[id] {
+				it.seen[id] = true
+				it.labels = append(it.labels, id)
+			}
+		}
+	}
+	it.releases = LabelReleasesIter(it.ctx, it.s, it.label, it.o.Pagination, it.opts...)
+	return true
+}
+
+// Item returns the current release.
+func (it *LabelDiscographyIterator) Item() LabelRelease {
+	return it.item
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *LabelDiscographyIterator) Err() error {
+	return it.err
+}
+
+// LabelDiscography returns the releases of the label as iterated by LabelDiscographyIter. A limit greater than zero
+// caps the number of releases returned. On error the releases collected so far are returned along with the error.
+func LabelDiscography(ctx context.Context, s DatabaseService, labelID int, o LabelDiscographyOptions, limit int, opts ...RequestOption) ([]LabelRelease, error) {
+	var releases []LabelRelease
+	it := LabelDiscographyIter(ctx, s, labelID, o, opts...)
+	for (limit <= 0 || len(releases) < limit) && it.Next() {
+		releases = append(releases, it.Item())
+	}
+	return releases, it.Err()
+}
@@ -0,0 +1,179 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// LabelsServer serves a label hierarchy: label 1 has the sublabels 2 and 3, label 2 has the sublabel 4 and label 4
+// refers back to label 1. Label 5 has the missing sublabel 6.
+func LabelsServer(w http.ResponseWriter, r *http.Request) {
+	sublabels := map[string][]int{
+		"/labels/1": {2, 3},
+		"/labels/2": {4},
+		"/labels/3": {},
+		"/labels/4": {1},
+		"/labels/5": {6},
+	}
+	ids, ok := sublabels[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var subs []string
+	for _, id := range ids {
+		subs = append(subs, fmt.Sprintf(`{"id": %d, "name": "Label %d", "resource_url": "https://api.discogs.com/labels/%d"}`, id, id, id))
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/labels/")
+	if _, err := fmt.Fprintf(w, `{"id": %s, "name": "Label %s", "sublabels": [%s]}`, id, id, strings.Join(subs, ", ")); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func TestSubLabels(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(LabelsServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		labelID   int
+		recursive bool
+		want      []int
+		err       error
+	}{
+		"direct":           {1, false, []int{2, 3}, nil},
+		"recursive":        {1, true, []int{2, 4, 3}, nil},
+		"no sublabels":     {3, true, nil, nil},
+		"missing label":    {7, false, nil, ErrNotFound},
+		"missing sublabel": {5, false, nil, ErrNotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			labels, err := SubLabels(ctx, d, tt.labelID, tt.recursive)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err got=%v; want=%v", err, tt.err)
+			}
+			var got []int
+			for _, l := range labels {
+				got = append(got, l.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("sublabels got=%v; want=%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelParentLabel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.WriteString(w, `{"id": 86537, "name": "Antidote (4)", "parent_label": {"id": 1, "name": "Planet E", "resource_url": "https://api.discogs.com/labels/1"}}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	label, err := d.Label(context.Background(), 86537)
+	if err != nil {
+		t.Fatalf("failed to get label: %s", err)
+	}
+	want := Sublable{ID: 1, Name: "Planet E", ResourceURL: "https://api.discogs.com/labels/1"}
+	if label.ParentLabel == nil || *label.ParentLabel != want {
+		t.Errorf("parent label got=%+v; want=%+v", label.ParentLabel, want)
+	}
+}
+
+// LabelDiscographyServer serves the hierarchy of LabelsServer with releases in pages of two: label 1 lists releases
+// 10 and 11 of master 100 and release 12 without a master, label 2 lists release 13 of master 101 and again release
+// 10, label 4 lists release 14 of master 100 and label 3 lists release 15 of master 102.
+func LabelDiscographyServer(w http.ResponseWriter, r *http.Request) {
+	releases := map[string][]int{
+		"/labels/1/releases": {10, 11, 12},
+		"/labels/2/releases": {13, 10},
+		"/labels/3/releases": {15},
+		"/labels/4/releases": {14},
+	}
+	masters := map[string]int{
+		"/releases/10": 100, "/releases/11": 100, "/releases/12": 0, "/releases/13": 101, "/releases/14": 100, "/releases/15": 102,
+	}
+
+	if master, ok := masters[r.URL.Path]; ok {
+		fmt.Fprintf(w, `{"id": %s, "master_id": %d}`, strings.TrimPrefix(r.URL.Path, "/releases/"), master)
+		return
+	}
+	ids, ok := releases[r.URL.Path]
+	if !ok {
+		LabelsServer(w, r)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	end := page * 2
+	if end > len(ids) {
+		end = len(ids)
+	}
+	var items []string
+	for _, id := range ids[(page-1)*2 : end] {
+		items = append(items, fmt.Sprintf(`{"id": %d, "title": "Release %d"}`, id, id))
+	}
+	fmt.Fprintf(w, `{"pagination": {"page": %d, "pages": %d, "per_page": 2, "items": %d}, "releases": [%s]}`,
+		page, (len(ids)+1)/2, len(ids), strings.Join(items, ", "))
+}
+
+func TestLabelDiscography(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(LabelDiscographyServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		labelID int
+		o       LabelDiscographyOptions
+		limit   int
+		want    []int
+		err     error
+	}{
+		"label":            {1, LabelDiscographyOptions{}, 0, []int{10, 11, 12}, nil},
+		"sublabels":        {1, LabelDiscographyOptions{Sublabels: true}, 0, []int{10, 11, 12, 13, 14, 15}, nil},
+		"by master":        {1, LabelDiscographyOptions{Sublabels: true, ByMaster: true}, 0, []int{10, 12, 13, 15}, nil},
+		"limit":            {1, LabelDiscographyOptions{Sublabels: true}, 4, []int{10, 11, 12, 13}, nil},
+		"missing sublabel": {5, LabelDiscographyOptions{Sublabels: true}, 0, nil, ErrNotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			releases, err := LabelDiscography(ctx, d, tt.labelID, tt.o, tt.limit)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err got=%v; want=%v", err, tt.err)
+			}
+			var got []int
+			for _, r := range releases {
+				got = append(got, r.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("releases got=%v; want=%v", got, tt.want)
+			}
+		})
+	}
+
+	releases, err := LabelDiscography(ctx, d, 1, LabelDiscographyOptions{Sublabels: true, ByMaster: true}, 0)
+	if err != nil {
+		t.Fatalf("failed to get discography: %s", err)
+	}
+	if r := releases[2]; r.LabelID != 2 || r.MasterID != 101 || r.Title != "Release 13" {
+		t.Errorf("release got=%+v; want release 13 of label 2 and master 101", r)
+	}
+}
@@ -0,0 +1,71 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Logged returns d with all functions replaced with versions logging every call with logf, e.g. log.Printf, along
+// with its duration and error:
+//
+//	discogs: Release(8138518) took 212ms
+//	discogs: Artist(38661) failed after 94ms: discogs error: resource not found
+//
+// Clients derived with WithToken or WithOAuth are logged as well.
+func Logged(d Discogs, logf func(format string, args ...interface{})) Discogs {
+	return Intercept(d, func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		start := time.Now()
+		err := invoke(ctx)
+		duration := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			logf("discogs: %s failed after %s: %s", formatCall(call), duration, err)
+		} else {
+			logf("discogs: %s took %s", formatCall(call), duration)
+		}
+		return err
+	})
+}
+
+// payloadArgs maps methods to the index of their first argument holding a request or response body, which aren't
+// logged.
+var payloadArgs = map[string]int{
+	"Do": 3,
+}
+
+// formatCall returns the call as it would be written in Go, e.g. "Release(8138518)". Readers and request and response
+// bodies are replaced with their type, e.g. "<*bytes.Buffer>", to keep their content out of the logs.
+func formatCall(call Call) string {
+	args := make([]string, len(call.Args))
+	for i, arg := range call.Args {
+		if n, ok := payloadArgs[call.Method]; ok && i >= n {
+			args[i] = formatPayload(arg)
+			continue
+		}
+		switch arg := arg.(type) {
+		case string:
+			args[i] = fmt.Sprintf("%q", arg)
+		case *Pagination:
+			if arg == nil {
+				args[i] = "nil"
+			} else {
+				args[i] = fmt.Sprintf("%+v", *arg)
+			}
+		case io.Reader:
+			args[i] = formatPayload(arg)
+		default:
+			args[i] = fmt.Sprintf("%+v", arg)
+		}
+	}
+	return call.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// formatPayload returns a placeholder naming the type of a body, e.g. "<*bytes.Buffer>".
+func formatPayload(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("<%T>", v)
+}
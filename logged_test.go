@@ -0,0 +1,63 @@
+package discogs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func TestLogged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	var lines []string
+	d := Logged(initDiscogsClient(t, &Options{URL: ts.URL}), func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	})
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if _, err := d.ArtistReleases(context.Background(), 38661, &Pagination{Page: 2}); err == nil {
+		t.Fatalf("expected the artist releases to fail")
+	}
+
+	want := []*regexp.Regexp{
+		regexp.MustCompile(`^discogs: Release\(8138518\) took \S+$`),
+		regexp.MustCompile(`^discogs: ArtistReleases\(38661, \{Sort: SortOrder: Page:2 PerPage:0\}\) failed after \S+: unknown error: 405 Method Not Allowed$`),
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("lines got=%q; want %d lines", lines, len(want))
+	}
+	for i, re := range want {
+		if !re.MatchString(lines[i]) {
+			t.Errorf("line got=%q; want=%s", lines[i], re)
+		}
+	}
+}
+
+func TestFormatCall(t *testing.T) {
+	tests := map[string]struct {
+		call Call
+		want string
+	}{
+		"no args":    {call: Call{Method: "InventoryExports"}, want: "InventoryExports()"},
+		"int":        {call: Call{Method: "Release", Args: []interface{}{8138518}}, want: "Release(8138518)"},
+		"string":     {call: Call{Method: "Wantlist", Args: []interface{}{"some user", (*Pagination)(nil)}}, want: `Wantlist("some user", nil)`},
+		"pagination": {call: Call{Method: "LabelReleases", Args: []interface{}{1, &Pagination{Sort: SortYear, Page: 3}}}, want: "LabelReleases(1, {Sort:year SortOrder: Page:3 PerPage:0})"},
+		"reader":     {call: Call{Method: "UploadInventory", Args: []interface{}{UploadAdd, bytes.NewBufferString("release_id,price\n1,9.99\n")}}, want: "UploadInventory(add, <*bytes.Buffer>)"},
+		"bodies":     {call: Call{Method: "Do", Args: []interface{}{"POST", "/some/path", url.Values(nil), map[string]string{"secret": "x"}, nil}}, want: `Do("POST", "/some/path", map[], <map[string]string>, nil)`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := formatCall(tt.call); got != tt.want {
+				t.Errorf("got=%q; want=%q", got, tt.want)
+			}
+		})
+	}
+}
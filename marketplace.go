@@ -4,72 +4,354 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 const (
 	priceSuggestionsURI = "/price_suggestions/"
 	releaseStatsURI     = "/stats/"
+	listingsURI         = "/listings/"
+	ordersURI           = "/orders/"
 )
 
 type marketPlaceService struct {
 	request  requestFunc
 	url      string
+	usersURL string
 	currency string
 }
 
 type MarketPlaceService interface {
 	// The best price suggestions according to grading
 	// Authentication is required.
-	PriceSuggestions(ctx context.Context, releaseID int) (*PriceListing, error)
+	PriceSuggestions(ctx context.Context, releaseID int, opts ...RequestOption) (*PriceListing, error)
 	// Short summary of marketplace listings
 	// Authentication is optional.
-	ReleaseStatistics(ctx context.Context, releaseID int) (*Stats, error)
+	ReleaseStatistics(ctx context.Context, releaseID int, opts ...RequestOption) (*Stats, error)
+	// Inventory returns the listings in a seller's inventory.
+	// Authentication is optional; only the listings for sale are returned to users other than the seller.
+	// https://www.discogs.com/developers#page:marketplace,header:marketplace-inventory
+	Inventory(ctx context.Context, username string, pagination *Pagination, opts ...RequestOption) (*Inventory, error)
+	// Listing returns a marketplace listing.
+	// Authentication is optional.
+	// https://www.discogs.com/developers#page:marketplace,header:marketplace-listing
+	Listing(ctx context.Context, listingID int64, opts ...RequestOption) (*MarketplaceListing, error)
+	// Orders returns the orders of the authenticated seller, only those with the given status unless it is empty.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:marketplace,header:marketplace-list-orders
+	Orders(ctx context.Context, status OrderStatus, pagination *Pagination, opts ...RequestOption) (*Orders, error)
+	// Order returns an order of the authenticated seller.
+	// Authentication as the seller is required.
+	// https://www.discogs.com/developers#page:marketplace,header:marketplace-order
+	Order(ctx context.Context, orderID string, opts ...RequestOption) (*Order, error)
 }
 
-func newMarketPlaceService(req requestFunc, url string, currency string) MarketPlaceService {
+func newMarketPlaceService(req requestFunc, apiURL string, currency string) MarketPlaceService {
 	return &marketPlaceService{
 		request:  req,
-		url:      url,
+		url:      joinURL(apiURL, "marketplace"),
+		usersURL: joinURL(apiURL, "users"),
 		currency: currency,
 	}
 }
 
-// Listing is a marketplace listing with the user's currency and a price value
-type Listing struct {
-	Currency string  `json:"currency"`
-	Value    float64 `json:"value"`
-}
-
-// PriceListings are Listings per grading quality
+// PriceListings are suggested prices per grading quality
 type PriceListing struct {
-	VeryGood     *Listing `json:"Very Good (VG),omitempty"`
-	GoodPlus     *Listing `json:"Good Plus (G+),omitempty"`
-	NearMint     *Listing `json:"Near Mint (NM or M-)"`
-	Good         *Listing `json:"Good (G),omitempty"`
-	VeryGoodPlus *Listing `json:"Very Good Plus (VG+),omitempty"`
-	Mint         *Listing `json:"Mint (M),omitempty"`
-	Fair         *Listing `json:"Fair (F),omitempty"`
-	Poor         *Listing `json:"Poor (P),omitempty"`
+	VeryGood     *Price `json:"Very Good (VG),omitempty"`
+	GoodPlus     *Price `json:"Good Plus (G+),omitempty"`
+	NearMint     *Price `json:"Near Mint (NM or M-)"`
+	Good         *Price `json:"Good (G),omitempty"`
+	VeryGoodPlus *Price `json:"Very Good Plus (VG+),omitempty"`
+	Mint         *Price `json:"Mint (M),omitempty"`
+	Fair         *Price `json:"Fair (F),omitempty"`
+	Poor         *Price `json:"Poor (P),omitempty"`
 }
 
 // Stats returns the marketplace stats summary for a release containing
 type Stats struct {
-	LowestPrice *Listing `json:"lowest_price"`
-	ForSale     int      `json:"num_for_sale"`
-	Blocked     bool     `json:"blocked_from_sale"`
+	LowestPrice *Price `json:"lowest_price"`
+	ForSale     int    `json:"num_for_sale"`
+	Blocked     bool   `json:"blocked_from_sale"`
 }
 
-func (s *marketPlaceService) ReleaseStatistics(ctx context.Context, releaseID int) (*Stats, error) {
+func (s *marketPlaceService) ReleaseStatistics(ctx context.Context, releaseID int, opts ...RequestOption) (*Stats, error) {
+	cur, err := requestCurrency(s.currency, opts)
+	if err != nil {
+		return nil, err
+	}
 	params := url.Values{}
-	params.Set("curr_abbr", s.currency)
+	params.Set("curr_abbr", cur)
 
 	var stats *Stats
-	err := s.request(ctx, s.url+releaseStatsURI+strconv.Itoa(releaseID), params, &stats)
+	err = s.request(ctx, joinURL(s.url, releaseStatsURI, strconv.Itoa(releaseID)), params, &stats, opts...)
 	return stats, err
 }
 
-func (s *marketPlaceService) PriceSuggestions(ctx context.Context, releaseID int) (*PriceListing, error) {
+func (s *marketPlaceService) PriceSuggestions(ctx context.Context, releaseID int, opts ...RequestOption) (*PriceListing, error) {
 	var listings *PriceListing
-	err := s.request(ctx, s.url+priceSuggestionsURI+strconv.Itoa(releaseID), nil, &listings)
+	err := s.request(ctx, joinURL(s.url, priceSuggestionsURI, strconv.Itoa(releaseID)), nil, &listings, opts...)
 	return listings, err
 }
+
+// MarketplaceListing is an item for sale in the marketplace.
+type MarketplaceListing struct {
+	// ID is int64 as listing IDs exceed the range of int on 32-bit platforms.
+	ID              int64     `json:"id"`
+	Status          string    `json:"status"`
+	Price           Price     `json:"price"`
+	AllowOffers     bool      `json:"allow_offers"`
+	Condition       Condition `json:"condition"`
+	SleeveCondition Condition `json:"sleeve_condition"`
+	Comments        string    `json:"comments"`
+	ShipsFrom       string    `json:"ships_from"`
+	// ShippingPrice is the price of shipping the listing to the authenticated user, if the seller set one for their
+	// location.
+	ShippingPrice *Price         `json:"shipping_price,omitempty"`
+	Posted        Time           `json:"posted"`
+	Audio         bool           `json:"audio"`
+	URI           string         `json:"uri"`
+	ResourceURL   string         `json:"resource_url"`
+	Seller        Seller         `json:"seller"`
+	Release       ListingRelease `json:"release"`
+}
+
+// Seller is the seller of a marketplace listing.
+type Seller struct {
+	ID          int         `json:"id"`
+	Username    string      `json:"username"`
+	ResourceURL string      `json:"resource_url"`
+	Shipping    string      `json:"shipping,omitempty"`
+	Payment     string      `json:"payment,omitempty"`
+	Stats       SellerStats `json:"stats"`
+}
+
+// PaymentMethods returns the payment methods the seller accepts, e.g. ["Bank Transfer", "PayPal"].
+func (s Seller) PaymentMethods() []string {
+	var methods []string
+	for _, m := range strings.Split(s.Payment, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// ShippingPolicy is the shipping and payment terms of a listing.
+type ShippingPolicy struct {
+	// ShipsFrom is the country the listing ships from.
+	ShipsFrom string
+	// Terms are the seller's shipping terms as written by them, e.g. "Buyer pays shipping.".
+	Terms string
+	// PaymentMethods are the payment methods the seller accepts.
+	PaymentMethods []string
+	// Price is the price of shipping the listing to the authenticated user, or nil if unknown.
+	Price *Price
+}
+
+// ShippingPolicy returns the shipping and payment terms of the listing.
+func (l *MarketplaceListing) ShippingPolicy() ShippingPolicy {
+	return ShippingPolicy{
+		ShipsFrom:      l.ShipsFrom,
+		Terms:          l.Seller.Shipping,
+		PaymentMethods: l.Seller.PaymentMethods(),
+		Price:          l.ShippingPrice,
+	}
+}
+
+// SellerStats ...
+type SellerStats struct {
+	Rating string  `json:"rating"`
+	Stars  float64 `json:"stars"`
+	Total  int     `json:"total"`
+}
+
+// ListingRelease is the release offered by a marketplace listing.
+type ListingRelease struct {
+	ID            int    `json:"id"`
+	CatalogNumber string `json:"catalog_number"`
+	Artist        string `json:"artist"`
+	Title         string `json:"title"`
+	Format        string `json:"format"`
+	Year          int    `json:"year"`
+	Description   string `json:"description"`
+	Thumbnail     string `json:"thumbnail"`
+	ResourceURL   string `json:"resource_url"`
+}
+
+// Inventory is a list of listings in a seller's inventory.
+type Inventory struct {
+	Pagination Page                 `json:"pagination"`
+	Listings   []MarketplaceListing `json:"listings"`
+}
+
+// valid sort keys
+// https://www.discogs.com/developers#page:marketplace,header:marketplace-inventory
+var validInventorySort = map[SortKey]struct{}{
+	SortListed:   struct{}{},
+	SortPrice:    struct{}{},
+	SortItem:     struct{}{},
+	SortArtist:   struct{}{},
+	SortLabel:    struct{}{},
+	SortCatno:    struct{}{},
+	SortAudio:    struct{}{},
+	SortStatus:   struct{}{},
+	SortLocation: struct{}{},
+}
+
+func (s *marketPlaceService) Inventory(ctx context.Context, username string, pagination *Pagination, opts ...RequestOption) (*Inventory, error) {
+	if username == "" {
+		return nil, ErrInvalidUsername
+	}
+	if err := pagination.validate(validInventorySort); err != nil {
+		return nil, err
+	}
+	var inventory *Inventory
+	err := s.request(ctx, joinURL(s.usersURL, url.PathEscape(username), "inventory"), pagination.params(), &inventory, opts...)
+	return inventory, err
+}
+
+func (s *marketPlaceService) Listing(ctx context.Context, listingID int64, opts ...RequestOption) (*MarketplaceListing, error) {
+	cur, err := requestCurrency(s.currency, opts)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("curr_abbr", cur)
+
+	var listing *MarketplaceListing
+	err = s.request(ctx, joinURL(s.url, listingsURI, strconv.FormatInt(listingID, 10)), params, &listing, opts...)
+	return listing, err
+}
+
+// OrderStatus is the status of a marketplace order.
+type OrderStatus string
+
+// Statuses of orders.
+const (
+	OrderNewOrder              OrderStatus = "New Order"
+	OrderBuyerContacted        OrderStatus = "Buyer Contacted"
+	OrderInvoiceSent           OrderStatus = "Invoice Sent"
+	OrderPaymentPending        OrderStatus = "Payment Pending"
+	OrderPaymentReceived       OrderStatus = "Payment Received"
+	OrderInProgress            OrderStatus = "In Progress"
+	OrderShipped               OrderStatus = "Shipped"
+	OrderRefundSent            OrderStatus = "Refund Sent"
+	OrderCancelledNonPaying    OrderStatus = "Cancelled (Non-Paying Buyer)"
+	OrderCancelledUnavailable  OrderStatus = "Cancelled (Item Unavailable)"
+	OrderCancelledBuyerRequest OrderStatus = "Cancelled (Per Buyer's Request)"
+	OrderMerged                OrderStatus = "Merged"
+	OrderChanged               OrderStatus = "Order Changed"
+)
+
+// Order is a marketplace order of items of a seller's inventory.
+type Order struct {
+	ID                     string        `json:"id"`
+	Status                 OrderStatus   `json:"status"`
+	NextStatus             []OrderStatus `json:"next_status"`
+	Created                Time          `json:"created"`
+	LastActivity           Time          `json:"last_activity"`
+	Archived               bool          `json:"archived"`
+	Buyer                  OrderUser     `json:"buyer"`
+	Seller                 OrderUser     `json:"seller"`
+	Items                  []OrderItem   `json:"items"`
+	Total                  Price         `json:"total"`
+	Fee                    Price         `json:"fee"`
+	Shipping               OrderShipping `json:"shipping"`
+	ShippingAddress        string        `json:"shipping_address"`
+	AdditionalInstructions string        `json:"additional_instructions"`
+	URI                    string        `json:"uri"`
+	ResourceURL            string        `json:"resource_url"`
+	MessagesURL            string        `json:"messages_url"`
+}
+
+// OrderUser is the buyer or seller of an order.
+type OrderUser struct {
+	ID          int    `json:"id"`
+	Username    string `json:"username"`
+	ResourceURL string `json:"resource_url"`
+}
+
+// OrderItem is a listing sold with an order.
+type OrderItem struct {
+	// ID is the ID of the sold listing.
+	ID              int64            `json:"id"`
+	Release         OrderItemRelease `json:"release"`
+	Price           Price            `json:"price"`
+	MediaCondition  Condition        `json:"media_condition"`
+	SleeveCondition Condition        `json:"sleeve_condition"`
+}
+
+// OrderItemRelease is the release of an order item.
+type OrderItemRelease struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+// OrderShipping is the shipping of an order.
+type OrderShipping struct {
+	Method   string  `json:"method"`
+	Value    float64 `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+// Price returns the shipping price of the order.
+func (s OrderShipping) Price() Price {
+	return NewPrice(s.Value, s.Currency)
+}
+
+// Orders is a list of a seller's orders.
+type Orders struct {
+	Pagination Page    `json:"pagination"`
+	Orders     []Order `json:"orders"`
+}
+
+// valid sort keys
+// https://www.discogs.com/developers#page:marketplace,header:marketplace-list-orders
+var validOrdersSort = map[SortKey]struct{}{
+	SortID:           struct{}{},
+	SortBuyer:        struct{}{},
+	SortCreated:      struct{}{},
+	SortStatus:       struct{}{},
+	SortLastActivity: struct{}{},
+}
+
+func (s *marketPlaceService) Orders(ctx context.Context, status OrderStatus, pagination *Pagination, opts ...RequestOption) (*Orders, error) {
+	if err := pagination.validate(validOrdersSort); err != nil {
+		return nil, err
+	}
+	params := pagination.params()
+	if status != "" {
+		params.Set("status", string(status))
+	}
+	var orders *Orders
+	err := s.request(ctx, joinURL(s.url, ordersURI), params, &orders, opts...)
+	return orders, err
+}
+
+func (s *marketPlaceService) Order(ctx context.Context, orderID string, opts ...RequestOption) (*Order, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	var order *Order
+	err := s.request(ctx, joinURL(s.url, ordersURI, url.PathEscape(orderID)), nil, &order, opts...)
+	return order, err
+}
+
+// ReleaseListings returns the listings of the release that are for sale in the inventories of the given sellers. The
+// API offers no way to find all listings of a release, so the sellers' inventories are searched page by page; use
+// ReleaseStatistics for the number of listings and the lowest price across the whole marketplace.
+func ReleaseListings(ctx context.Context, s MarketPlaceService, releaseID int, sellers []string, opts ...RequestOption) ([]MarketplaceListing, error) {
+	var listings []MarketplaceListing
+	for _, seller := range sellers {
+		it := InventoryIter(ctx, s, seller, &Pagination{PerPage: maxPerPage}, opts...)
+		for it.Next() {
+			if l := it.Item(); l.Release.ID == releaseID && l.Status == "For Sale" {
+				listings = append(listings, l)
+			}
+		}
+		if err := it.Err(); err != nil {
+			return listings, err
+		}
+	}
+	return listings, nil
+}
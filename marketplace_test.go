@@ -3,14 +3,20 @@ package discogs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
-const testReleaseID = 9893847
+const (
+	testReleaseID = 9893847
+	testSeller    = "test_seller"
+)
 
 func MarketplaceServer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
@@ -33,6 +39,38 @@ func MarketplaceServer(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+	case "/marketplace" + listingsURI + "172723812":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, listingJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	case "/users/" + testSeller + "/inventory":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, inventoryJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	case "/marketplace/orders":
+		if r.URL.Query().Get("status") != string(OrderNewOrder) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, ordersJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+	case "/marketplace/orders/1-1":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, orderJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
@@ -54,7 +92,8 @@ func TestMarketplacePriceSuggestions(t *testing.T) {
 		t.Fatalf("failed to marshal folder: %s", err)
 	}
 
-	compareJson(t, string(json), priceSuggestionJson)
+	// prices are rounded to cents
+	compareJson(t, string(json), `{"Mint (M)": {"currency": "EUR", "value": 16.63}, "Near Mint (NM or M-)": {"currency": "EUR", "value": 14.88}, "Very Good Plus (VG+)": {"currency": "EUR", "value": 11.38}, "Very Good (VG)": {"currency": "EUR", "value": 7.88}, "Good Plus (G+)": {"currency": "EUR", "value": 4.38}, "Good (G)": {"currency": "EUR", "value": 2.63}, "Fair (F)": {"currency": "EUR", "value": 1.75}, "Poor (P)": {"currency": "EUR", "value": 0.88}}`)
 }
 
 func TestMarketplaceReleaseStatistics(t *testing.T) {
@@ -75,3 +114,141 @@ func TestMarketplaceReleaseStatistics(t *testing.T) {
 
 	compareJson(t, string(json), releaseStatsJson)
 }
+
+func TestMarketplaceListing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(MarketplaceServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	listing, err := d.Listing(context.Background(), 172723812)
+	if err != nil {
+		t.Fatalf("failed to get listing: %s", err)
+	}
+	if listing.Condition != ConditionMint || listing.Price != NewPrice(120, "USD") {
+		t.Errorf("listing got=%+v", listing)
+	}
+
+	shipping := NewPrice(5.5, "USD")
+	want := ShippingPolicy{ShipsFrom: "United States", Terms: "Buyer pays shipping.", PaymentMethods: []string{"PayPal"}, Price: &shipping}
+	if policy := listing.ShippingPolicy(); !cmp.Equal(policy, want) {
+		t.Errorf("shipping policy got=%+v; want=%+v", policy, want)
+	}
+
+	json, err := json.Marshal(listing)
+	if err != nil {
+		t.Fatalf("failed to marshal listing: %s", err)
+	}
+
+	compareJson(t, string(json), listingJson)
+}
+
+func TestMarketplaceInventory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(MarketplaceServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	inventory, err := d.Inventory(context.Background(), testSeller, nil)
+	if err != nil {
+		t.Fatalf("failed to get inventory: %s", err)
+	}
+
+	json, err := json.Marshal(inventory)
+	if err != nil {
+		t.Fatalf("failed to marshal inventory: %s", err)
+	}
+
+	compareJson(t, string(json), inventoryJson)
+
+	if _, err := d.Inventory(context.Background(), "", nil); err != ErrInvalidUsername {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUsername)
+	}
+	if _, err := d.Inventory(context.Background(), testSeller, &Pagination{Sort: "invalid"}); !errors.Is(err, ErrInvalidSortKey) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidSortKey)
+	}
+}
+
+func TestReleaseListings(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(MarketplaceServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	listings, err := ReleaseListings(context.Background(), d, 5610049, []string{testSeller})
+	if err != nil {
+		t.Fatalf("failed to get release listings: %s", err)
+	}
+	if len(listings) != 1 || listings[0].ID != 172723812 || listings[0].ShipsFrom != "United States" {
+		t.Errorf("listings got=%+v", listings)
+	}
+
+	listings, err = ReleaseListings(context.Background(), d, testReleaseID, []string{testSeller})
+	if err != nil || len(listings) != 0 {
+		t.Errorf("listings got=%+v, %v; want=[]", listings, err)
+	}
+
+	if _, err := ReleaseListings(context.Background(), d, 5610049, []string{"unknown"}); err == nil {
+		t.Error("expected error for unknown seller")
+	}
+}
+
+func TestMarketplaceOrders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(MarketplaceServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	orders, err := d.Orders(ctx, OrderNewOrder, &Pagination{Sort: SortLastActivity, SortOrder: SortDesc})
+	if err != nil {
+		t.Fatalf("failed to get orders: %s", err)
+	}
+	if len(orders.Orders) != 1 {
+		t.Fatalf("orders got=%d; want=1", len(orders.Orders))
+	}
+	o := orders.Orders[0]
+	if o.ID != "1-1" || o.Status != OrderNewOrder || o.Buyer.Username != "example_buyer" || len(o.Items) != 1 {
+		t.Errorf("order got=%+v", o)
+	}
+	if want := NewPrice(42, "USD"); o.Total != want || o.Items[0].Price != want {
+		t.Errorf("total got=%s, item price got=%s; want=%s", o.Total, o.Items[0].Price, want)
+	}
+	if want := NewPrice(0, "USD"); o.Shipping.Price() != want {
+		t.Errorf("shipping got=%s; want=%s", o.Shipping.Price(), want)
+	}
+
+	order, err := d.Order(ctx, "1-1")
+	if err != nil {
+		t.Fatalf("failed to get order: %s", err)
+	}
+	if order.ID != "1-1" || order.Items[0].Release.ID != 1 || order.Items[0].MediaCondition != ConditionMint {
+		t.Errorf("order got=%+v", order)
+	}
+
+	if _, err := d.Order(ctx, ""); err != ErrInvalidOrderID {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidOrderID)
+	}
+	if _, err := d.Orders(ctx, "", &Pagination{Sort: SortYear}); !errors.Is(err, ErrInvalidSortKey) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidSortKey)
+	}
+}
+
+func TestSellerPaymentMethods(t *testing.T) {
+	tests := map[string]struct {
+		payment string
+		want    []string
+	}{
+		"none":     {payment: "", want: nil},
+		"single":   {payment: "PayPal", want: []string{"PayPal"}},
+		"multiple": {payment: "Bank Transfer, PayPal,  Credit Card ,", want: []string{"Bank Transfer", "PayPal", "Credit Card"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := (Seller{Payment: tt.payment}).PaymentMethods(); !cmp.Equal(got, tt.want) {
+				t.Errorf("got=%q; want=%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package discogs
+
+import (
+	"context"
+)
+
+// MasterMainRelease returns the main release of the master release, which is often the chronologically earliest.
+func MasterMainRelease(ctx context.Context, s DatabaseService, masterID int, opts ...RequestOption) (*Release, error) {
+	master, err := s.Master(ctx, masterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return linkedRelease(ctx, s, master.MainRelease, master.MainReleaseURL, opts)
+}
+
+// MasterMostRecentRelease returns the most recent release of the master release.
+func MasterMostRecentRelease(ctx context.Context, s DatabaseService, masterID int, opts ...RequestOption) (*Release, error) {
+	master, err := s.Master(ctx, masterID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return linkedRelease(ctx, s, master.MostRecentRelease, master.MostRecentReleaseURL, opts)
+}
+
+// linkedRelease fetches the release a master release links to, returning ErrNotFound if it links to none.
+func linkedRelease(ctx context.Context, s DatabaseService, id int, resourceURL string, opts []RequestOption) (*Release, error) {
+	id = linkedID(id, resourceURL)
+	if id == 0 {
+		return nil, ErrNotFound
+	}
+	return s.Release(ctx, id, opts...)
+}
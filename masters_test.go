@@ -0,0 +1,61 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// mastersDatabase serves the test master as master 718441, the test master without its linked releases as master
+// 1 and a release with just its ID for any release.
+type mastersDatabase struct {
+	DatabaseService
+	releases []int
+}
+
+func (d *mastersDatabase) Master(ctx context.Context, masterID int, opts ...RequestOption) (*Master, error) {
+	var master *Master
+	if err := json.Unmarshal([]byte(masterJson), &master); err != nil {
+		return nil, err
+	}
+	switch masterID {
+	case 718441:
+		// only the resource URL links the most recent release
+		master.MostRecentRelease = 0
+	case 1:
+		master.MainRelease, master.MainReleaseURL = 0, ""
+		master.MostRecentRelease, master.MostRecentReleaseURL = 0, ""
+	default:
+		return nil, ErrNotFound
+	}
+	return master, nil
+}
+
+func (d *mastersDatabase) Release(ctx context.Context, releaseID int, opts ...RequestOption) (*Release, error) {
+	d.releases = append(d.releases, releaseID)
+	return &Release{ID: releaseID}, nil
+}
+
+func TestMasterReleases(t *testing.T) {
+	db := &mastersDatabase{}
+	ctx := context.Background()
+
+	main, err := MasterMainRelease(ctx, db, 718441)
+	if err != nil || main.ID != 3221262 {
+		t.Errorf("main release got=%v, %v; want=3221262", main, err)
+	}
+	recent, err := MasterMostRecentRelease(ctx, db, 718441)
+	if err != nil || recent.ID != 10670860 {
+		t.Errorf("most recent release got=%v, %v; want=10670860", recent, err)
+	}
+
+	if _, err := MasterMainRelease(ctx, db, 1); err != ErrNotFound {
+		t.Errorf("err got=%v; want=%s", err, ErrNotFound)
+	}
+	if _, err := MasterMostRecentRelease(ctx, db, 2); err != ErrNotFound {
+		t.Errorf("err got=%v; want=%s", err, ErrNotFound)
+	}
+	if len(db.releases) != 2 {
+		t.Errorf("release requests got=%v; want=[3221262 10670860]", db.releases)
+	}
+}
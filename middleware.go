@@ -0,0 +1,20 @@
+package discogs
+
+import (
+	"net/http"
+)
+
+// RoundTripFunc sends an HTTP request to the Discogs API and returns its response.
+type RoundTripFunc func(r *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every API request, e.g. for logging, metrics or to modify the request. It may
+// inspect or modify the request before calling next and inspect the response or error returned by next.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chain returns rt wrapped with the middleware such that the first middleware is the outermost one.
+func chain(rt RoundTripFunc, middleware []Middleware) RoundTripFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		rt = middleware[i](rt)
+	}
+	return rt
+}
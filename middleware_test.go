@@ -0,0 +1,57 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Discogs token=rotated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(r)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+	rotate := func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			r.Header.Set("Authorization", "Discogs token=rotated")
+			return next(r)
+		}
+	}
+
+	d := initDiscogsClient(t, &Options{
+		URL:        ts.URL,
+		Token:      "expired",
+		Middleware: []Middleware{trace("outer"), trace("inner"), rotate},
+	})
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+
+	want := []string{"outer before", "inner before", "inner after", "outer after"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls got=%v; want=%v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls got=%v; want=%v", calls, want)
+		}
+	}
+}
@@ -1,19 +1,59 @@
 package discogs
 
 import (
+	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
-// Video ...
+// Video is a video of a release or master, usually on YouTube.
 type Video struct {
 	Description string `json:"description"`
-	Duration    int    `json:"duration"`
-	Embed       bool   `json:"embed"`
+	Duration    int    `json:"duration"` // in seconds
+	Embed       bool   `json:"embed"`    // whether the video may be embedded
 	Title       string `json:"title"`
 	URI         string `json:"uri"`
 }
 
+// Length returns the duration of the video.
+func (v Video) Length() time.Duration {
+	return time.Duration(v.Duration) * time.Second
+}
+
+// YouTubeID returns the ID of the video if it is on YouTube, such as "i4_kwCTrTRs" for
+// "https://www.youtube.com/watch?v=i4_kwCTrTRs", or an empty string otherwise.
+func (v Video) YouTubeID() string {
+	u, err := url.Parse(v.URI)
+	if err != nil {
+		return ""
+	}
+	switch strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.") {
+	case "youtube.com", "m.youtube.com":
+		if id := u.Query().Get("v"); id != "" {
+			return id
+		}
+		if strings.HasPrefix(u.Path, "/embed/") {
+			return strings.TrimPrefix(u.Path, "/embed/")
+		}
+	case "youtu.be":
+		return strings.TrimPrefix(u.Path, "/")
+	}
+	return ""
+}
+
+// EmbedURL returns the URL to embed the video with, e.g. in an iframe, or an empty string if it may not be embedded
+// or isn't on YouTube.
+func (v Video) EmbedURL() string {
+	id := v.YouTubeID()
+	if !v.Embed || id == "" {
+		return ""
+	}
+	return "https://www.youtube.com/embed/" + url.PathEscape(id)
+}
+
 // Series ...
 type Series struct {
 	Catno          string `json:"catno"`
@@ -36,14 +76,69 @@ type ArtistSource struct {
 	Tracks      string `json:"tracks"`
 }
 
-// Image ...
+// DisplayName returns the name the artist is credited with: the name variation if any, otherwise the name without
+// the number telling apart artists of the same name, e.g. "Mike Smith" for "Mike Smith (2)".
+func (a ArtistSource) DisplayName() string {
+	if a.Anv != "" {
+		return a.Anv
+	}
+	return StripNameNumber(a.Name)
+}
+
+// ArtistCredit renders the artists as credited on a release, honoring name variations and join strings, e.g.
+// "A feat. B, C & D". The join string of the last artist is ignored.
+func ArtistCredit(artists []ArtistSource) string {
+	return joinArtists(artists, ArtistSource.DisplayName)
+}
+
+// joinArtists joins the names of the artists returned by name with their join strings.
+func joinArtists(artists []ArtistSource, name func(ArtistSource) string) string {
+	var b strings.Builder
+	for i, a := range artists {
+		b.WriteString(name(a))
+		if i == len(artists)-1 {
+			break
+		}
+		switch join := strings.TrimSpace(a.Join); {
+		case join == "" || join == ",":
+			b.WriteString(", ")
+		case strings.HasPrefix(join, ","):
+			b.WriteString(join + " ")
+		default:
+			b.WriteString(" " + join + " ")
+		}
+	}
+	return b.String()
+}
+
+// Image is an image of a release, master, artist or label. Download it with ImagesService.
 type Image struct {
 	Height      int    `json:"height"`
 	Width       int    `json:"width"`
 	ResourceURL string `json:"resource_url"`
-	Type        string `json:"type"`
+	Type        string `json:"type"` // ImagePrimary or ImageSecondary
 	URI         string `json:"uri"`
-	URI150      string `json:"uri150"`
+	URI150      string `json:"uri150"` // a 150x150 thumbnail
+}
+
+// Types of images as reported in Image.Type.
+const (
+	ImagePrimary   = "primary"
+	ImageSecondary = "secondary"
+)
+
+// PrimaryImage returns the primary image of images, such as a release's cover, or the first image if none is marked
+// primary. It returns false if there are no images.
+func PrimaryImage(images []Image) (Image, bool) {
+	for _, img := range images {
+		if img.Type == ImagePrimary {
+			return img, true
+		}
+	}
+	if len(images) == 0 {
+		return Image{}, false
+	}
+	return images[0], true
 }
 
 // Track ...
@@ -54,6 +149,7 @@ type Track struct {
 	Type         string         `json:"type_"`
 	Extraartists []ArtistSource `json:"extraartists,omitempty"`
 	Artists      []ArtistSource `json:"artists,omitempty"`
+	SubTracks    []Track        `json:"sub_tracks,omitempty"`
 }
 
 // LabelSource ...
@@ -75,10 +171,10 @@ type Identifier struct {
 
 // Format ...
 type Format struct {
-	Descriptions []string `json:"descriptions"`
-	Name         string   `json:"name"`
-	Qty          string   `json:"qty"`
-	Text         string   `json:"text,omitempty"`
+	Descriptions []string   `json:"descriptions"`
+	Name         FormatName `json:"name"`
+	Qty          string     `json:"qty"`
+	Text         string     `json:"text,omitempty"`
 }
 
 // Company ...
@@ -91,15 +187,15 @@ type Company struct {
 	ResourceURL    string `json:"resource_url"`
 }
 
-// Community ...
+// Community holds the community data of a release.
 type Community struct {
-	Contributors []Contributor `json:"contributors"`
+	Contributors []Contributor `json:"contributors"` // Users who contributed to the release's data.
 	DataQuality  string        `json:"data_quality"`
-	Have         int           `json:"have"`
-	Rating       Rating        `json:"rating"`
-	Status       string        `json:"status"`
-	Submitter    Submitter     `json:"submitter"`
-	Want         int           `json:"want"`
+	Have         int           `json:"have"`      // Number of users who have the release in their collection.
+	Rating       Rating        `json:"rating"`    // Average rating and number of ratings.
+	Status       ReleaseStatus `json:"status"`    // Status of the release's submission, e.g. "Accepted".
+	Submitter    Submitter     `json:"submitter"` // User who submitted the release.
+	Want         int           `json:"want"`      // Number of users who have the release in their wantlist.
 }
 
 // Submitter ...
@@ -135,56 +231,130 @@ type URLsList struct {
 	Next string `json:"next,omitempty"`
 }
 
+// HasNext reports whether there is a page following this one.
+func (p Page) HasNext() bool {
+	return p.URLs.Next != "" || (p.Page > 0 && p.Page < p.Pages)
+}
+
+// Next returns the pagination requesting the page following this one, keeping the sort order and page size, or nil
+// if this is the last page.
+func (p Page) Next() *Pagination {
+	if !p.HasNext() {
+		return nil
+	}
+	if next, err := ParsePageURL(p.URLs.Next); err == nil {
+		return next
+	}
+	return &Pagination{Page: p.Page + 1, PerPage: p.PerPage}
+}
+
+// TotalItems returns the number of items on all pages.
+func (p Page) TotalItems() int {
+	return p.Items
+}
+
+// ParsePageURL returns the pagination requested by a page URL such as Page.URLs.Next.
+func ParsePageURL(u string) (*Pagination, error) {
+	parsed, err := url.Parse(u)
+	if err != nil || u == "" {
+		return nil, ErrInvalidURL
+	}
+	q := parsed.Query()
+	p := &Pagination{
+		Sort:      SortKey(q.Get("sort")),
+		SortOrder: SortOrder(q.Get("sort_order")),
+	}
+	if v := q.Get("page"); v != "" {
+		if p.Page, err = strconv.Atoi(v); err != nil {
+			return nil, ErrInvalidURL
+		}
+	}
+	if v := q.Get("per_page"); v != "" {
+		if p.PerPage, err = strconv.Atoi(v); err != nil {
+			return nil, ErrInvalidURL
+		}
+	}
+	return p, nil
+}
+
 // Version ...
 type Version struct {
-	Catno       string `json:"catno"`
-	Country     string `json:"country"`
-	Format      string `json:"format"`
-	ID          int    `json:"id"`
-	Label       string `json:"label"`
-	Released    string `json:"released"`
-	ResourceURL string `json:"resource_url"`
-	Status      string `json:"status"`
-	Thumb       string `json:"thumb"`
-	Title       string `json:"title"`
+	Catno       string        `json:"catno"`
+	Country     string        `json:"country"`
+	Format      string        `json:"format"`
+	ID          int           `json:"id"`
+	Label       string        `json:"label"`
+	Released    string        `json:"released"`
+	ResourceURL string        `json:"resource_url"`
+	Status      ReleaseStatus `json:"status"`
+	Thumb       string        `json:"thumb"`
+	Title       string        `json:"title"`
 }
 
 // Member ...
 type Member struct {
-	Active      bool   `json:"active"`
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	ResourceURL string `json:"resource_url"`
+	Active       bool   `json:"active"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	ResourceURL  string `json:"resource_url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
 }
 
 // Alias ...
 type Alias struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	ResourceURL string `json:"resource_url"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	ResourceURL  string `json:"resource_url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
 }
 
 // Sublable ...
 type Sublable struct {
-	ResourceURL string `json:"url"`
+	ResourceURL string `json:"resource_url"`
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 }
 
 // ReleaseSource ...
 type ReleaseSource struct {
-	Artist      string `json:"artist"`
-	Catno       string `json:"catno"`
-	Format      string `json:"format"`
-	ID          int    `json:"id"`
-	ResourceURL string `json:"resource_url"`
-	Status      string `json:"status"`
-	Thumb       string `json:"thumb"`
-	Title       string `json:"title"`
-	Year        int    `json:"year"`
-	MainRelease int    `json:"main_release"`
-	Role        string `json:"role"`
-	Type        string `json:"type"`
+	Artist      string        `json:"artist"`
+	Catno       string        `json:"catno"`
+	Format      string        `json:"format"`
+	ID          int           `json:"id"`
+	ResourceURL string        `json:"resource_url"`
+	Status      ReleaseStatus `json:"status"`
+	Thumb       string        `json:"thumb"`
+	Title       string        `json:"title"`
+	Year        int           `json:"year"`
+	MainRelease int           `json:"main_release"`
+	Role        string        `json:"role"`
+	Type        string        `json:"type"`
+}
+
+// Roles of an artist's releases as reported in ReleaseSource.Role.
+const (
+	RoleMain              = "Main"
+	RoleAppearance        = "Appearance"
+	RoleTrackAppearance   = "TrackAppearance"
+	RoleUnofficialRelease = "UnofficialRelease"
+)
+
+// Types of an artist's or label's releases as reported in ReleaseSource.Type.
+const (
+	ReleaseSourceTypeRelease = "release"
+	ReleaseSourceTypeMaster  = "master"
+)
+
+// ReleasesByRole returns the releases having the given role, e.g. RoleMain to separate an artist's own releases from
+// appearances and track credits.
+func ReleasesByRole(releases []ReleaseSource, role string) []ReleaseSource {
+	var filtered []ReleaseSource
+	for _, r := range releases {
+		if r.Role == role {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
 }
 
 // Notes ...
@@ -193,26 +363,149 @@ type Notes struct {
 	Value   string `json:"value"`
 }
 
-// Pagination ...
+// Pagination selects a page of a paginated endpoint and its sort order. Every endpoint validates the sort key
+// against the keys it supports, returning ErrInvalidSortKey for others, and fills in the defaults of the fields left
+// unset: the first page, 50 items per page and, if Sort is set, ascending order. A negative Page fails with
+// ErrInvalidPagination and a negative PerPage requests a single item. A PerPage above the maximum of 100 is limited to
+// it when the request is sent, with a warning through Options.Warnf. A nil Pagination requests the defaults.
 type Pagination struct {
-	// TODO(irlndts): validate requested Sort
-	Sort string // year, title, format etc
-	// TODO(irlndts): validate requested SortOrder
-	SortOrder string // asc, desc
+	Sort      SortKey   // e.g. SortYear, SortTitle, SortFormat
+	SortOrder SortOrder // SortAsc or SortDesc
 	Page      int
 	PerPage   int
 }
 
-// toParams converts pagaination params to request values
-func (p *Pagination) params() url.Values {
+// SortKey is the key to sort the items of a paginated endpoint by.
+type SortKey string
+
+// Sort keys of the paginated endpoints.
+const (
+	SortAdded        SortKey = "added"
+	SortArtist       SortKey = "artist"
+	SortAudio        SortKey = "audio"
+	SortBuyer        SortKey = "buyer"
+	SortCatno        SortKey = "catno"
+	SortCountry      SortKey = "country"
+	SortCreated      SortKey = "created"
+	SortFormat       SortKey = "format"
+	SortID           SortKey = "id"
+	SortItem         SortKey = "item"
+	SortLabel        SortKey = "label"
+	SortLastActivity SortKey = "last_activity"
+	SortListed       SortKey = "listed"
+	SortLocation     SortKey = "location"
+	SortPrice        SortKey = "price"
+	SortRating       SortKey = "rating"
+	SortReleased     SortKey = "released"
+	SortStatus       SortKey = "status"
+	SortTitle        SortKey = "title"
+	SortYear         SortKey = "year"
+)
+
+// SortOrder is the direction to sort the items of a paginated endpoint in.
+type SortOrder string
+
+// Sort orders of the paginated endpoints.
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// NextPage returns a copy of p requesting the following page, the first page following a zero Page.
+func (p *Pagination) NextPage() *Pagination {
+	page := 1
+	if p != nil && p.Page > 0 {
+		page = p.Page + 1
+	}
+	return p.withPage(page)
+}
+
+// validate checks the pagination of an endpoint that supports the sort keys sorts.
+func (p *Pagination) validate(sorts map[SortKey]struct{}) error {
 	if p == nil {
 		return nil
 	}
 
-	params := url.Values{}
-	params.Set("sort", p.Sort)
-	params.Set("sort_order", p.SortOrder)
-	params.Set("page", strconv.Itoa(p.Page))
-	params.Set("per_page", strconv.Itoa(p.PerPage))
+	if p.Sort != "" {
+		if _, ok := sorts[p.Sort]; !ok {
+			if len(sorts) == 0 {
+				return fmt.Errorf("%w: %q, the endpoint doesn't support sorting", ErrInvalidSortKey, p.Sort)
+			}
+			keys := make([]string, 0, len(sorts))
+			for key := range sorts {
+				keys = append(keys, string(key))
+			}
+			sort.Strings(keys)
+			return fmt.Errorf("%w: %q, expected one of %s", ErrInvalidSortKey, p.Sort, strings.Join(keys, ", "))
+		}
+	}
+
+	switch p.SortOrder {
+	case "", SortAsc, SortDesc:
+	default:
+		return fmt.Errorf("%w: %q, expected %s or %s", ErrInvalidSortOrder, p.SortOrder, SortAsc, SortDesc)
+	}
+
+	if p.Page < 0 {
+		return fmt.Errorf("%w: page %d", ErrInvalidPagination, p.Page)
+	}
+	return nil
+}
+
+// normalized returns a copy of p with the defaults filled in.
+func (p *Pagination) normalized() Pagination {
+	var n Pagination
+	if p != nil {
+		n = *p
+	}
+	n.Page = normalizePage(n.Page)
+	n.PerPage = normalizePerPage(n.PerPage)
+	if n.Sort != "" && n.SortOrder == "" {
+		n.SortOrder = SortAsc
+	}
+	return n
+}
+
+// params converts the normalized pagination to request values. Unset sort fields are left to the endpoint's
+// default.
+func (p *Pagination) params() url.Values {
+	n := p.normalized()
+
+	params := make(url.Values, 4)
+	if n.Sort != "" {
+		params.Set("sort", string(n.Sort))
+	}
+	if n.SortOrder != "" {
+		params.Set("sort_order", string(n.SortOrder))
+	}
+	params.Set("page", strconv.Itoa(n.Page))
+	params.Set("per_page", strconv.Itoa(n.PerPage))
 	return params
 }
+
+const (
+	// defaultPerPage is the number of items per page requested unless another is.
+	defaultPerPage = 50
+	// maxPerPage is the largest number of items per page accepted by paginated endpoints.
+	maxPerPage = 100
+)
+
+// normalizePage returns the page requested for page, the first one if it's unset.
+func normalizePage(page int) int {
+	if page < 1 {
+		return 1
+	}
+	return page
+}
+
+// normalizePerPage returns the number of items per page requested for perPage, defaultPerPage if it's unset. Requests
+// for more than maxPerPage are limited by the transport, which warns about them.
+func normalizePerPage(perPage int) int {
+	switch {
+	case perPage == 0:
+		return defaultPerPage
+	case perPage < 1:
+		return 1
+	}
+	return perPage
+}
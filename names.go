@@ -0,0 +1,49 @@
+package discogs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// nameNumber matches the number Discogs appends to the names of artists and labels sharing a name, e.g. " (2)".
+var nameNumber = regexp.MustCompile(`\s+\((\d+)\)$`)
+
+// SplitNameNumber splits the name of an artist or label into the name and the number Discogs appends to tell apart
+// artists and labels of the same name, e.g. "Mike Smith" and 2 for "Mike Smith (2)". The number is zero if the name
+// has none.
+func SplitNameNumber(name string) (string, int) {
+	name = strings.TrimSpace(name)
+	m := nameNumber.FindStringSubmatchIndex(name)
+	if m == nil {
+		return name, 0
+	}
+	n, err := strconv.Atoi(name[m[2]:m[3]])
+	if err != nil {
+		return name, 0
+	}
+	return name[:m[0]], n
+}
+
+// StripNameNumber returns the name of an artist or label without the number Discogs appends to tell apart artists
+// and labels of the same name, e.g. "Mike Smith" for "Mike Smith (2)".
+func StripNameNumber(name string) string {
+	name, _ = SplitNameNumber(name)
+	return name
+}
+
+// NamesMatch reports whether two artist or label names refer to the same name, e.g. to match Discogs artists against
+// other metadata sources. The numbers Discogs appends, case, surrounding and repeated whitespace and a leading "The"
+// are ignored, so "The Beatles (2)" matches "beatles".
+func NamesMatch(a, b string) bool {
+	return normalizeName(a) == normalizeName(b)
+}
+
+// normalizeName returns the name as compared by NamesMatch.
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.Join(strings.Fields(StripNameNumber(name)), " "))
+	if rest := strings.TrimPrefix(name, "the "); rest != "" {
+		name = rest
+	}
+	return name
+}
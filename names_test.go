@@ -0,0 +1,48 @@
+package discogs
+
+import (
+	"testing"
+)
+
+func TestSplitNameNumber(t *testing.T) {
+	tests := map[string]struct {
+		name   string
+		number int
+	}{
+		"Mike Smith (2)":   {"Mike Smith", 2},
+		"Mike Smith  (12)": {"Mike Smith", 12},
+		"Mike Smith":       {"Mike Smith", 0},
+		"(2)":              {"(2)", 0},
+		"Front 242":        {"Front 242", 0},
+		"Live (Remix)":     {"Live (Remix)", 0},
+		" Warp (3) ":       {"Warp", 3},
+	}
+	for in, want := range tests {
+		name, number := SplitNameNumber(in)
+		if name != want.name || number != want.number {
+			t.Errorf("%q: got=%q, %d; want=%q, %d", in, name, number, want.name, want.number)
+		}
+		if got := StripNameNumber(in); got != want.name {
+			t.Errorf("%q: stripped got=%q; want=%q", in, got, want.name)
+		}
+	}
+}
+
+func TestNamesMatch(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Mike Smith (2)", "Mike Smith", true},
+		{"Mike Smith (2)", "Mike Smith (3)", true},
+		{"The Beatles", "beatles", true},
+		{"The  Beatles (2)", " the beatles", true},
+		{"The The", "the the", true},
+		{"Beatles", "Beatless", false},
+	}
+	for _, tt := range tests {
+		if got := NamesMatch(tt.a, tt.b); got != tt.want {
+			t.Errorf("%q, %q: got=%t; want=%t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package discogs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuthCredentials are the OAuth 1.0a credentials of a user who authorized an application, as obtained through the
+// Discogs OAuth flow.
+type OAuthCredentials struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	Token          string
+	TokenSecret    string
+}
+
+// authorization returns the Authorization header of a request signed with the PLAINTEXT method, which Discogs
+// accepts for requests made over HTTPS.
+func (c *OAuthCredentials) authorization() string {
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+
+	return `OAuth oauth_consumer_key="` + percentEncode(c.ConsumerKey) +
+		`", oauth_nonce="` + hex.EncodeToString(nonce) +
+		`", oauth_signature="` + percentEncode(percentEncode(c.ConsumerSecret)+"&"+percentEncode(c.TokenSecret)) +
+		`", oauth_signature_method="PLAINTEXT", oauth_timestamp="` + strconv.FormatInt(time.Now().Unix(), 10) +
+		`", oauth_token="` + percentEncode(c.Token) + `", oauth_version="1.0"`
+}
+
+// percentEncode encodes s as required by OAuth, escaping all but the unreserved characters of RFC 3986.
+func percentEncode(s string) string {
+	const upperhex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&15])
+	}
+	return b.String()
+}
@@ -0,0 +1,364 @@
+package discogs
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestOption configures a single API request, overriding the client's defaults.
+type RequestOption interface {
+	applyRequest(o *requestOptions)
+}
+
+type requestOptionFunc func(o *requestOptions)
+
+func (f requestOptionFunc) applyRequest(o *requestOptions) {
+	f(o)
+}
+
+// requestOptions holds the configuration of a single API request.
+type requestOptions struct {
+	currency string
+	header   http.Header
+	timeout  time.Duration
+	raw      io.Writer
+	strict   bool
+	dryRun   func(r *http.Request)
+	bulk     bool
+	progress ProgressFunc
+	// anonymous drops the client's Authorization header, e.g. for hosts other than Discogs'
+	anonymous bool
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{}
+	for _, opt := range opts {
+		opt.applyRequest(o)
+	}
+	return o
+}
+
+// WithCurrency sets the currency to use for marketplace data, e.g. "EUR". Passed to NewClient it sets the default
+// currency of the client.
+func WithCurrency(currency string) SharedOption {
+	return sharedOption{
+		client:  func(o *Options) { o.Currency = currency },
+		request: func(o *requestOptions) { o.currency = currency },
+	}
+}
+
+// WithHeader sets a header of the request, replacing any value set by the client.
+func WithHeader(key, value string) RequestOption {
+	return requestOptionFunc(func(o *requestOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Set(key, value)
+	})
+}
+
+// WithTimeout limits the time the request may take. Passed to NewClient it limits the time every request of the
+// client may take.
+func WithTimeout(timeout time.Duration) SharedOption {
+	return sharedOption{
+		client:  func(o *Options) { o.RequestTimeout = timeout },
+		request: func(o *requestOptions) { o.timeout = timeout },
+	}
+}
+
+// WithRawCapture writes the raw JSON body of the response to w, e.g. a *bytes.Buffer, in addition to decoding it. This
+// allows persisting exact API payloads or decoding fields the structs don't cover. Responses served by Cached without
+// a request aren't captured.
+func WithRawCapture(w io.Writer) RequestOption {
+	return requestOptionFunc(func(o *requestOptions) {
+		o.raw = w
+	})
+}
+
+// WithDryRun passes the request to fn instead of sending it and fails the call with ErrDryRun, e.g. to inspect the
+// URL, headers and body built for a call or to sign the request externally. Calls served without a request, e.g. from
+// a Cached client's cache, don't call fn. See BuildRequest.
+func WithDryRun(fn func(r *http.Request)) RequestOption {
+	return requestOptionFunc(func(o *requestOptions) {
+		o.dryRun = fn
+	})
+}
+
+// WithProgress makes the iterators, and the All, Export and discogssync functions built on them, call f after every
+// page they fetch with the pages and items fetched so far and the totals reported by the first page. Requests for a
+// single page ignore it.
+func WithProgress(f ProgressFunc) RequestOption {
+	return requestOptionFunc(func(o *requestOptions) {
+		o.progress = f
+	})
+}
+
+// withoutCredentials makes the request without the client's token, consumer key or OAuth signature.
+func withoutCredentials() RequestOption {
+	return requestOptionFunc(func(o *requestOptions) {
+		o.anonymous = true
+	})
+}
+
+// WithStrictDecoding fails the request with ErrUnknownField if the response contains fields the structs don't map.
+// Passed to NewClient it applies to every request of the client.
+func WithStrictDecoding() SharedOption {
+	return sharedOption{
+		client:  func(o *Options) { o.StrictDecoding = true },
+		request: func(o *requestOptions) { o.strict = true },
+	}
+}
+
+// WithBulk requests the maximum of 100 items per page from paginated endpoints, whatever Pagination.PerPage requests,
+// to make the fewest requests when fetching every page. Passed to NewClient it applies to every request of the client.
+func WithBulk() SharedOption {
+	return sharedOption{
+		client:  func(o *Options) { o.Bulk = true },
+		request: func(o *requestOptions) { o.bulk = true },
+	}
+}
+
+// requestCurrency returns the currency requested by opts, or def if none was.
+func requestCurrency(def string, opts []RequestOption) (string, error) {
+	if c := newRequestOptions(opts).currency; c != "" {
+		return currency(c)
+	}
+	return def, nil
+}
+
+// Option configures a client created with NewClient.
+type Option interface {
+	applyClient(o *Options)
+}
+
+type optionFunc func(o *Options)
+
+func (f optionFunc) applyClient(o *Options) {
+	f(o)
+}
+
+// SharedOption is an option that configures either a single request or, passed to NewClient, all requests of a
+// client.
+type SharedOption interface {
+	Option
+	RequestOption
+}
+
+type sharedOption struct {
+	client  optionFunc
+	request requestOptionFunc
+}
+
+func (o sharedOption) applyClient(opts *Options) {
+	o.client(opts)
+}
+
+func (o sharedOption) applyRequest(opts *requestOptions) {
+	o.request(opts)
+}
+
+// NewClient returns a new discogs API client configured by opts. WithUserAgent is required.
+func NewClient(opts ...Option) (Discogs, error) {
+	o := &Options{}
+	for _, opt := range opts {
+		opt.applyClient(o)
+	}
+	return New(o)
+}
+
+// WithUserAgent sets the user-agent to call the Discogs API with.
+func WithUserAgent(userAgent string) Option {
+	return optionFunc(func(o *Options) {
+		o.UserAgent = userAgent
+	})
+}
+
+// WithStrictUserAgent makes NewClient fail with ErrInvalidUserAgent for user-agents CheckUserAgent reports.
+func WithStrictUserAgent() Option {
+	return optionFunc(func(o *Options) {
+		o.StrictUserAgent = true
+	})
+}
+
+// WithWarnf sets the function called with warnings about requests the client adjusted, e.g. log.Printf.
+func WithWarnf(warnf func(format string, args ...interface{})) Option {
+	return optionFunc(func(o *Options) {
+		o.Warnf = warnf
+	})
+}
+
+// WithToken authenticates the client with a user token.
+func WithToken(token string) Option {
+	return optionFunc(func(o *Options) {
+		o.Token = token
+	})
+}
+
+// WithConsumerKey authenticates the client with the consumer key and secret of a Discogs application.
+func WithConsumerKey(key, secret string) Option {
+	return optionFunc(func(o *Options) {
+		o.ConsumerKey = key
+		o.ConsumerSecret = secret
+	})
+}
+
+// WithOAuthCredentials authenticates the client with the OAuth credentials of a user.
+func WithOAuthCredentials(creds OAuthCredentials) Option {
+	return optionFunc(func(o *Options) {
+		o.OAuth = &creds
+	})
+}
+
+// WithHTTPClient sets the HTTP client to send requests with.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(o *Options) {
+		o.Client = client
+	})
+}
+
+// WithTransportOptions sets the settings of the HTTP client used if none is set with WithHTTPClient, replacing those
+// set by WithProxy, WithTLSConfig and WithDialTimeout before it.
+func WithTransportOptions(t TransportOptions) Option {
+	return optionFunc(func(o *Options) {
+		o.Transport = t
+	})
+}
+
+// WithProxy sets the proxy the HTTP client used if none is set with WithHTTPClient sends requests through, as
+// TransportOptions.ProxyURL.
+func WithProxy(proxyURL string) Option {
+	return optionFunc(func(o *Options) {
+		o.Transport.ProxyURL = proxyURL
+	})
+}
+
+// WithTLSConfig sets the TLS configuration of the HTTP client used if none is set with WithHTTPClient, as
+// TransportOptions.TLSConfig.
+func WithTLSConfig(config *tls.Config) Option {
+	return optionFunc(func(o *Options) {
+		o.Transport.TLSConfig = config
+	})
+}
+
+// WithDialTimeout sets the maximum time the HTTP client used if none is set with WithHTTPClient takes to establish a
+// connection, as TransportOptions.DialTimeout.
+func WithDialTimeout(timeout time.Duration) Option {
+	return optionFunc(func(o *Options) {
+		o.Transport.DialTimeout = timeout
+	})
+}
+
+// WithBaseURL sets the Discogs API endpoint, e.g. for testing against a local server.
+func WithBaseURL(baseURL string) Option {
+	return optionFunc(func(o *Options) {
+		o.URL = baseURL
+	})
+}
+
+// ServiceURLs are Discogs API endpoints replacing the client's for the requests of single services. The paths of the
+// requests are appended to them as to the client's endpoint, e.g. "/users/{username}/wants" for Wantlist. Empty ones
+// leave the client's endpoint in place. InventoryExportService and InventoryUploadService use Marketplace.
+// FetchService and ImagesService always use the client's endpoint.
+type ServiceURLs struct {
+	Collection  string
+	Database    string
+	Marketplace string
+	Search      string
+	Wantlist    string
+}
+
+// WithServiceURLs sets endpoints replacing the client's for the requests of single services.
+func WithServiceURLs(urls ServiceURLs) Option {
+	return optionFunc(func(o *Options) {
+		o.ServiceURLs = urls
+	})
+}
+
+// WithRateLimit sets the rate limit instance to track request rates with.
+func WithRateLimit(rl *RateLimit) Option {
+	return optionFunc(func(o *Options) {
+		o.RateLimit = rl
+	})
+}
+
+// WithMiddleware adds middleware invoked around every API request.
+func WithMiddleware(middleware ...Middleware) Option {
+	return optionFunc(func(o *Options) {
+		o.Middleware = append(o.Middleware, middleware...)
+	})
+}
+
+// Validate reports whether the options are complete and consistent, returning ErrInvalidUserAgent,
+// ErrCurrencyNotSupported, ErrInvalidCredentials, ErrInvalidURL or ErrInvalidOptions describing the first problem
+// found.
+func (o *Options) Validate() error {
+	if o == nil || o.UserAgent == "" {
+		return ErrInvalidUserAgent
+	}
+	if o.StrictUserAgent {
+		if err := CheckUserAgent(o.UserAgent); err != nil {
+			return err
+		}
+	}
+
+	if _, err := currency(o.Currency); err != nil {
+		return err
+	}
+
+	if o.URL != "" && o.BaseURL != nil {
+		return fmt.Errorf("%w: url and base url are exclusive", ErrInvalidOptions)
+	}
+	urls := []string{o.URL, o.ServiceURLs.Collection, o.ServiceURLs.Database, o.ServiceURLs.Marketplace,
+		o.ServiceURLs.Search, o.ServiceURLs.Wantlist}
+	if o.BaseURL != nil {
+		urls = append(urls, o.BaseURL.String())
+	}
+	for _, u := range urls {
+		if err := validateURL(u); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case o.Token != "" && o.OAuth != nil:
+		return fmt.Errorf("%w: token and oauth credentials are exclusive", ErrInvalidCredentials)
+	case o.OAuth != nil && (o.OAuth.ConsumerKey == "" || o.OAuth.Token == ""):
+		return fmt.Errorf("%w: oauth credentials require a consumer key and token", ErrInvalidCredentials)
+	case (o.ConsumerKey == "") != (o.ConsumerSecret == ""):
+		return fmt.Errorf("%w: consumer key and secret must be set together", ErrInvalidCredentials)
+	}
+
+	if o.Client != nil && o.Transport != (TransportOptions{}) {
+		return fmt.Errorf("%w: client and transport options are exclusive", ErrInvalidOptions)
+	}
+	if err := o.Transport.validate(); err != nil {
+		return err
+	}
+
+	if o.RateLimit != nil && o.RateLimits != nil {
+		return fmt.Errorf("%w: rate limit and rate limit registry are exclusive", ErrInvalidOptions)
+	}
+	if o.RequestTimeout < 0 {
+		return fmt.Errorf("%w: negative request timeout", ErrInvalidOptions)
+	}
+	if o.MaxResponseSize < 0 {
+		return fmt.Errorf("%w: negative maximum response size", ErrInvalidOptions)
+	}
+	return nil
+}
+
+// validateURL reports ErrInvalidURL unless u is empty or an absolute HTTP(S) URL.
+func validateURL(u string) error {
+	if u == "" {
+		return nil
+	}
+	parsed, err := url.Parse(u)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidURL, u)
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package discogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRequestOptions(t *testing.T) {
+	var currency, header string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		currency = r.URL.Query().Get("curr_abbr")
+		header = r.Header.Get("X-Test")
+		if r.URL.Path == "/releases/1" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		MarketplaceServer(w, r)
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Currency: "USD"})
+	ctx := context.Background()
+
+	if _, err := d.ReleaseStatistics(ctx, testReleaseID); err != nil {
+		t.Fatalf("failed to get release statistics: %s", err)
+	}
+	if currency != "USD" {
+		t.Errorf("currency got=%s; want=USD", currency)
+	}
+
+	if _, err := d.ReleaseStatistics(ctx, testReleaseID, WithCurrency("EUR"), WithHeader("X-Test", "value")); err != nil {
+		t.Fatalf("failed to get release statistics: %s", err)
+	}
+	if currency != "EUR" {
+		t.Errorf("currency got=%s; want=EUR", currency)
+	}
+	if header != "value" {
+		t.Errorf("header got=%s; want=value", header)
+	}
+
+	if _, err := d.ReleaseStatistics(ctx, testReleaseID, WithCurrency("RUR")); err != ErrCurrencyNotSupported {
+		t.Errorf("err got=%v; want=%s", err, ErrCurrencyNotSupported)
+	}
+
+	if _, err := d.Release(ctx, 1, WithTimeout(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err got=%v; want=%s", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/releases/8138518" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, RequestTimeout: 10 * time.Millisecond})
+	ctx := context.Background()
+
+	if _, err := d.Release(ctx, 8138518); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err got=%v; want=%s", err, context.DeadlineExceeded)
+	}
+	if _, err := d.Release(ctx, 8138518, WithTimeout(time.Second)); err != nil {
+		t.Errorf("err got=%v; want=nil", err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	var currency, auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		currency = r.URL.Query().Get("curr_abbr")
+		auth = r.Header.Get("Authorization")
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	requests := 0
+	counter := func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			requests++
+			return next(r)
+		}
+	}
+
+	d, err := NewClient(
+		WithUserAgent(testUserAgent),
+		WithBaseURL(ts.URL),
+		WithToken("some token"),
+		WithCurrency("EUR"),
+		WithTimeout(time.Second),
+		WithHTTPClient(ts.Client()),
+		WithMiddleware(counter),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if currency != "EUR" || auth != "Discogs token=some token" || requests != 1 {
+		t.Errorf("got currency=%s, authorization=%s, requests=%d; want=EUR, Discogs token=some token, 1", currency, auth, requests)
+	}
+
+	if _, err := NewClient(WithBaseURL(ts.URL)); err != ErrInvalidUserAgent {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUserAgent)
+	}
+	if _, err := NewClient(WithUserAgent(testUserAgent), WithConsumerKey("some key", "")); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidCredentials)
+	}
+	if _, err := NewClient(WithUserAgent(testUserAgent), WithCurrency("RUR")); err != ErrCurrencyNotSupported {
+		t.Errorf("err got=%v; want=%s", err, ErrCurrencyNotSupported)
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+	ctx := context.Background()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL, MaxResponseSize: int64(len(releaseJson))})
+	if _, err := d.Release(ctx, 8138518); err != nil {
+		t.Errorf("err got=%v; want=nil", err)
+	}
+
+	d = initDiscogsClient(t, &Options{URL: ts.URL, MaxResponseSize: 1024})
+	if _, err := d.Release(ctx, 8138518); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("err got=%v; want=%s", err, ErrResponseTooLarge)
+	}
+}
+
+// onceErrReader fails the first read with err, and reaches the end of the input afterwards.
+type onceErrReader struct {
+	err error
+}
+
+func (r *onceErrReader) Read(p []byte) (int, error) {
+	err := r.err
+	r.err = nil
+	if err == nil {
+		return 0, io.EOF
+	}
+	return 0, err
+}
+
+func TestLimitedReader(t *testing.T) {
+	failure := errors.New("connection reset")
+	tests := map[string]struct {
+		r    io.Reader
+		want error
+	}{
+		"exact size": {strings.NewReader("1234"), nil},
+		"larger":     {strings.NewReader("12345"), ErrResponseTooLarge},
+		"read error": {io.MultiReader(strings.NewReader("1234"), &onceErrReader{err: failure}), failure},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(&limitedReader{r: tt.r, n: 4}); err != tt.want {
+				t.Errorf("err got=%v; want=%v", err, tt.want)
+			}
+			if buf.String() != "1234" {
+				t.Errorf("body got=%q; want=%q", buf.String(), "1234")
+			}
+		})
+	}
+}
+
+func TestWithRawCapture(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	var buf bytes.Buffer
+	release, err := d.Release(context.Background(), 8138518, WithRawCapture(&buf))
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if release.ID != 8138518 {
+		t.Errorf("release got=%d; want=8138518", release.ID)
+	}
+	if buf.String() != releaseJson {
+		t.Errorf("raw body got=%q; want=%q", buf.String(), releaseJson)
+	}
+}
+
+// streamed returns a middleware responding with body, of an unknown length, so that it's decoded as it's read.
+func streamed(body io.Reader) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(body), ContentLength: -1, Request: r}, nil
+		}
+	}
+}
+
+func TestStreamedResponse(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	d := initDiscogsClient(t, &Options{URL: "http://discogs.test", Middleware: []Middleware{streamed(strings.NewReader(releaseJson + "\n"))}})
+	release, err := d.Release(ctx, 8138518, WithRawCapture(&buf))
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if release.ID != 8138518 {
+		t.Errorf("release got=%d; want=8138518", release.ID)
+	}
+	if buf.String() != releaseJson+"\n" {
+		t.Errorf("raw body got=%q; want=%q", buf.String(), releaseJson+"\n")
+	}
+
+	body := io.MultiReader(strings.NewReader(releaseJson[:100]), iotest.ErrReader(errors.New("connection reset")))
+	d = initDiscogsClient(t, &Options{URL: "http://discogs.test", Middleware: []Middleware{streamed(body)}})
+	if _, err := d.Release(ctx, 8138518); !errors.Is(err, ErrTransport) {
+		t.Errorf("err got=%v; want=%s", err, ErrTransport)
+	}
+
+	d = initDiscogsClient(t, &Options{URL: "http://discogs.test", MaxResponseSize: 1024, Middleware: []Middleware{streamed(strings.NewReader(releaseJson))}})
+	if _, err := d.Release(ctx, 8138518); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("err got=%v; want=%s", err, ErrResponseTooLarge)
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, `{"id": 8138518, "title": "Elephant Riddim", "new_field": true}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	if _, err := d.Release(ctx, 8138518); err != nil {
+		t.Errorf("err got=%v; want=nil", err)
+	}
+	_, err := d.Release(ctx, 8138518, WithStrictDecoding())
+	if !errors.Is(err, ErrUnknownField) || !strings.Contains(err.Error(), "new_field") {
+		t.Errorf("err got=%v; want=%s", err, ErrUnknownField)
+	}
+
+	d = initDiscogsClient(t, &Options{URL: ts.URL, StrictDecoding: true})
+	if _, err := d.Release(ctx, 8138518); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("err got=%v; want=%s", err, ErrUnknownField)
+	}
+}
+
+func TestBulk(t *testing.T) {
+	var perPage string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = r.URL.Query().Get("per_page")
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	var warnings []string
+	warnf := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Warnf: warnf})
+	bulk := initDiscogsClient(t, &Options{URL: ts.URL, Warnf: warnf, Bulk: true})
+
+	tests := map[string]struct {
+		d          Discogs
+		pagination *Pagination
+		opts       []RequestOption
+		want       string
+		warnings   int
+	}{
+		"default":           {d: d, want: "50"},
+		"per page":          {d: d, pagination: &Pagination{PerPage: 25}, want: "25"},
+		"bulk request":      {d: d, pagination: &Pagination{PerPage: 25}, opts: []RequestOption{WithBulk()}, want: "100"},
+		"bulk client":       {d: bulk, want: "100"},
+		"more than maximum": {d: d, pagination: &Pagination{PerPage: 500}, want: "100", warnings: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			warnings = nil
+			if _, err := tt.d.Wantlist(ctx, testUsername, tt.pagination, tt.opts...); err != nil {
+				t.Fatalf("failed to get wantlist: %s", err)
+			}
+			if perPage != tt.want {
+				t.Errorf("per_page got=%s; want=%s", perPage, tt.want)
+			}
+			if len(warnings) != tt.warnings {
+				t.Errorf("warnings got=%q; want %d", warnings, tt.warnings)
+			}
+		})
+	}
+
+	want := "discogs: 500 items per page requested from /users/{username}/wants, limiting to the maximum of 100"
+	warnings = nil
+	params := url.Values{"per_page": {"500"}}
+	if err := d.Do(ctx, http.MethodGet, "/users/"+testUsername+"/wants", params, nil, nil); err != nil {
+		t.Fatalf("failed to call: %s", err)
+	}
+	if len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("warnings got=%q; want=[%s]", warnings, want)
+	}
+	if params.Get("per_page") != "500" {
+		t.Errorf("expected the caller's params to be left unchanged")
+	}
+}
+
+func TestServiceURLs(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	base, err := url.Parse(ts.URL + "/proxy/?key=secret")
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err)
+	}
+	d := initDiscogsClient(t, &Options{
+		BaseURL:     base,
+		ServiceURLs: ServiceURLs{Wantlist: ts.URL + "/mock"},
+	})
+
+	ctx := context.Background()
+	if _, err := d.Release(ctx, 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if _, err := d.Inventory(ctx, "some user", nil); err != nil {
+		t.Fatalf("failed to get inventory: %s", err)
+	}
+	if _, err := d.Wantlist(ctx, "user/name", nil); err != nil {
+		t.Fatalf("failed to get wantlist: %s", err)
+	}
+
+	want := []string{
+		"/proxy/releases/8138518?curr_abbr=USD&key=secret",
+		"/proxy/users/some%20user/inventory?key=secret&page=1&per_page=50",
+		"/mock/users/user%2Fname/wants?page=1&per_page=50",
+	}
+	if !cmp.Equal(paths, want) {
+		t.Errorf("paths got=%v; want=%v", paths, want)
+	}
+}
@@ -0,0 +1,261 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// OrderEventType is the kind of change an OrderEvent reports.
+type OrderEventType int
+
+// Kinds of order changes.
+const (
+	// OrderCreated reports a new order.
+	OrderCreated OrderEventType = iota
+	// OrderStatusChanged reports a new status of an order.
+	OrderStatusChanged
+)
+
+func (t OrderEventType) String() string {
+	switch t {
+	case OrderCreated:
+		return "created"
+	case OrderStatusChanged:
+		return "status changed"
+	default:
+		return "unknown"
+	}
+}
+
+// OrderEvent is a change to a seller's orders detected by an OrderWatcher.
+type OrderEvent struct {
+	Type  OrderEventType
+	Order Order
+	// PreviousStatus is the status of the order before an OrderStatusChanged event.
+	PreviousStatus OrderStatus
+}
+
+// OrderCheckpoint is the state of an OrderWatcher persisted between polls, so a restarted watcher resumes where it
+// stopped instead of reporting the same changes again.
+type OrderCheckpoint struct {
+	// LastActivity is the time of the latest activity on the orders seen.
+	LastActivity time.Time `json:"last_activity"`
+	// Statuses are the statuses of the orders seen by ID.
+	Statuses map[string]OrderStatus `json:"statuses"`
+}
+
+// OrderStore persists the checkpoint of an OrderWatcher, e.g. in a file or database.
+type OrderStore interface {
+	// Load returns the saved checkpoint, or nil if none was saved yet.
+	Load(ctx context.Context) (*OrderCheckpoint, error)
+	// Save replaces the saved checkpoint.
+	Save(ctx context.Context, c *OrderCheckpoint) error
+}
+
+// FileOrderStore is an OrderStore keeping the checkpoint as JSON in a file.
+type FileOrderStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileOrderStore returns a FileOrderStore keeping the checkpoint in the file at path, which is created on the first
+// save.
+func NewFileOrderStore(path string) *FileOrderStore {
+	return &FileOrderStore{path: path}
+}
+
+// Load implements OrderStore.
+func (s *FileOrderStore) Load(ctx context.Context) (*OrderCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c OrderCheckpoint
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Save implements OrderStore. The file is replaced atomically, so a crash never leaves a partial checkpoint.
+func (s *FileOrderStore) Save(ctx context.Context, c *OrderCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// OrderWatcherConfig configures an OrderWatcher. Zero values select the defaults.
+type OrderWatcherConfig struct {
+	// Interval is the time between polls (default 1 minute).
+	Interval time.Duration
+	// Store persists the checkpoint between polls (optional, by default it is only kept in memory).
+	Store OrderStore
+	// OnError is called with the errors of polls (optional). Failed polls don't emit events or move the checkpoint,
+	// so the next poll reports their changes.
+	OnError func(err error)
+}
+
+// OrderWatcher polls the orders of the authenticated seller and emits new orders and status changes, e.g. to drive
+// fulfillment automation. Every poll lists the orders by last activity, newest first, up to the checkpoint. The first
+// poll without a saved checkpoint only records the latest orders; orders it didn't see whose activity changes later
+// are recorded without events, as their previous status is unknown.
+type OrderWatcher struct {
+	s      MarketPlaceService
+	cfg    OrderWatcherConfig
+	events chan OrderEvent
+
+	checkpoint *OrderCheckpoint
+}
+
+// NewOrderWatcher returns an OrderWatcher polling with s, which must be authenticated as the seller.
+func NewOrderWatcher(s MarketPlaceService, cfg OrderWatcherConfig) *OrderWatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	return &OrderWatcher{
+		s:      s,
+		cfg:    cfg,
+		events: make(chan OrderEvent),
+	}
+}
+
+// Events returns the channel Run emits the detected changes on, which is closed when Run returns.
+func (w *OrderWatcher) Events() <-chan OrderEvent {
+	return w.events
+}
+
+// Run polls the orders at the configured interval, starting immediately, and emits the changes on the Events channel
+// until ctx is done. Errors of polls are reported to OnError and don't stop the watcher. Run returns the error of ctx
+// and must be called only once.
+func (w *OrderWatcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	t := time.NewTicker(w.cfg.Interval)
+	defer t.Stop()
+
+	for {
+		events, err := w.Poll(ctx)
+		if err != nil && ctx.Err() == nil && w.cfg.OnError != nil {
+			w.cfg.OnError(err)
+		}
+		for _, e := range events {
+			select {
+			case w.events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll lists the orders once and returns the changes since the checkpoint, oldest activity first, for callers driving
+// the polling themselves instead of calling Run. The checkpoint is saved before Poll returns.
+func (w *OrderWatcher) Poll(ctx context.Context) ([]OrderEvent, error) {
+	if w.checkpoint == nil && w.cfg.Store != nil {
+		c, err := w.cfg.Store.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		w.checkpoint = c
+	}
+	first := w.checkpoint == nil
+
+	orders, err := w.list(ctx, first)
+	if err != nil {
+		return nil, err
+	}
+
+	next := &OrderCheckpoint{Statuses: make(map[string]OrderStatus)}
+	if !first {
+		next.LastActivity = w.checkpoint.LastActivity
+		for id, status := range w.checkpoint.Statuses {
+			next.Statuses[id] = status
+		}
+	}
+
+	since := next.LastActivity
+	var events []OrderEvent
+	// orders are listed newest first, so walk them backwards to emit the changes in the order they happened
+	for i := len(orders) - 1; i >= 0; i-- {
+		o := orders[i]
+		previous, seen := next.Statuses[o.ID]
+		switch {
+		case first:
+		case !seen && !o.Created.Before(since):
+			events = append(events, OrderEvent{Type: OrderCreated, Order: o})
+		case seen && previous != o.Status:
+			events = append(events, OrderEvent{Type: OrderStatusChanged, Order: o, PreviousStatus: previous})
+		}
+		next.Statuses[o.ID] = o.Status
+		if o.LastActivity.After(next.LastActivity) {
+			next.LastActivity = o.LastActivity.Time
+		}
+	}
+
+	if w.cfg.Store != nil {
+		if err := w.cfg.Store.Save(ctx, next); err != nil {
+			return nil, err
+		}
+	}
+	w.checkpoint = next
+	return events, nil
+}
+
+// list returns the orders active since the checkpoint, newest first, or only the first page on the first poll.
+func (w *OrderWatcher) list(ctx context.Context, first bool) ([]Order, error) {
+	pagination := &Pagination{Sort: SortLastActivity, SortOrder: SortDesc, Page: 1, PerPage: maxPerPage}
+	var orders []Order
+	for {
+		page, err := w.s.Orders(ctx, "", pagination)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range page.Orders {
+			// orders last active at the time of the checkpoint are listed again, as others may share the time
+			if !first && o.LastActivity.Before(w.checkpoint.LastActivity) {
+				return orders, nil
+			}
+			orders = append(orders, o)
+		}
+		if first || !page.Pagination.HasNext() {
+			return orders, nil
+		}
+		pagination = pagination.NextPage()
+	}
+}
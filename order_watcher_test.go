@@ -0,0 +1,92 @@
+package discogs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// ordersService serves orders, which are expected newest activity first, on a single page.
+type ordersService struct {
+	MarketPlaceService
+	orders []Order
+}
+
+func (s *ordersService) Orders(ctx context.Context, status OrderStatus, pagination *Pagination, opts ...RequestOption) (*Orders, error) {
+	if pagination.Sort != SortLastActivity || pagination.SortOrder != SortDesc {
+		return nil, ErrInvalidSortKey
+	}
+	return &Orders{Pagination: Page{Page: 1, Pages: 1, Items: len(s.orders)}, Orders: s.orders}, nil
+}
+
+func testOrder(id string, status OrderStatus, created, lastActivity time.Time) Order {
+	return Order{ID: id, Status: status, Created: Time{created}, LastActivity: Time{lastActivity}}
+}
+
+func TestOrderWatcher(t *testing.T) {
+	t0 := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	s := &ordersService{orders: []Order{
+		testOrder("1-2", OrderNewOrder, t0, t0),
+		testOrder("1-1", OrderPaymentReceived, t0.Add(-time.Hour), t0.Add(-time.Minute)),
+	}}
+	store := NewFileOrderStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	w := NewOrderWatcher(s, OrderWatcherConfig{Store: store})
+	ctx := context.Background()
+
+	events, err := w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("failed to poll: %s", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events of first poll got=%+v; want none", events)
+	}
+
+	// order 1-1 is shipped, 1-3 is placed and 1-2 sees no activity
+	s.orders = []Order{
+		testOrder("1-3", OrderNewOrder, t0.Add(2*time.Minute), t0.Add(2*time.Minute)),
+		testOrder("1-1", OrderShipped, t0.Add(-time.Hour), t0.Add(time.Minute)),
+		testOrder("1-2", OrderNewOrder, t0, t0),
+	}
+	events, err = w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("failed to poll: %s", err)
+	}
+
+	type change struct {
+		Type     OrderEventType
+		ID       string
+		Status   OrderStatus
+		Previous OrderStatus
+	}
+	var got []change
+	for _, e := range events {
+		got = append(got, change{e.Type, e.Order.ID, e.Order.Status, e.PreviousStatus})
+	}
+	want := []change{
+		{OrderStatusChanged, "1-1", OrderShipped, OrderPaymentReceived},
+		{OrderCreated, "1-3", OrderNewOrder, ""},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("events got=%+v; want=%+v", got, want)
+	}
+
+	// a restarted watcher resumes from the stored checkpoint
+	w = NewOrderWatcher(s, OrderWatcherConfig{Store: store})
+	events, err = w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("failed to poll: %s", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events after restart got=%+v; want none", events)
+	}
+	c, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %s", err)
+	}
+	if !c.LastActivity.Equal(t0.Add(2*time.Minute)) || c.Statuses["1-1"] != OrderShipped {
+		t.Errorf("checkpoint got=%+v", c)
+	}
+}
@@ -0,0 +1,93 @@
+package discogs
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// Price is an amount of money in a currency. The amount is held in the currency's minor units, e.g. cents, to avoid
+// floating point rounding errors when prices are added up or compared.
+type Price struct {
+	Amount   int64  // value in minor units, e.g. 1807 for 18.07 USD
+	Currency string // currency code, e.g. "USD"
+}
+
+// NewPrice returns the price of value in currency, rounded to the currency's minor units.
+func NewPrice(value float64, currency string) Price {
+	return Price{
+		Amount:   int64(math.Round(value * math.Pow10(decimals(currency)))),
+		Currency: currency,
+	}
+}
+
+// decimals returns the number of decimal places of the minor units of the currency.
+func decimals(currency string) int {
+	if currency == "JPY" {
+		return 0
+	}
+	return 2
+}
+
+// Value returns the price in the currency's major units, e.g. 18.07.
+func (p Price) Value() float64 {
+	return float64(p.Amount) / math.Pow10(decimals(p.Currency))
+}
+
+// String formats the price, e.g. "18.07 USD".
+func (p Price) String() string {
+	return strconv.FormatFloat(p.Value(), 'f', decimals(p.Currency), 64) + " " + p.Currency
+}
+
+// Compare returns -1, 0 or 1 if p is less than, equal to or greater than o. It returns ErrCurrencyMismatch if the
+// prices are in different currencies.
+func (p Price) Compare(o Price) (int, error) {
+	if p.Currency != o.Currency {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case p.Amount < o.Amount:
+		return -1, nil
+	case p.Amount > o.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Add returns the sum of p and o. It returns ErrCurrencyMismatch if the prices are in different currencies.
+func (p Price) Add(o Price) (Price, error) {
+	if p.Currency != o.Currency {
+		return Price{}, ErrCurrencyMismatch
+	}
+	return Price{Amount: p.Amount + o.Amount, Currency: p.Currency}, nil
+}
+
+// Sub returns the difference of p and o. It returns ErrCurrencyMismatch if the prices are in different currencies.
+func (p Price) Sub(o Price) (Price, error) {
+	if p.Currency != o.Currency {
+		return Price{}, ErrCurrencyMismatch
+	}
+	return Price{Amount: p.Amount - o.Amount, Currency: p.Currency}, nil
+}
+
+// price is the JSON representation of a price used by Discogs.
+type price struct {
+	Currency string  `json:"currency"`
+	Value    float64 `json:"value"`
+}
+
+// UnmarshalJSON decodes a price such as {"currency": "USD", "value": 18.07}.
+func (p *Price) UnmarshalJSON(b []byte) error {
+	var v price
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = NewPrice(v.Value, v.Currency)
+	return nil
+}
+
+// MarshalJSON encodes the price as Discogs does.
+func (p Price) MarshalJSON() ([]byte, error) {
+	return json.Marshal(price{Currency: p.Currency, Value: p.Value()})
+}
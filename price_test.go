@@ -0,0 +1,78 @@
+package discogs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewPrice(t *testing.T) {
+	tests := map[string]struct {
+		value    float64
+		currency string
+		amount   int64
+		str      string
+	}{
+		"cents":         {18.07, "USD", 1807, "18.07 USD"},
+		"rounded":       {14.875000000000002, "EUR", 1488, "14.88 EUR"},
+		"float error":   {0.1 + 0.2, "GBP", 30, "0.30 GBP"},
+		"no minor unit": {1500, "JPY", 1500, "1500 JPY"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPrice(tt.value, tt.currency)
+			if p.Amount != tt.amount {
+				t.Errorf("amount got=%d; want=%d", p.Amount, tt.amount)
+			}
+			if p.String() != tt.str {
+				t.Errorf("string got=%s; want=%s", p, tt.str)
+			}
+		})
+	}
+}
+
+func TestPriceArithmetic(t *testing.T) {
+	a := NewPrice(0.1, "USD")
+	b := NewPrice(0.2, "USD")
+
+	sum, err := a.Add(b)
+	if err != nil || sum != NewPrice(0.3, "USD") {
+		t.Errorf("sum got=%v, %v; want=0.30 USD", sum, err)
+	}
+	diff, err := a.Sub(b)
+	if err != nil || diff.Amount != -10 {
+		t.Errorf("difference got=%v, %v; want=-0.10 USD", diff, err)
+	}
+	if c, err := a.Compare(b); err != nil || c != -1 {
+		t.Errorf("compare got=%d, %v; want=-1", c, err)
+	}
+	if c, err := sum.Compare(NewPrice(0.3, "USD")); err != nil || c != 0 {
+		t.Errorf("compare got=%d, %v; want=0", c, err)
+	}
+
+	eur := NewPrice(0.1, "EUR")
+	if _, err := a.Add(eur); err != ErrCurrencyMismatch {
+		t.Errorf("err got=%v; want=%s", err, ErrCurrencyMismatch)
+	}
+	if _, err := a.Sub(eur); err != ErrCurrencyMismatch {
+		t.Errorf("err got=%v; want=%s", err, ErrCurrencyMismatch)
+	}
+	if _, err := a.Compare(eur); err != ErrCurrencyMismatch {
+		t.Errorf("err got=%v; want=%s", err, ErrCurrencyMismatch)
+	}
+}
+
+func TestPriceJSON(t *testing.T) {
+	var p Price
+	if err := json.Unmarshal([]byte(`{"value": 18.07, "currency": "USD"}`), &p); err != nil {
+		t.Fatalf("failed to unmarshal price: %s", err)
+	}
+	if p != (Price{Amount: 1807, Currency: "USD"}) {
+		t.Errorf("price got=%+v; want={Amount:1807 Currency:USD}", p)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("failed to marshal price: %s", err)
+	}
+	compareJson(t, string(b), `{"currency": "USD", "value": 18.07}`)
+}
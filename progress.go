@@ -0,0 +1,32 @@
+package discogs
+
+import (
+	"time"
+)
+
+// Progress describes how far a long-running operation got, e.g. fetching every page of a collection. Totals are zero
+// while unknown.
+type Progress struct {
+	// Pages is the number of pages fetched and TotalPages the number of pages to fetch. Both are zero for operations
+	// which don't page, such as RateAll.
+	Pages      int
+	TotalPages int
+	// Items is the number of items fetched or processed and TotalItems the number of items to fetch or process.
+	Items      int
+	TotalItems int
+	// Elapsed is the time since the operation started.
+	Elapsed time.Duration
+}
+
+// ETA estimates the time left until the operation completes from the time taken per item so far. It's zero if the
+// total is unknown or the operation is done.
+func (p Progress) ETA() time.Duration {
+	if p.Items <= 0 || p.TotalItems <= p.Items {
+		return 0
+	}
+	return time.Duration(float64(p.Elapsed) * float64(p.TotalItems-p.Items) / float64(p.Items))
+}
+
+// ProgressFunc receives the progress of a long-running operation, e.g. to render a progress bar. It's called from the
+// goroutine running the operation, which waits for it to return.
+type ProgressFunc func(p Progress)
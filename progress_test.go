@@ -0,0 +1,25 @@
+package discogs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressETA(t *testing.T) {
+	tests := map[string]struct {
+		p    Progress
+		want time.Duration
+	}{
+		"started":       {Progress{Items: 0, TotalItems: 100, Elapsed: time.Second}, 0},
+		"quarter":       {Progress{Items: 25, TotalItems: 100, Elapsed: 10 * time.Second}, 30 * time.Second},
+		"done":          {Progress{Items: 100, TotalItems: 100, Elapsed: time.Minute}, 0},
+		"unknown total": {Progress{Items: 50, Elapsed: time.Minute}, 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.p.ETA(); got != tt.want {
+				t.Errorf("ETA got=%s; want=%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,16 +3,107 @@ package discogs
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strconv"
 	"sync"
 	"time"
 )
 
+// RateLimit tracks the rate limiting parameters reported by Discogs and paces requests made through Call accordingly.
+// The zero value is ready to use with the default backoff; use NewRateLimit to configure it.
 type RateLimit struct {
+	opts RateLimitOptions
+
 	mu        sync.Mutex
 	total     int
 	used      int
 	remaining int
 	updated   time.Time
+
+	interval time.Duration // minimum interval between requests when a budget is set
+	next     time.Time     // earliest time at which the next request may be made when a budget is set
+	reserved int           // remaining requests background calls leave to others
+
+	onWait func(delay time.Duration, attempt int)
+	stats  RateLimitStats
+}
+
+// RateLimitStats holds counters describing how much Call has been throttled.
+type RateLimitStats struct {
+	Waits           int           // The number of times Call waited before making a request.
+	WaitTime        time.Duration // The total time Call waited before making requests.
+	TooManyRequests int           // The number of requests rejected with ErrTooManyRequests.
+}
+
+// RateLimitOptions configures the backoff applied by RateLimit.Call when Discogs reports that the rate limit has been
+// reached. Zero values select the defaults.
+type RateLimitOptions struct {
+	// InitialDelay is the first delay (default 2.5s).
+	InitialDelay time.Duration
+	// Multiplier is the factor the delay is multiplied with after every delay (default 2).
+	Multiplier float64
+	// MaxDelay caps the delay (optional).
+	MaxDelay time.Duration
+	// MaxAttempts is the maximum number of attempts made by Call, after which it returns ErrRateLimitExceeded
+	// (optional, Call retries indefinitely by default).
+	MaxAttempts int
+	// Jitter randomizes every delay by up to the given fraction of it, e.g. 0.2 for ±20% (optional).
+	Jitter float64
+}
+
+// NewRateLimit returns a RateLimit applying the given backoff.
+func NewRateLimit(opts RateLimitOptions) *RateLimit {
+	return &RateLimit{opts: opts}
+}
+
+// Priority is the priority of the calls made through RateLimit.Call with a context, see WithPriority.
+type Priority int
+
+// Priorities of calls.
+const (
+	// PriorityNormal calls are paced by the budget and the rate limiting metrics. It is the default.
+	PriorityNormal Priority = iota
+	// PriorityHigh calls, such as lookups a user waits for, skip the pacing of the budget. They still back off when
+	// Discogs reports that the rate limit has been reached.
+	PriorityHigh
+	// PriorityBackground calls, such as bulk synchronization, are paced like normal ones and additionally wait while
+	// the remaining requests don't exceed the reserve set with SetBackgroundReserve.
+	PriorityBackground
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx making the calls it is passed to have the given priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// priority returns the priority of the calls made with ctx.
+func priority(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
+
+// RateLimitSnapshot holds the rate limiting parameters reported by a Discogs API response.
+type RateLimitSnapshot struct {
+	Total     int // The total number of requests you can make in a one minute window.
+	Used      int // The number of requests you’ve made in your existing rate limit window.
+	Remaining int // The number of remaining requests you are able to make in the existing rate limit window.
+}
+
+// ParseRateLimitSnapshot parses the rate limiting headers of a Discogs API response, e.g. in Middleware. Missing
+// headers leave the corresponding fields zero.
+func ParseRateLimitSnapshot(header http.Header) RateLimitSnapshot {
+	return rateLimitSnapshot(header)
+}
+
+// rateLimitSnapshot parses the rate limiting headers of a response.
+func rateLimitSnapshot(header http.Header) RateLimitSnapshot {
+	var s RateLimitSnapshot
+	s.Total, _ = strconv.Atoi(header.Get("X-Discogs-Ratelimit"))
+	s.Used, _ = strconv.Atoi(header.Get("X-Discogs-Ratelimit-Used"))
+	s.Remaining, _ = strconv.Atoi(header.Get("X-Discogs-Ratelimit-Remaining"))
+	return s
 }
 
 // Update sets the rate limiting parameters received from the headers of a Discogs API call.
@@ -38,8 +129,117 @@ func (r *RateLimit) Get() (total, used, remaining int, updated time.Time) {
 	return
 }
 
+// SetBudget makes Call pace requests proactively, spacing them evenly so that no more than requestsPerMinute are made
+// per minute, in addition to reacting to the rate limiting metrics reported by Discogs. This avoids bursts of requests
+// before the first response reports the rate limit; Discogs permits 60 authenticated or 25 unauthenticated requests
+// per minute. A budget of zero or less disables pacing.
+func (r *RateLimit) SetBudget(requestsPerMinute int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interval = 0
+	if requestsPerMinute > 0 {
+		r.interval = time.Minute / time.Duration(requestsPerMinute)
+	}
+	r.next = time.Time{}
+}
+
+// SetBackgroundReserve makes calls with PriorityBackground wait while the remaining requests reported by Discogs are
+// no more than n, leaving them to normal and high priority calls so mixed workloads don't starve interactive ones.
+// A reserve of zero or less disables it.
+func (r *RateLimit) SetBackgroundReserve(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reserved = n
+}
+
+// starved reports whether a background call has to wait for the remaining requests to exceed the reserve. Metrics
+// older than the ones call trusts don't hold background calls back, so they resume once other calls stop.
+func (r *RateLimit) starved() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reserved > 0 && time.Since(r.updated) < 10*time.Second && r.remaining <= r.reserved
+}
+
+// reserve reserves the next request permitted by the budget and returns the time it's reserved for and the time to
+// wait before making it.
+func (r *RateLimit) reserve() (time.Time, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.interval == 0 {
+		return time.Time{}, 0
+	}
+
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	slot := r.next
+	r.next = r.next.Add(r.interval)
+	return slot, slot.Sub(now)
+}
+
+// unreserve gives back the request reserved for slot by reserve when it won't be made. Only the latest reservation
+// can be given back, as the requests reserved after it keep their times.
+func (r *RateLimit) unreserve(slot time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.interval > 0 && r.next.Equal(slot.Add(r.interval)) {
+		r.next = slot
+	}
+}
+
+// OnWait sets a callback invoked whenever Call is about to wait before making a request, receiving the delay and the
+// number of the attempt the wait precedes, the first attempt being number 1.
+func (r *RateLimit) OnWait(f func(delay time.Duration, attempt int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onWait = f
+}
+
+// Stats returns the counters accumulated by Call.
+func (r *RateLimit) Stats() RateLimitStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stats
+}
+
+// wait notifies the OnWait callback of a wait of the given delay before the given attempt, waits and records the time
+// waited, which falls short of the delay if ctx is done first.
+func (r *RateLimit) wait(ctx context.Context, sleep func(context.Context, time.Duration) error, delay time.Duration, attempt int) error {
+	r.mu.Lock()
+	onWait := r.onWait
+	r.mu.Unlock()
+
+	if onWait != nil {
+		onWait(delay, attempt)
+	}
+	start := time.Now()
+	err := sleep(ctx, delay)
+	waited := delay
+	if err != nil {
+		if waited = time.Since(start); waited > delay {
+			waited = delay
+		}
+	}
+
+	r.mu.Lock()
+	r.stats.Waits++
+	r.stats.WaitTime += waited
+	r.mu.Unlock()
+	return err
+}
+
 // Call invokes f() when the rate limiting metrics indicate that it's likely safe to do so and, if a rate limiting
 // error is returned, repeats the call with exponential backoff until it returns any value other than ErrTooManyRequests.
+// If the maximum number of attempts is reached, ErrRateLimitExceeded is returned. The priority set on ctx with
+// WithPriority determines whether the call skips the budget or leaves the reserve to others.
 func (r *RateLimit) Call(ctx context.Context, f func() error) error {
 
 	t := time.NewTimer(time.Minute)
@@ -47,6 +247,9 @@ func (r *RateLimit) Call(ctx context.Context, f func() error) error {
 	defer t.Stop()
 
 	sleep := func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		t.Reset(d)
 		select {
 		case <-ctx.Done():
@@ -64,26 +267,54 @@ const minimumRateLimitDelay = 2500 * time.Millisecond
 
 // call is the inner implementation of Call which accepts a sleep function that can be mocked during testing.
 func (r *RateLimit) call(ctx context.Context, f func() error, sleep func(context.Context, time.Duration) error) error {
-	delay := minimumRateLimitDelay
+	initial := r.opts.InitialDelay
+	if initial <= 0 {
+		initial = minimumRateLimitDelay
+	}
+	delays := 0
 	first := true
+	p := priority(ctx)
 
-	for {
+	for attempt := 1; ; attempt++ {
 		_, _, remaining, when := r.Get()
 
 		// pause if the rate limiting metrics are reasonably fresh and we have no remaining permitted requests, OR if
 		// we just received ErrTooManyRequests regardless of how many requests Discogs claims we have remaining;
 		// Discogs seems to report the pre-request X-Discogs-Ratelimit-Used value, so we're out of requests when remaining==1
 		if !first || time.Now().Sub(when) < 10*time.Second && remaining <= 1 {
-			if err := sleep(ctx, delay); err != nil {
+			delays++
+			if err := r.wait(ctx, sleep, backoff(initial, r.opts.Multiplier, r.opts.MaxDelay, r.opts.Jitter, delays), attempt); err != nil {
 				return err
 			}
-			delay *= 2
+		}
+
+		if p == PriorityBackground {
+			for r.starved() {
+				if err := r.wait(ctx, sleep, initial, attempt); err != nil {
+					return err
+				}
+			}
+		}
+
+		if p != PriorityHigh {
+			if slot, wait := r.reserve(); wait > 0 {
+				if err := r.wait(ctx, sleep, wait, attempt); err != nil {
+					r.unreserve(slot)
+					return err
+				}
+			}
 		}
 
 		err := f()
 		if !errors.Is(err, ErrTooManyRequests) {
 			return err
 		}
+		r.mu.Lock()
+		r.stats.TooManyRequests++
+		r.mu.Unlock()
+		if r.opts.MaxAttempts > 0 && attempt >= r.opts.MaxAttempts {
+			return ErrRateLimitExceeded
+		}
 		first = false
 	}
 }
@@ -0,0 +1,59 @@
+package discogs
+
+import (
+	"sync"
+)
+
+// RateLimitRegistry holds a RateLimit per token. Discogs applies its rate limits per token, or per IP address for
+// unauthenticated requests, so clients sharing a token must share a RateLimit to pace their requests correctly.
+type RateLimitRegistry struct {
+	mu     sync.Mutex
+	limits map[string]*RateLimit
+	keys   map[string]*RateLimit
+	budget int
+}
+
+// NewRateLimitRegistry returns an empty RateLimitRegistry.
+func NewRateLimitRegistry() *RateLimitRegistry {
+	return &RateLimitRegistry{
+		limits: make(map[string]*RateLimit),
+		keys:   make(map[string]*RateLimit),
+	}
+}
+
+// SetBudget sets the budget, see RateLimit.SetBudget, of the RateLimits created by the registry from now on.
+func (r *RateLimitRegistry) SetBudget(requestsPerMinute int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.budget = requestsPerMinute
+}
+
+// Get returns the RateLimit for token, creating it if necessary. An empty token returns the RateLimit shared by all
+// unauthenticated clients.
+func (r *RateLimitRegistry) Get(token string) *RateLimit {
+	return r.get(r.limits, token)
+}
+
+// GetConsumerKey returns the RateLimit for the clients authenticating with the given consumer key and secret rather
+// than a token, creating it if necessary. It's distinct from the RateLimits of tokens and of unauthenticated clients.
+func (r *RateLimitRegistry) GetConsumerKey(consumerKey string) *RateLimit {
+	if consumerKey == "" {
+		return r.Get("")
+	}
+	return r.get(r.keys, consumerKey)
+}
+
+// get returns the RateLimit for id in limits, creating it if necessary.
+func (r *RateLimitRegistry) get(limits map[string]*RateLimit, id string) *RateLimit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rl, ok := limits[id]
+	if !ok {
+		rl = &RateLimit{}
+		rl.SetBudget(r.budget)
+		limits[id] = rl
+	}
+	return rl
+}
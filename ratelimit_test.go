@@ -87,3 +87,299 @@ func TestRateLimit_Call(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimit_Backoff(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		opts        RateLimitOptions
+		attempts    []error
+		expectErr   error
+		expectDelay time.Duration
+	}{
+		{"initial delay", RateLimitOptions{InitialDelay: time.Second}, []error{ErrTooManyRequests, ErrTooManyRequests, nil}, nil, 3 * time.Second},
+		{"multiplier", RateLimitOptions{InitialDelay: time.Second, Multiplier: 3}, []error{ErrTooManyRequests, ErrTooManyRequests, nil}, nil, 4 * time.Second},
+		{"max delay", RateLimitOptions{InitialDelay: time.Second, MaxDelay: 2 * time.Second}, []error{ErrTooManyRequests, ErrTooManyRequests, ErrTooManyRequests, nil}, nil, 5 * time.Second},
+		{"max attempts", RateLimitOptions{InitialDelay: time.Second, MaxAttempts: 2}, []error{ErrTooManyRequests, ErrTooManyRequests, nil}, ErrRateLimitExceeded, time.Second},
+		{"max attempts not reached", RateLimitOptions{InitialDelay: time.Second, MaxAttempts: 3}, []error{ErrTooManyRequests, io.ErrUnexpectedEOF}, io.ErrUnexpectedEOF, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimit(tt.opts)
+			attempts := tt.attempts[:]
+			slept := time.Duration(0)
+
+			request := func() error {
+				err := attempts[0]
+				attempts = attempts[1:]
+				return err
+			}
+
+			sleep := func(ctx context.Context, duration time.Duration) error {
+				slept += duration
+				return nil
+			}
+
+			err := rl.call(ctx, request, sleep)
+
+			if err != tt.expectErr {
+				t.Errorf("Expected error %v, got error %v", tt.expectErr, err)
+			}
+			if slept != tt.expectDelay {
+				t.Errorf("Expected delay %v, got delay %v", tt.expectDelay.String(), slept.String())
+			}
+		})
+	}
+}
+
+func TestRateLimit_BackoffJitter(t *testing.T) {
+	rl := NewRateLimit(RateLimitOptions{InitialDelay: time.Second, Jitter: 0.5})
+	attempts := []error{ErrTooManyRequests, nil}
+	request := func() error {
+		err := attempts[0]
+		attempts = attempts[1:]
+		return err
+	}
+	slept := time.Duration(0)
+	sleep := func(ctx context.Context, duration time.Duration) error {
+		slept += duration
+		return nil
+	}
+
+	if err := rl.call(context.Background(), request, sleep); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if slept < 500*time.Millisecond || slept > 1500*time.Millisecond {
+		t.Errorf("Expected delay between %v and %v, got delay %v", 500*time.Millisecond, 1500*time.Millisecond, slept)
+	}
+}
+
+func TestRateLimit_Stats(t *testing.T) {
+	rl := NewRateLimit(RateLimitOptions{InitialDelay: time.Second})
+
+	type wait struct {
+		delay   time.Duration
+		attempt int
+	}
+	var waits []wait
+	rl.OnWait(func(delay time.Duration, attempt int) {
+		waits = append(waits, wait{delay, attempt})
+	})
+
+	attempts := []error{ErrTooManyRequests, ErrTooManyRequests, nil}
+	request := func() error {
+		err := attempts[0]
+		attempts = attempts[1:]
+		return err
+	}
+	sleep := func(ctx context.Context, duration time.Duration) error {
+		return nil
+	}
+
+	if err := rl.call(context.Background(), request, sleep); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	want := []wait{{time.Second, 2}, {2 * time.Second, 3}}
+	if len(waits) != len(want) {
+		t.Fatalf("waits=%v; want=%v", waits, want)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("waits[%d]=%v; want=%v", i, waits[i], want[i])
+		}
+	}
+
+	stats := rl.Stats()
+	wantStats := RateLimitStats{Waits: 2, WaitTime: 3 * time.Second, TooManyRequests: 2}
+	if stats != wantStats {
+		t.Errorf("stats=%+v; want=%+v", stats, wantStats)
+	}
+}
+
+func TestRateLimit_CallCanceled(t *testing.T) {
+	rl := &RateLimit{}
+	rl.Update(10, 10, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	start := time.Now()
+	err := rl.Call(ctx, func() error {
+		called = true
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("err got=%v; want=%v", err, context.Canceled)
+	}
+	if called {
+		t.Error("request made despite canceled context")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Call returned after %v", elapsed)
+	}
+}
+
+func TestRateLimit_StatsCanceled(t *testing.T) {
+	rl := &RateLimit{}
+	rl.Update(10, 10, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := rl.Call(ctx, func() error { return nil }); err != context.DeadlineExceeded {
+		t.Errorf("err got=%v; want=%v", err, context.DeadlineExceeded)
+	}
+	stats := rl.Stats()
+	if stats.Waits != 1 {
+		t.Errorf("waits got=%d; want=1", stats.Waits)
+	}
+	if stats.WaitTime <= 0 || stats.WaitTime >= minimumRateLimitDelay {
+		t.Errorf("wait time got=%v; want the time until the context was done", stats.WaitTime)
+	}
+}
+
+func TestRateLimit_SetBudget(t *testing.T) {
+	rl := &RateLimit{}
+	rl.SetBudget(60)
+	ctx := context.Background()
+
+	slept := time.Duration(0)
+	sleep := func(ctx context.Context, duration time.Duration) error {
+		slept += duration
+		return nil
+	}
+	request := func() error {
+		return nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := rl.call(ctx, request, sleep); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	// the first request is made immediately and the following ones are spaced a second apart; as the mocked sleep
+	// doesn't pass any time, the second request waits one second and the third one two seconds
+	if slept < 3*time.Second-100*time.Millisecond || slept > 3*time.Second {
+		t.Errorf("Expected delay of about %v, got delay %v", 3*time.Second, slept)
+	}
+
+	rl.SetBudget(0)
+	slept = 0
+	if err := rl.call(ctx, request, sleep); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if slept != 0 {
+		t.Errorf("Expected no delay, got delay %v", slept)
+	}
+}
+
+func TestRateLimit_SetBudgetCanceled(t *testing.T) {
+	rl := &RateLimit{}
+	rl.SetBudget(60)
+	request := func() error {
+		return nil
+	}
+	if err := rl.Call(context.Background(), request); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the second call waits for the slot a second later and is canceled while waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	rl.OnWait(func(delay time.Duration, attempt int) { cancel() })
+	called := false
+	err := rl.Call(ctx, func() error {
+		called = true
+		return nil
+	})
+	if err != context.Canceled || called {
+		t.Fatalf("got=%v, %t; want=%v, false", err, called, context.Canceled)
+	}
+
+	// the canceled call gave its slot back, so the next call waits for it rather than the one after it
+	rl.OnWait(nil)
+	slept := time.Duration(0)
+	sleep := func(ctx context.Context, duration time.Duration) error {
+		slept += duration
+		return nil
+	}
+	if err := rl.call(context.Background(), request, sleep); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if slept <= 0 || slept > time.Second {
+		t.Errorf("Expected delay of at most %v, got delay %v", time.Second, slept)
+	}
+}
+
+func TestRateLimitRegistry(t *testing.T) {
+	registry := NewRateLimitRegistry()
+	registry.SetBudget(60)
+
+	a := registry.Get("token a")
+	if a != registry.Get("token a") {
+		t.Errorf("expected the same rate limit for the same token")
+	}
+	if a == registry.Get("token b") || a == registry.Get("") {
+		t.Errorf("expected different rate limits for different tokens")
+	}
+	if a.interval != time.Second {
+		t.Errorf("Expected interval %v, got interval %v", time.Second, a.interval)
+	}
+
+	k := registry.GetConsumerKey("key a")
+	if k != registry.GetConsumerKey("key a") {
+		t.Errorf("expected the same rate limit for the same consumer key")
+	}
+	if k == registry.GetConsumerKey("key b") || k == registry.Get("") || k == registry.Get("key a") {
+		t.Errorf("expected different rate limits for different consumer keys, tokens and unauthenticated clients")
+	}
+	if registry.GetConsumerKey("") != registry.Get("") {
+		t.Errorf("expected the unauthenticated rate limit for an empty consumer key")
+	}
+}
+
+func TestRateLimit_Priority(t *testing.T) {
+	rl := NewRateLimit(RateLimitOptions{InitialDelay: time.Second})
+	rl.SetBackgroundReserve(10)
+	rl.Update(60, 50, 10)
+
+	slept := time.Duration(0)
+	sleep := func(ctx context.Context, duration time.Duration) error {
+		slept += duration
+		// other calls leave the window while background calls wait
+		rl.Update(60, 40, 20)
+		return nil
+	}
+	request := func() error {
+		return nil
+	}
+
+	if err := rl.call(context.Background(), request, sleep); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if slept != 0 {
+		t.Errorf("normal call delay got=%v; want=0", slept)
+	}
+
+	if err := rl.call(WithPriority(context.Background(), PriorityBackground), request, sleep); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if slept != time.Second {
+		t.Errorf("background call delay got=%v; want=%v", slept, time.Second)
+	}
+
+	rl.SetBudget(60)
+	slept = 0
+	ctx := WithPriority(context.Background(), PriorityHigh)
+	for i := 0; i < 3; i++ {
+		if err := rl.call(ctx, request, sleep); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if slept != 0 {
+		t.Errorf("high priority call delay got=%v; want=0", slept)
+	}
+}
@@ -0,0 +1,283 @@
+// Package recorder records the responses of the Discogs API to golden files and replays them, so code using a
+// discogs client can be tested against realistic payloads without sending requests to Discogs.
+//
+// A Recorder is added to a client as middleware. In ModeRecord it sends the requests and records the responses, which
+// Save writes to the golden file. In ModeReplay it serves the recorded responses without sending any request:
+//
+//	rec, err := recorder.New("testdata/release.json", recorder.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	d, err := discogs.New(&discogs.Options{
+//		UserAgent:  "TestClient/1.0",
+//		Middleware: []discogs.Middleware{rec.Middleware()},
+//	})
+//
+// Golden files never hold credentials: request headers, including Authorization, aren't recorded, the token, key,
+// secret and OAuth query parameters are replaced by "REDACTED" and so are any values passed to Redact.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/irlndts/go-discogs"
+)
+
+// Mode selects whether a Recorder records or replays responses.
+type Mode int
+
+// Modes of a Recorder.
+const (
+	// ModeReplay serves the responses recorded in the golden file and fails requests that weren't recorded with
+	// ErrNotRecorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests and records their responses to be written to the golden file by Save.
+	ModeRecord
+)
+
+// redacted replaces credentials and the values passed to Redact in golden files.
+const redacted = "REDACTED"
+
+// ErrNotRecorded is returned in ModeReplay for requests the golden file holds no response to.
+var ErrNotRecorded = errors.New("recorder: request not recorded")
+
+// Interaction is a request and its response as stored in a golden file.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. URL holds the path and query, so the responses can be replayed against any
+// endpoint.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// Response is a recorded response. JSON bodies are stored as is to keep golden files readable and editable, other
+// bodies such as images are stored base64 encoded in Data.
+type Response struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Data   []byte          `json:"data,omitempty"`
+}
+
+// golden is the content of a golden file.
+type golden struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder records or replays the responses of the requests sent through its Middleware. It is safe for concurrent
+// use.
+type Recorder struct {
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+	redact       []string
+}
+
+// New returns a Recorder recording to or replaying from the golden file at path. In ModeReplay the file is read
+// immediately and must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode != ModeReplay {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var g golden
+	if err := json.Unmarshal(b, &g); err != nil {
+		return nil, fmt.Errorf("recorder: invalid golden file %s: %w", path, err)
+	}
+	r.interactions = g.Interactions
+	r.replayed = make([]bool, len(g.Interactions))
+	return r, nil
+}
+
+// Redact replaces values, such as usernames or personal data, by "REDACTED" in the URLs, headers and bodies of the
+// responses recorded from now on. In ModeReplay the values are replaced in the URLs of requests before they are
+// matched, so the same Redact calls should be made when recording and replaying.
+func (r *Recorder) Redact(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, v := range values {
+		if v != "" {
+			r.redact = append(r.redact, v)
+		}
+	}
+}
+
+// Middleware returns middleware recording or replaying every request, e.g. for discogs.Options.Middleware or
+// discogs.WithMiddleware. It should be the innermost middleware, so other middleware runs as if the API was called.
+func (r *Recorder) Middleware() discogs.Middleware {
+	return func(next discogs.RoundTripFunc) discogs.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if r.mode == ModeReplay {
+				return r.replay(req)
+			}
+			return r.record(req, next)
+		}
+	}
+}
+
+// replay returns the first recorded response to req which hasn't been replayed yet, or the last one if all have.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u := r.scrubURL(req.URL)
+	match := -1
+	for i, in := range r.interactions {
+		if in.Request.Method != req.Method || in.Request.URL != u {
+			continue
+		}
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, req.Method, u)
+	}
+	r.replayed[match] = true
+
+	resp := r.interactions[match].Response
+	body := []byte(resp.Body)
+	if resp.Data != nil {
+		body = resp.Data
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+		StatusCode:    resp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        resp.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record sends req with next and records its response.
+func (r *Recorder) record(req *http.Request, next discogs.RoundTripFunc) (*http.Response, error) {
+	// without an explicit Accept-Encoding the HTTP transport decompresses the body, which keeps golden files readable
+	req = req.Clone(req.Context())
+	req.Header.Del("Accept-Encoding")
+
+	resp, err := next(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recorded := Response{Status: resp.StatusCode, Header: http.Header{}}
+	for k, v := range resp.Header {
+		if k == "Set-Cookie" || k == "Content-Length" {
+			continue
+		}
+		for _, s := range v {
+			recorded.Header.Add(k, r.scrub(s))
+		}
+	}
+	if scrubbed := []byte(r.scrub(string(body))); json.Valid(scrubbed) {
+		recorded.Body = scrubbed
+	} else {
+		recorded.Data = body
+	}
+	r.interactions = append(r.interactions, Interaction{
+		Request:  Request{Method: req.Method, URL: r.scrubURL(req.URL)},
+		Response: recorded,
+	})
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the golden file, creating its directory if needed. It does nothing in
+// ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(golden{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// Interactions returns the interactions recorded or loaded from the golden file.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// scrubURL returns the path and query of u with credentials and redacted values replaced.
+func (r *Recorder) scrubURL(u *url.URL) string {
+	q := u.Query()
+	for k := range q {
+		if isCredential(k) {
+			q[k] = []string{redacted}
+		}
+	}
+	s := u.EscapedPath()
+	if len(q) > 0 {
+		s += "?" + q.Encode()
+	}
+	return r.scrub(s)
+}
+
+// scrub returns s with the values passed to Redact replaced.
+func (r *Recorder) scrub(s string) string {
+	for _, v := range r.redact {
+		s = strings.ReplaceAll(s, v, redacted)
+		if escaped := url.PathEscape(v); escaped != v {
+			s = strings.ReplaceAll(s, escaped, redacted)
+		}
+	}
+	return s
+}
+
+// isCredential reports whether the query parameter name carries credentials.
+func isCredential(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "token", "key", "secret":
+		return true
+	}
+	return strings.HasPrefix(name, "oauth_")
+}
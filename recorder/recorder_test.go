@@ -0,0 +1,99 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/irlndts/go-discogs"
+	"github.com/irlndts/go-discogs/discogstest"
+)
+
+func newClient(t *testing.T, url string, rec *Recorder) discogs.Discogs {
+	t.Helper()
+	d, err := discogs.New(&discogs.Options{
+		UserAgent:  "UnitTestClient/0.0.2",
+		URL:        url,
+		Token:      "some token",
+		Middleware: []discogs.Middleware{rec.Middleware()},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	return d
+}
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "golden.json")
+	s := discogstest.NewServer()
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %s", err)
+	}
+	rec.Redact("secret_user")
+	d := newClient(t, s.URL, rec)
+	ctx := context.Background()
+
+	release, err := d.Release(ctx, 8138518)
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	var master discogs.Master
+	if err := d.Fetch(ctx, s.URL+"/masters/718441?token=some+token", &master); err != nil {
+		t.Fatalf("failed to fetch master: %s", err)
+	}
+	if _, err := d.CollectionFolders(ctx, "secret_user"); err != nil {
+		t.Fatalf("failed to get folders: %s", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("failed to save: %s", err)
+	}
+	s.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %s", err)
+	}
+	for _, secret := range []string{"some token", "some+token", "secret_user"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("golden file contains %q", secret)
+		}
+	}
+
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("failed to load recorder: %s", err)
+	}
+	rec.Redact("secret_user")
+	if n := len(rec.Interactions()); n != 3 {
+		t.Errorf("interactions got=%d; want=3", n)
+	}
+	d = newClient(t, s.URL, rec)
+
+	replayed, err := d.Release(ctx, 8138518)
+	if err != nil {
+		t.Fatalf("failed to replay release: %s", err)
+	}
+	if replayed.Title != release.Title || len(replayed.Tracklist) != len(release.Tracklist) {
+		t.Errorf("release got=%s, %d tracks; want=%s, %d tracks", replayed.Title, len(replayed.Tracklist), release.Title, len(release.Tracklist))
+	}
+	if err := d.Fetch(ctx, s.URL+"/masters/718441?token=other+token", &master); err != nil {
+		t.Errorf("failed to replay master: %s", err)
+	}
+	if _, err := d.CollectionFolders(ctx, "secret_user"); err != nil {
+		t.Errorf("failed to replay folders: %s", err)
+	}
+	if _, err := d.Release(ctx, 1); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("err got=%v; want=%s", err, ErrNotRecorded)
+	}
+}
+
+func TestReplayMissingFile(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err got=%v; want=%s", err, os.ErrNotExist)
+	}
+}
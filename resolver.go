@@ -0,0 +1,306 @@
+package discogs
+
+import (
+	"context"
+	"sync"
+)
+
+// Resolver navigates the links between releases, masters, artists and labels. The refs it returns identify an entity
+// by its ID and fetch it lazily on first use, so following a chain of links such as
+//
+//	master, err := discogs.NewResolver(client).ReleaseRef(8138518).Master(ctx)
+//
+// only requests the entities that are actually needed. Every entity is fetched at most once per ref.
+type Resolver struct {
+	s    DatabaseService
+	opts []RequestOption
+}
+
+// NewResolver returns a Resolver fetching entities through s, applying opts to every request.
+func NewResolver(s DatabaseService, opts ...RequestOption) *Resolver {
+	return &Resolver{s: s, opts: opts}
+}
+
+// ReleaseRef returns a reference to the release with the given ID.
+func (r *Resolver) ReleaseRef(releaseID int) *ReleaseRef {
+	return &ReleaseRef{ID: releaseID, r: r}
+}
+
+// MasterRef returns a reference to the master release with the given ID.
+func (r *Resolver) MasterRef(masterID int) *MasterRef {
+	return &MasterRef{ID: masterID, r: r}
+}
+
+// ArtistRef returns a reference to the artist with the given ID.
+func (r *Resolver) ArtistRef(artistID int) *ArtistRef {
+	return &ArtistRef{ID: artistID, r: r}
+}
+
+// LabelRef returns a reference to the label with the given ID.
+func (r *Resolver) LabelRef(labelID int) *LabelRef {
+	return &LabelRef{ID: labelID, r: r}
+}
+
+// URLRef returns a reference to the entity linked by u, a discogs.com page or API resource URL. The returned value is
+// a *ReleaseRef, *MasterRef, *ArtistRef or *LabelRef.
+func (r *Resolver) URLRef(u string) (interface{}, error) {
+	kind, id, err := ParseURL(u)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case KindRelease:
+		return r.ReleaseRef(id), nil
+	case KindMaster:
+		return r.MasterRef(id), nil
+	case KindArtist:
+		return r.ArtistRef(id), nil
+	case KindLabel:
+		return r.LabelRef(id), nil
+	}
+	return nil, ErrInvalidURL
+}
+
+// linkedID returns id or, if it's unknown, the ID parsed from the resource URL linking the entity.
+func linkedID(id int, resourceURL string) int {
+	if id > 0 {
+		return id
+	}
+	if _, id, err := ParseURL(resourceURL); err == nil {
+		return id
+	}
+	return 0
+}
+
+// lazy runs a fetch function until it succeeds once.
+type lazy struct {
+	mu   sync.Mutex
+	done bool
+}
+
+func (l *lazy) load(f func() error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.done {
+		return nil
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	l.done = true
+	return nil
+}
+
+// ReleaseRef is a lazily fetched release.
+type ReleaseRef struct {
+	ID int
+
+	r       *Resolver
+	lazy    lazy
+	release *Release
+}
+
+// Get returns the release.
+func (ref *ReleaseRef) Get(ctx context.Context) (*Release, error) {
+	err := ref.lazy.load(func() error {
+		var err error
+		ref.release, err = ref.r.s.Release(ctx, ref.ID, ref.r.opts...)
+		return err
+	})
+	return ref.release, err
+}
+
+// MasterRef returns a reference to the master release of the release, or ErrNotFound if it has none.
+func (ref *ReleaseRef) MasterRef(ctx context.Context) (*MasterRef, error) {
+	release, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := linkedID(release.MasterID, release.MasterURL)
+	if id == 0 {
+		return nil, ErrNotFound
+	}
+	return ref.r.MasterRef(id), nil
+}
+
+// Master returns the master release of the release, or ErrNotFound if it has none.
+func (ref *ReleaseRef) Master(ctx context.Context) (*Master, error) {
+	master, err := ref.MasterRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return master.Get(ctx)
+}
+
+// Artists returns references to the main artists of the release.
+func (ref *ReleaseRef) Artists(ctx context.Context) ([]*ArtistRef, error) {
+	release, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ref.r.artistRefs(release.Artists), nil
+}
+
+// Labels returns references to the labels of the release.
+func (ref *ReleaseRef) Labels(ctx context.Context) ([]*LabelRef, error) {
+	release, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var labels []*LabelRef
+	for _, l := range release.Labels {
+		if id := linkedID(l.ID, l.ResourceURL); id != 0 {
+			labels = append(labels, ref.r.LabelRef(id))
+		}
+	}
+	return labels, nil
+}
+
+// MasterRef is a lazily fetched master release.
+type MasterRef struct {
+	ID int
+
+	r      *Resolver
+	lazy   lazy
+	master *Master
+}
+
+// Get returns the master release.
+func (ref *MasterRef) Get(ctx context.Context) (*Master, error) {
+	err := ref.lazy.load(func() error {
+		var err error
+		ref.master, err = ref.r.s.Master(ctx, ref.ID, ref.r.opts...)
+		return err
+	})
+	return ref.master, err
+}
+
+// MainRelease returns a reference to the main release of the master release.
+func (ref *MasterRef) MainRelease(ctx context.Context) (*ReleaseRef, error) {
+	master, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := linkedID(master.MainRelease, master.MainReleaseURL)
+	if id == 0 {
+		return nil, ErrNotFound
+	}
+	return ref.r.ReleaseRef(id), nil
+}
+
+// MostRecentRelease returns a reference to the most recent release of the master release.
+func (ref *MasterRef) MostRecentRelease(ctx context.Context) (*ReleaseRef, error) {
+	master, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := linkedID(master.MostRecentRelease, master.MostRecentReleaseURL)
+	if id == 0 {
+		return nil, ErrNotFound
+	}
+	return ref.r.ReleaseRef(id), nil
+}
+
+// Versions returns all versions of the master release.
+func (ref *MasterRef) Versions(ctx context.Context) ([]Version, error) {
+	return AllMasterVersions(ctx, ref.r.s, ref.ID, nil, 0, ref.r.opts...)
+}
+
+// Artists returns references to the main artists of the master release.
+func (ref *MasterRef) Artists(ctx context.Context) ([]*ArtistRef, error) {
+	master, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ref.r.artistRefs(master.Artists), nil
+}
+
+// ArtistRef is a lazily fetched artist.
+type ArtistRef struct {
+	ID int
+
+	r      *Resolver
+	lazy   lazy
+	artist *Artist
+}
+
+// Get returns the artist.
+func (ref *ArtistRef) Get(ctx context.Context) (*Artist, error) {
+	err := ref.lazy.load(func() error {
+		var err error
+		ref.artist, err = ref.r.s.Artist(ctx, ref.ID, ref.r.opts...)
+		return err
+	})
+	return ref.artist, err
+}
+
+// Releases returns all releases associated with the artist.
+func (ref *ArtistRef) Releases(ctx context.Context) ([]ReleaseSource, error) {
+	return AllArtistReleases(ctx, ref.r.s, ref.ID, nil, 0, ref.r.opts...)
+}
+
+func (r *Resolver) artistRefs(artists []ArtistSource) []*ArtistRef {
+	var refs []*ArtistRef
+	for _, a := range artists {
+		if id := linkedID(a.ID, a.ResourceURL); id != 0 {
+			refs = append(refs, r.ArtistRef(id))
+		}
+	}
+	return refs
+}
+
+// LabelRef is a lazily fetched label.
+type LabelRef struct {
+	ID int
+
+	r     *Resolver
+	lazy  lazy
+	label *Label
+}
+
+// Get returns the label.
+func (ref *LabelRef) Get(ctx context.Context) (*Label, error) {
+	err := ref.lazy.load(func() error {
+		var err error
+		ref.label, err = ref.r.s.Label(ctx, ref.ID, ref.r.opts...)
+		return err
+	})
+	return ref.label, err
+}
+
+// Releases returns all releases associated with the label.
+func (ref *LabelRef) Releases(ctx context.Context) ([]ReleaseSource, error) {
+	return AllLabelReleases(ctx, ref.r.s, ref.ID, nil, 0, ref.r.opts...)
+}
+
+// Parent returns a reference to the parent label of the label, or ErrNotFound if it has none.
+func (ref *LabelRef) Parent(ctx context.Context) (*LabelRef, error) {
+	label, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if label.ParentLabel == nil {
+		return nil, ErrNotFound
+	}
+	id := linkedID(label.ParentLabel.ID, label.ParentLabel.ResourceURL)
+	if id == 0 {
+		return nil, ErrNotFound
+	}
+	return ref.r.LabelRef(id), nil
+}
+
+// SubLabels returns references to the direct sublabels of the label.
+func (ref *LabelRef) SubLabels(ctx context.Context) ([]*LabelRef, error) {
+	label, err := ref.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var labels []*LabelRef
+	for _, sub := range label.Sublabels {
+		if id := linkedID(sub.ID, sub.ResourceURL); id != 0 {
+			labels = append(labels, ref.r.LabelRef(id))
+		}
+	}
+	return labels, nil
+}
@@ -0,0 +1,112 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// resolverDatabase serves the test release, master and artist and counts the requests made.
+type resolverDatabase struct {
+	DatabaseService
+	requests int
+}
+
+func (d *resolverDatabase) Release(ctx context.Context, releaseID int, opts ...RequestOption) (*Release, error) {
+	d.requests++
+	if releaseID != 8138518 {
+		return nil, ErrNotFound
+	}
+	var release *Release
+	err := json.Unmarshal([]byte(releaseJson), &release)
+	release.MasterID = 0
+	release.MasterURL = "https://api.discogs.com/masters/718441"
+	return release, err
+}
+
+func (d *resolverDatabase) Master(ctx context.Context, masterID int, opts ...RequestOption) (*Master, error) {
+	d.requests++
+	if masterID != 718441 {
+		return nil, ErrNotFound
+	}
+	var master *Master
+	err := json.Unmarshal([]byte(masterJson), &master)
+	return master, err
+}
+
+func (d *resolverDatabase) Artist(ctx context.Context, artistID int, opts ...RequestOption) (*Artist, error) {
+	d.requests++
+	if artistID != 38661 {
+		return nil, ErrNotFound
+	}
+	var artist *Artist
+	err := json.Unmarshal([]byte(artistJson), &artist)
+	return artist, err
+}
+
+func TestResolver(t *testing.T) {
+	db := &resolverDatabase{}
+	r := NewResolver(db)
+	ctx := context.Background()
+
+	release := r.ReleaseRef(8138518)
+	master, err := release.Master(ctx)
+	if err != nil {
+		t.Fatalf("failed to resolve master: %s", err)
+	}
+	if master.ID != 718441 {
+		t.Errorf("master got=%d; want=718441", master.ID)
+	}
+
+	labels, err := release.Labels(ctx)
+	if err != nil || len(labels) != 1 || labels[0].ID != 890477 {
+		t.Errorf("labels got=%v, %v; want=[890477]", labels, err)
+	}
+	if db.requests != 2 {
+		t.Errorf("requests got=%d; want=2", db.requests)
+	}
+
+	masterRef, err := release.MasterRef(ctx)
+	if err != nil {
+		t.Fatalf("failed to resolve master: %s", err)
+	}
+	main, err := masterRef.MainRelease(ctx)
+	if err != nil || main.ID != 3221262 {
+		t.Errorf("main release got=%v, %v; want=3221262", main, err)
+	}
+	recent, err := masterRef.MostRecentRelease(ctx)
+	if err != nil || recent.ID != 10670860 {
+		t.Errorf("most recent release got=%v, %v; want=10670860", recent, err)
+	}
+	artists, err := masterRef.Artists(ctx)
+	if err != nil || len(artists) != 1 {
+		t.Fatalf("artists got=%v, %v; want 1 artist", artists, err)
+	}
+	artist, err := artists[0].Get(ctx)
+	if err != nil || artist.Name != "Eminem" {
+		t.Errorf("artist got=%v, %v; want=Eminem", artist, err)
+	}
+	if db.requests != 4 {
+		t.Errorf("requests got=%d; want=4", db.requests)
+	}
+
+	if _, err := r.ReleaseRef(1).Master(ctx); err != ErrNotFound {
+		t.Errorf("err got=%v; want=%s", err, ErrNotFound)
+	}
+}
+
+func TestResolverURLRef(t *testing.T) {
+	r := NewResolver(&resolverDatabase{})
+
+	ref, err := r.URLRef("https://www.discogs.com/artist/38661-Eminem")
+	if err != nil {
+		t.Fatalf("failed to resolve url: %s", err)
+	}
+	if artist, ok := ref.(*ArtistRef); !ok || artist.ID != 38661 {
+		t.Errorf("ref got=%#v; want artist 38661", ref)
+	}
+
+	if _, err := r.URLRef("https://www.discogs.com/sell/item/172723812"); err != ErrInvalidURL {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidURL)
+	}
+}
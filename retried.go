@@ -0,0 +1,16 @@
+package discogs
+
+import "context"
+
+// Retry returns d with all functions replaced with versions that retry failed requests per policy. Calls that aren't
+// idempotent, such as UploadInventory, whose CSV file is consumed by the first attempt, or Do with POST, are made once,
+// as a failed attempt may still have been processed. Clients derived with WithToken or WithOAuth retry per policy as
+// well.
+func Retry(d Discogs, policy RetryPolicy) Discogs {
+	return Intercept(d, func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		if !call.Idempotent {
+			return invoke(ctx)
+		}
+		return policy.Call(ctx, func() error { return invoke(ctx) })
+	})
+}
@@ -0,0 +1,113 @@
+package discogs
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy configures how Retry retries failed requests. The zero value retries server errors and network errors
+// up to three times in total, waiting one second before the first retry and doubling the delay for every following one.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one (default 3).
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry (default 1s).
+	InitialDelay time.Duration
+	// Multiplier is the factor the delay is multiplied with after every retry (default 2).
+	Multiplier float64
+	// MaxDelay caps the delay between retries (optional).
+	MaxDelay time.Duration
+	// Jitter randomizes every delay by up to the given fraction of it, e.g. 0.2 for ±20% (optional).
+	Jitter float64
+	// RetryStatus reports whether a response with the given status code should be retried
+	// (default is to retry 5xx responses).
+	RetryStatus func(statusCode int) bool
+}
+
+// retryable reports whether err should be retried according to the policy.
+func (p RetryPolicy) retryable(err error) bool {
+	if code, ok := statusCode(err); ok {
+		if p.RetryStatus != nil {
+			return p.RetryStatus(code)
+		}
+		return code >= http.StatusInternalServerError
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// delay returns the delay before the given retry, the first retry being number 1.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	initial := p.InitialDelay
+	if initial <= 0 {
+		initial = time.Second
+	}
+	return backoff(initial, p.Multiplier, p.MaxDelay, p.Jitter, retry)
+}
+
+// backoff returns the delay before the given retry, the first retry being number 1, when the delay starts at initial
+// and is multiplied with multiplier (default 2) after every retry, capped at max if it's greater than zero and
+// randomized by up to the fraction jitter of it.
+func backoff(initial time.Duration, multiplier float64, max time.Duration, jitter float64, retry int) time.Duration {
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	d := initial
+	for i := 1; i < retry && (max <= 0 || d < max); i++ {
+		d = time.Duration(float64(d) * multiplier)
+	}
+	if max > 0 && d > max {
+		d = max
+	}
+
+	if jitter > 0 {
+		d += time.Duration(float64(d) * jitter * (2*rand.Float64() - 1))
+	}
+	return d
+}
+
+// Call invokes f() and repeats the call with backoff while it returns a retryable error, until the maximum number of
+// attempts is reached.
+func (p RetryPolicy) Call(ctx context.Context, f func() error) error {
+	t := time.NewTimer(time.Minute)
+	t.Stop()
+	defer t.Stop()
+
+	sleep := func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		t.Reset(d)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			return nil
+		}
+	}
+
+	return p.call(ctx, f, sleep)
+}
+
+// call is the inner implementation of Call which accepts a sleep function that can be mocked during testing.
+func (p RetryPolicy) call(ctx context.Context, f func() error, sleep func(context.Context, time.Duration) error) error {
+	attempts := p.MaxAttempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= attempts || !p.retryable(err) {
+			return err
+		}
+		if err := sleep(ctx, p.delay(attempt)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Call(t *testing.T) {
+	ctx := context.Background()
+	serverErr := &StatusError{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"}
+	networkErr := &url.Error{Op: "Get", URL: "https://api.discogs.com", Err: io.ErrUnexpectedEOF}
+
+	tests := []struct {
+		name          string
+		policy        RetryPolicy
+		attempts      []error
+		expectErr     error
+		expectDelay   time.Duration
+		expectAttempt int
+	}{
+		{"success", RetryPolicy{}, []error{nil}, nil, 0, 1},
+		{"not retryable", RetryPolicy{}, []error{ErrNotFound}, ErrNotFound, 0, 1},
+		{"server error", RetryPolicy{}, []error{serverErr, nil}, nil, time.Second, 2},
+		{"network error", RetryPolicy{}, []error{networkErr, networkErr, nil}, nil, 3 * time.Second, 3},
+		{"max attempts", RetryPolicy{}, []error{serverErr, serverErr, serverErr}, serverErr, 3 * time.Second, 3},
+		{"custom backoff", RetryPolicy{MaxAttempts: 4, InitialDelay: time.Millisecond, Multiplier: 10, MaxDelay: 50 * time.Millisecond}, []error{serverErr, serverErr, serverErr, nil}, nil, 61 * time.Millisecond, 4},
+		{"custom status", RetryPolicy{RetryStatus: func(code int) bool { return code == http.StatusTooManyRequests }}, []error{ErrTooManyRequests, nil}, nil, time.Second, 2},
+		{"custom status excludes server error", RetryPolicy{RetryStatus: func(code int) bool { return code == http.StatusTooManyRequests }}, []error{serverErr}, serverErr, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := tt.attempts[:]
+			attempt := 0
+			slept := time.Duration(0)
+
+			request := func() error {
+				attempt++
+				err := attempts[0]
+				attempts = attempts[1:]
+				return err
+			}
+
+			sleep := func(ctx context.Context, duration time.Duration) error {
+				slept += duration
+				return nil
+			}
+
+			err := tt.policy.call(ctx, request, sleep)
+
+			if err != tt.expectErr {
+				t.Errorf("Expected error %v, got error %v", tt.expectErr, err)
+			}
+			if slept != tt.expectDelay {
+				t.Errorf("Expected delay %v, got delay %v", tt.expectDelay.String(), slept.String())
+			}
+			if attempt != tt.expectAttempt {
+				t.Errorf("Expected %d attempts, got %d attempts", tt.expectAttempt, attempt)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	d := Retry(initDiscogsClient(t, &Options{URL: ts.URL}), RetryPolicy{InitialDelay: time.Millisecond})
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests got=%d; want=2", requests)
+	}
+}
@@ -0,0 +1,199 @@
+package discogs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// SchedulerConfig configures a Scheduler. Zero values select the defaults.
+type SchedulerConfig struct {
+	// MaxInFlight is the maximum number of requests sent at the same time (default 1).
+	MaxInFlight int
+	// MaxQueued is the maximum number of requests of a single caller waiting to be sent, beyond which requests fail
+	// with ErrQueueFull (optional, unlimited by default).
+	MaxQueued int
+}
+
+// SchedulerStats describes the requests handled by a Scheduler at a point in time.
+type SchedulerStats struct {
+	InFlight int            // The number of requests being sent.
+	Queued   int            // The number of requests waiting to be sent.
+	Callers  map[string]int // The number of requests waiting to be sent by caller.
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx identifying the requests made with it as the given caller's, e.g. a user or a job,
+// for the fair scheduling of a Scheduler. Requests without a caller share the queue of the empty caller.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// Scheduler coordinates the requests of all clients and goroutines sharing it, sending at most a maximum number of
+// them at the same time. Waiting requests are queued per caller and the queues take turns, so a caller making many
+// requests, such as a bulk job, doesn't hold up the others, which is a step beyond RateLimit.Call pacing each call on
+// its own. A Scheduler is added to clients as middleware and is safe for concurrent use.
+type Scheduler struct {
+	cfg SchedulerConfig
+
+	mu       sync.Mutex
+	inFlight int
+	queued   int
+	queues   map[string][]*ticket
+	turns    []string // callers with queued requests in the order of their turns
+}
+
+// ticket is a queued request, whose ready channel is closed when it may be sent.
+type ticket struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// NewScheduler returns a Scheduler configured by cfg.
+func NewScheduler(cfg SchedulerConfig) *Scheduler {
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = 1
+	}
+	return &Scheduler{
+		cfg:    cfg,
+		queues: make(map[string][]*ticket),
+	}
+}
+
+// Middleware returns middleware sending every request through the scheduler, e.g. for Options.Middleware or
+// WithMiddleware. A request occupies its slot until its response body is closed.
+func (s *Scheduler) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			caller, _ := r.Context().Value(callerKey{}).(string)
+			if err := s.acquire(r.Context(), caller); err != nil {
+				return nil, err
+			}
+			resp, err := next(r)
+			if err != nil || resp == nil || resp.Body == nil {
+				s.release()
+				return resp, err
+			}
+			resp.Body = &releaseBody{ReadCloser: resp.Body, release: s.release}
+			return resp, nil
+		}
+	}
+}
+
+// Stats returns the number of requests being sent and waiting to be sent.
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := SchedulerStats{InFlight: s.inFlight, Queued: s.queued, Callers: make(map[string]int, len(s.queues))}
+	for caller, q := range s.queues {
+		stats.Callers[caller] = len(q)
+	}
+	return stats
+}
+
+// acquire waits until a request of caller may be sent and occupies a slot for it.
+func (s *Scheduler) acquire(ctx context.Context, caller string) error {
+	s.mu.Lock()
+	if s.inFlight < s.cfg.MaxInFlight && s.queued == 0 {
+		s.inFlight++
+		s.mu.Unlock()
+		return nil
+	}
+	if s.cfg.MaxQueued > 0 && len(s.queues[caller]) >= s.cfg.MaxQueued {
+		s.mu.Unlock()
+		return ErrQueueFull
+	}
+	t := &ticket{ready: make(chan struct{})}
+	if len(s.queues[caller]) == 0 {
+		s.turns = append(s.turns, caller)
+	}
+	s.queues[caller] = append(s.queues[caller], t)
+	s.queued++
+	s.mu.Unlock()
+
+	select {
+	case <-t.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.granted {
+		// the slot was granted while the context was canceled, so pass it on
+		s.inFlight--
+		s.dispatch()
+	} else {
+		s.remove(caller, t)
+	}
+	return ctx.Err()
+}
+
+// release frees the slot of a request and grants it to the next queued one.
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inFlight--
+	s.dispatch()
+}
+
+// dispatch grants the free slots to the queued requests, taking one request of each caller in turn. It must be called
+// with the lock held.
+func (s *Scheduler) dispatch() {
+	for s.inFlight < s.cfg.MaxInFlight && len(s.turns) > 0 {
+		caller := s.turns[0]
+		s.turns = s.turns[1:]
+		q := s.queues[caller]
+		t := q[0]
+		if len(q) > 1 {
+			s.queues[caller] = q[1:]
+			s.turns = append(s.turns, caller)
+		} else {
+			delete(s.queues, caller)
+		}
+		s.queued--
+		s.inFlight++
+		t.granted = true
+		close(t.ready)
+	}
+}
+
+// remove removes the queued request t of caller. It must be called with the lock held.
+func (s *Scheduler) remove(caller string, t *ticket) {
+	q := s.queues[caller]
+	for i := range q {
+		if q[i] == t {
+			q = append(q[:i:i], q[i+1:]...)
+			s.queued--
+			break
+		}
+	}
+	if len(q) > 0 {
+		s.queues[caller] = q
+		return
+	}
+	delete(s.queues, caller)
+	for i, c := range s.turns {
+		if c == caller {
+			s.turns = append(s.turns[:i:i], s.turns[i+1:]...)
+			break
+		}
+	}
+}
+
+// releaseBody releases the slot of a request once its response body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,130 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// waitQueued waits until n requests are queued by s.
+func waitQueued(t *testing.T, s *Scheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for s.Stats().Queued != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("queued got=%d; want=%d", s.Stats().Queued, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerFairness(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{MaxInFlight: 1})
+	ctx := context.Background()
+	if err := s.acquire(ctx, "other"); err != nil {
+		t.Fatalf("failed to acquire: %s", err)
+	}
+
+	granted := make(chan string)
+	queue := func(caller, name string, n int) {
+		go func() {
+			if err := s.acquire(ctx, caller); err != nil {
+				t.Errorf("failed to acquire: %s", err)
+				return
+			}
+			granted <- name
+		}()
+		waitQueued(t, s, n)
+	}
+	queue("bulk", "bulk 1", 1)
+	queue("bulk", "bulk 2", 2)
+	queue("bulk", "bulk 3", 3)
+	queue("user", "user 1", 4)
+
+	stats := s.Stats()
+	want := SchedulerStats{InFlight: 1, Queued: 4, Callers: map[string]int{"bulk": 3, "user": 1}}
+	if !cmp.Equal(stats, want) {
+		t.Errorf("stats got=%+v; want=%+v", stats, want)
+	}
+
+	var order []string
+	for i := 0; i < 4; i++ {
+		s.release()
+		order = append(order, <-granted)
+	}
+	if want := []string{"bulk 1", "user 1", "bulk 2", "bulk 3"}; !cmp.Equal(order, want) {
+		t.Errorf("order got=%v; want=%v", order, want)
+	}
+}
+
+func TestSchedulerQueue(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{MaxInFlight: 1, MaxQueued: 1})
+	if err := s.acquire(context.Background(), ""); err != nil {
+		t.Fatalf("failed to acquire: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.acquire(ctx, "")
+	}()
+	waitQueued(t, s, 1)
+
+	if err := s.acquire(context.Background(), ""); err != ErrQueueFull {
+		t.Errorf("err got=%v; want=%s", err, ErrQueueFull)
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("err got=%v; want=%s", err, context.Canceled)
+	}
+	if stats := s.Stats(); stats.InFlight != 1 || stats.Queued != 0 {
+		t.Errorf("stats got=%+v; want 1 in flight, none queued", stats)
+	}
+}
+
+func TestSchedulerMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		DatabaseServer(w, r)
+	}))
+	defer ts.Close()
+
+	s := NewScheduler(SchedulerConfig{MaxInFlight: 2})
+	d := initDiscogsClient(t, &Options{URL: ts.URL, Middleware: []Middleware{s.Middleware()}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := WithCaller(context.Background(), []string{"a", "b"}[i%2])
+			if _, err := d.Release(ctx, 8138518); err != nil {
+				t.Errorf("failed to get release: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("max in flight got=%d; want<=2", maxInFlight)
+	}
+	if stats := s.Stats(); stats.InFlight != 0 || stats.Queued != 0 {
+		t.Errorf("stats got=%+v; want none in flight or queued", stats)
+	}
+}
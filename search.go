@@ -2,8 +2,10 @@ package discogs
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // SearchService is an interface to work with search.
@@ -12,7 +14,7 @@ type SearchService interface {
 	// Issue a search query to database. This endpoint accepts pagination parameters.
 	// Authentication (as any user) is required.
 	// https://www.discogs.com/developers/#page:database,header:database-search
-	Search(ctx context.Context, req SearchRequest) (*Search, error)
+	Search(ctx context.Context, req SearchRequest, opts ...RequestOption) (*Search, error)
 }
 
 // searchService ...
@@ -28,43 +30,87 @@ func newSearchService(req requestFunc, url string) SearchService {
 	}
 }
 
+// SearchType is the type of item a search is restricted to.
+type SearchType string
+
+// Search types.
+const (
+	SearchTypeRelease SearchType = "release"
+	SearchTypeMaster  SearchType = "master"
+	SearchTypeArtist  SearchType = "artist"
+	SearchTypeLabel   SearchType = "label"
+)
+
 // SearchRequest describes search request
 type SearchRequest struct {
-	Q            string // search query
-	Type         string // one of release, master, artist, label
-	Title        string // search by combined “Artist Name - Release Title” title field
-	ReleaseTitle string // search release titles
-	Credit       string // search release credits
-	Artist       string // search artist names
-	Anv          string // search artist ANV
-	Label        string // search label names
-	Genre        string // search genres
-	Style        string // search styles
-	Country      string // search release country
-	Year         string // search release year
-	Format       string // search formats
-	Catno        string // search catalog number
-	Barcode      string // search barcodes
-	Track        string // search track titles
-	Submitter    string // search submitter username
-	Contributor  string // search contributor usernames
+	Q            string     // search query
+	Type         SearchType // one of release, master, artist, label
+	Title        string     // search by combined “Artist Name - Release Title” title field
+	ReleaseTitle string     // search release titles
+	Credit       string     // search release credits
+	Artist       string     // search artist names
+	Anv          string     // search artist ANV
+	Label        string     // search label names
+	Genre        string     // search genres
+	Style        string     // search styles
+	Country      string     // search release country
+	Year         string     // search release year
+	Format       string     // search formats
+	Catno        string     // search catalog number
+	Barcode      string     // search barcodes
+	Track        string     // search track titles
+	Submitter    string     // search submitter username
+	Contributor  string     // search contributor usernames
 
+	// NormalizeCountry maps Country to the name Discogs uses with NormalizeCountry, e.g. "United Kingdom" to "UK", as
+	// searches for other names silently find nothing.
+	NormalizeCountry bool
+
+	// Page and PerPage select the page of results like Pagination, defaulting to the first page of 50 results.
+	// A negative Page fails with ErrInvalidPagination and a negative PerPage requests a single result. A PerPage
+	// above the maximum of 100 is limited to it when the request is sent, with a warning through Options.Warnf.
 	Page    int
 	PerPage int
 }
 
+// Validate checks the request for values Discogs doesn't accept.
+func (r *SearchRequest) Validate() error {
+	switch r.Type {
+	case "", SearchTypeRelease, SearchTypeMaster, SearchTypeArtist, SearchTypeLabel:
+	default:
+		return ErrInvalidSearchType
+	}
+
+	if r.Year != "" {
+		if len(r.Year) != 4 {
+			return ErrInvalidYear
+		}
+		for _, c := range r.Year {
+			if c < '0' || c > '9' {
+				return ErrInvalidYear
+			}
+		}
+	}
+
+	if r.Page < 0 {
+		return ErrInvalidPagination
+	}
+	return nil
+}
+
 func (r *SearchRequest) params() url.Values {
 	if r == nil {
 		return nil
 	}
 
-	params := url.Values{}
+	// sized for typical requests, sparing the map from growing as parameters are set
+	params := make(url.Values, 8)
 
 	if r.Q != "" {
 		params.Set("q", r.Q)
 	}
 	if r.Type != "" {
-		params.Set("type", r.Type)
+		params.Set("type", string(r.Type))
 	}
 	if r.Title != "" {
 		params.Set("title", r.Title)
@@ -91,7 +137,11 @@ func (r *SearchRequest) params() url.Values {
 		params.Set("style", r.Style)
 	}
 	if r.Country != "" {
-		params.Set("country", r.Country)
+		country := r.Country
+		if r.NormalizeCountry {
+			country = NormalizeCountry(country)
+		}
+		params.Set("country", country)
 	}
 	if r.Year != "" {
 		params.Set("year", r.Year)
@@ -115,41 +165,152 @@ func (r *SearchRequest) params() url.Values {
 		params.Set("contributor", r.Contributor)
 	}
 
-	params.Set("page", strconv.Itoa(r.Page))
-	if r.PerPage != 0 {
-		params.Set("per_page", strconv.Itoa(r.PerPage))
-	}
+	params.Set("page", strconv.Itoa(normalizePage(r.Page)))
+	params.Set("per_page", strconv.Itoa(normalizePerPage(r.PerPage)))
 	return params
 }
 
 // Search describes search response
 type Search struct {
-	Pagination Page     `json:"pagination"`
-	Results    []Result `json:"results,omitempty"`
+	Pagination Page    `json:"pagination"`
+	Results    Results `json:"results,omitempty"`
+}
+
+// Results is a list of search results, which may mix artists, labels, masters and releases.
+type Results []Result
+
+// Releases returns the results which are releases.
+func (r Results) Releases() Results {
+	return r.ofType(SearchTypeRelease)
+}
+
+// Masters returns the results which are master releases.
+func (r Results) Masters() Results {
+	return r.ofType(SearchTypeMaster)
+}
+
+// Artists returns the results which are artists.
+func (r Results) Artists() Results {
+	return r.ofType(SearchTypeArtist)
+}
+
+// Labels returns the results which are labels.
+func (r Results) Labels() Results {
+	return r.ofType(SearchTypeLabel)
+}
+
+func (r Results) ofType(t SearchType) Results {
+	var results Results
+	for _, result := range r {
+		if result.Type == t {
+			results = append(results, result)
+		}
+	}
+	return results
 }
 
 // Result describes a part of search result
 type Result struct {
-	Style       []string  `json:"style,omitempty"`
-	Thumb       string    `json:"thumb,omitempty"`
-	CoverImage  string    `json:"cover_image,omitempty"`
-	Title       string    `json:"title,omitempty"`
-	Country     string    `json:"country,omitempty"`
-	Format      []string  `json:"format,omitempty"`
-	URI         string    `json:"uri,omitempty"`
-	Community   Community `json:"community,omitempty"`
-	Label       []string  `json:"label,omitempty"`
-	Catno       string    `json:"catno,omitempty"`
-	Year        string    `json:"year,omitempty"`
-	Genre       []string  `json:"genre,omitempty"`
-	ResourceURL string    `json:"resource_url,omitempty"`
-	Type        string    `json:"type,omitempty"`
-	ID          int       `json:"id,omitempty"`
-	MasterID    int       `json:"master_id,omitempty"`
-}
-
-func (s *searchService) Search(ctx context.Context, req SearchRequest) (*Search, error) {
+	Style       []string   `json:"style,omitempty"`
+	Thumb       string     `json:"thumb,omitempty"`
+	CoverImage  string     `json:"cover_image,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Country     string     `json:"country,omitempty"`
+	Format      []string   `json:"format,omitempty"`
+	URI         string     `json:"uri,omitempty"`
+	Community   Community  `json:"community,omitempty"`
+	Label       []string   `json:"label,omitempty"`
+	Catno       string     `json:"catno,omitempty"`
+	Year        string     `json:"year,omitempty"`
+	Genre       []string   `json:"genre,omitempty"`
+	ResourceURL string     `json:"resource_url,omitempty"`
+	Type        SearchType `json:"type,omitempty"`
+	ID          int        `json:"id,omitempty"`
+	MasterID    int        `json:"master_id,omitempty"`
+}
+
+func (s *searchService) Search(ctx context.Context, req SearchRequest, opts ...RequestOption) (*Search, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var search *Search
-	err := s.request(ctx, s.url, req.params(), &search)
+	err := s.request(ctx, s.url, req.params(), &search, opts...)
 	return search, err
 }
+
+// SearchByBarcode returns the releases with the given barcode, fetching all pages of results.
+func SearchByBarcode(ctx context.Context, s SearchService, barcode string, opts ...RequestOption) (Results, error) {
+	if barcode == "" {
+		return nil, ErrInvalidBarcode
+	}
+	results, err := AllSearch(ctx, s, SearchRequest{Type: SearchTypeRelease, Barcode: barcode}, 0, opts...)
+	return Results(results).Releases(), err
+}
+
+// SearchByCatNo returns the releases with the given catalog number, fetching all pages of results. The label is
+// optional and narrows down the search to releases on labels matching it.
+func SearchByCatNo(ctx context.Context, s SearchService, label, catno string, opts ...RequestOption) (Results, error) {
+	if catno == "" {
+		return nil, ErrInvalidCatno
+	}
+	results, err := AllSearch(ctx, s, SearchRequest{Type: SearchTypeRelease, Label: label, Catno: catno}, 0, opts...)
+	return Results(results).Releases(), err
+}
+
+// TrackMatch is a track found on a release by SearchTrack.
+type TrackMatch struct {
+	Release *Release
+	Track   Track
+}
+
+// SearchTrack returns the tracks titled track on the releases found by searching for the track title, with sub tracks
+// taken into account. The artist is optional and narrows down the search to releases by artists matching it. As every
+// release found is fetched to locate the track on it, limit caps the number of releases fetched and stops the search
+// once as many have been found; zero fetches all. Releases that aren't found or weren't accepted are skipped.
+func SearchTrack(ctx context.Context, d Discogs, artist, track string, limit int, opts ...RequestOption) ([]TrackMatch, error) {
+	if strings.TrimSpace(track) == "" {
+		return nil, ErrInvalidTrack
+	}
+
+	var ids []int
+	seen := make(map[int]bool)
+	it := SearchIter(ctx, d, SearchRequest{Type: SearchTypeRelease, Artist: artist, Track: track}, opts...)
+	for (limit <= 0 || len(ids) < limit) && it.Next() {
+		result := it.Item()
+		if result.Type != SearchTypeRelease || seen[result.ID] {
+			continue
+		}
+		seen[result.ID] = true
+		ids = append(ids, result.ID)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	var matches []TrackMatch
+	for _, id := range ids {
+		release, err := d.Release(ctx, id, opts...)
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrReleaseNotAccepted) {
+			continue
+		}
+		if err != nil {
+			return matches, err
+		}
+		for _, t := range matchingTracks(release.Tracklist, track) {
+			matches = append(matches, TrackMatch{Release: release, Track: t})
+		}
+	}
+	return matches, nil
+}
+
+// matchingTracks returns the tracks and sub tracks of the tracklist titled title, ignoring case and surrounding spaces.
+func matchingTracks(tracklist []Track, title string) []Track {
+	var tracks []Track
+	for _, t := range tracklist {
+		if strings.EqualFold(strings.TrimSpace(t.Title), strings.TrimSpace(title)) {
+			tracks = append(tracks, t)
+		}
+		tracks = append(tracks, matchingTracks(t.SubTracks, title)...)
+	}
+	return tracks
+}
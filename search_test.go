@@ -0,0 +1,273 @@
+package discogs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSearchRequestValidate(t *testing.T) {
+	tests := map[string]struct {
+		req SearchRequest
+		err error
+	}{
+		"empty":            {SearchRequest{}, nil},
+		"release":          {SearchRequest{Type: SearchTypeRelease, Artist: "reggaenauts", Year: "2017"}, nil},
+		"master":           {SearchRequest{Type: SearchTypeMaster}, nil},
+		"artist":           {SearchRequest{Type: SearchTypeArtist}, nil},
+		"label":            {SearchRequest{Type: SearchTypeLabel}, nil},
+		"invalid type":     {SearchRequest{Type: "track"}, ErrInvalidSearchType},
+		"short year":       {SearchRequest{Year: "96"}, ErrInvalidYear},
+		"non numeric year": {SearchRequest{Year: "19x6"}, ErrInvalidYear},
+		"negative page":    {SearchRequest{Page: -1}, ErrInvalidPagination},
+		"per page too big": {SearchRequest{PerPage: 101}, nil},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			if err := tt.req.Validate(); err != tt.err {
+				t.Errorf("err got=%v; want=%v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSearchRequestParams(t *testing.T) {
+	req := SearchRequest{Type: SearchTypeRelease, ReleaseTitle: "river rock", Catno: "AR 0001", PerPage: 5}
+	params := req.params()
+
+	want := map[string]string{
+		"type":          "release",
+		"release_title": "river rock",
+		"catno":         "AR 0001",
+		"page":          "1",
+		"per_page":      "5",
+	}
+	if len(params) != len(want) {
+		t.Errorf("params got=%v; want=%v", params, want)
+	}
+	for key, value := range want {
+		if params.Get(key) != value {
+			t.Errorf("param %s got=%q; want=%q", key, params.Get(key), value)
+		}
+	}
+}
+
+func TestSearchRequestNormalizeCountry(t *testing.T) {
+	req := SearchRequest{Country: "United Kingdom"}
+	if country := req.params().Get("country"); country != "United Kingdom" {
+		t.Errorf("country got=%q; want=%q", country, "United Kingdom")
+	}
+	req.NormalizeCountry = true
+	if country := req.params().Get("country"); country != "UK" {
+		t.Errorf("normalized country got=%q; want=%q", country, "UK")
+	}
+}
+
+func TestResultsByType(t *testing.T) {
+	results := Results{
+		{ID: 11162127, Type: SearchTypeRelease},
+		{ID: 1244466, Type: SearchTypeMaster},
+		{ID: 5604785, Type: SearchTypeArtist},
+		{ID: 11162128, Type: SearchTypeRelease},
+		{ID: 1, Type: SearchTypeLabel},
+	}
+
+	tests := map[string]struct {
+		got  Results
+		want []int
+	}{
+		"releases": {results.Releases(), []int{11162127, 11162128}},
+		"masters":  {results.Masters(), []int{1244466}},
+		"artists":  {results.Artists(), []int{5604785}},
+		"labels":   {results.Labels(), []int{1}},
+	}
+
+	for name, tt := range tests {
+		if len(tt.got) != len(tt.want) {
+			t.Errorf("%s got=%v; want ids %v", name, tt.got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if tt.got[i].ID != tt.want[i] {
+				t.Errorf("%s got=%v; want ids %v", name, tt.got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSearchByBarcodeAndCatNo(t *testing.T) {
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, `{"pagination": {"page": 1, "pages": 1}, "results": [{"id": 11162127, "type": "release"}, {"id": 1244466, "type": "master"}]}`); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	results, err := SearchByBarcode(ctx, d, "4607053460238")
+	if err != nil {
+		t.Fatalf("failed to search by barcode: %s", err)
+	}
+	if len(results) != 1 || results[0].ID != 11162127 {
+		t.Errorf("results got=%v; want release 11162127", results)
+	}
+	if query.Get("barcode") != "4607053460238" || query.Get("type") != "release" {
+		t.Errorf("query got=%v", query)
+	}
+
+	if _, err := SearchByCatNo(ctx, d, "Artless Records", "AR 0001"); err != nil {
+		t.Fatalf("failed to search by catalog number: %s", err)
+	}
+	if query.Get("catno") != "AR 0001" || query.Get("label") != "Artless Records" || query.Get("type") != "release" {
+		t.Errorf("query got=%v", query)
+	}
+
+	if _, err := SearchByBarcode(ctx, d, ""); err != ErrInvalidBarcode {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidBarcode)
+	}
+	if _, err := SearchByCatNo(ctx, d, "", ""); err != ErrInvalidCatno {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidCatno)
+	}
+}
+
+func TestSearchTrack(t *testing.T) {
+	var query url.Values
+	searches := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/database/search":
+			query = r.URL.Query()
+			searches++
+			if query.Get("page") == "2" {
+				body = `{"pagination": {"page": 2, "pages": 2}, "results": [{"id": 5, "type": "release"}]}`
+				break
+			}
+			body = `{"pagination": {"page": 1, "pages": 2}, "results": [{"id": 1, "type": "release"}, {"id": 2, "type": "release"}, {"id": 1, "type": "release"}, {"id": 3, "type": "master"}, {"id": 4, "type": "release"}]}`
+		case "/releases/5":
+			body = `{"id": 5, "status": "Draft", "tracklist": [{"position": "A1", "title": "Water Taxi"}]}`
+		case "/releases/1":
+			body = `{"id": 1, "tracklist": [{"position": "A1", "title": "Water Taxi"}, {"position": "A2", "title": "Misterioso"}]}`
+		case "/releases/2":
+			body = `{"id": 2, "tracklist": [{"position": "", "type_": "index", "title": "Suite", "sub_tracks": [{"position": "1a", "title": "water taxi "}]}]}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := io.WriteString(w, body); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	matches, err := SearchTrack(ctx, d, "St. Petersburg Ska-Jazz Review", "Water Taxi", 0)
+	if err != nil {
+		t.Fatalf("failed to search track: %s", err)
+	}
+	if query.Get("track") != "Water Taxi" || query.Get("artist") != "St. Petersburg Ska-Jazz Review" || query.Get("type") != "release" {
+		t.Errorf("query got=%v", query)
+	}
+	if searches != 2 {
+		t.Errorf("search requests got=%d; want=2", searches)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("matches got=%d; want=2", len(matches))
+	}
+	if matches[0].Release.ID != 1 || matches[0].Track.Position != "A1" {
+		t.Errorf("match got=%d %s; want=1 A1", matches[0].Release.ID, matches[0].Track.Position)
+	}
+	if matches[1].Release.ID != 2 || matches[1].Track.Position != "1a" {
+		t.Errorf("match got=%d %s; want=2 1a", matches[1].Release.ID, matches[1].Track.Position)
+	}
+
+	searches = 0
+	matches, err = SearchTrack(ctx, d, "", "Water Taxi", 1)
+	if err != nil || len(matches) != 1 {
+		t.Errorf("limited matches got=%d, %v; want=1", len(matches), err)
+	}
+	if searches != 1 {
+		t.Errorf("limited search requests got=%d; want=1", searches)
+	}
+
+	if _, err := SearchTrack(ctx, d, "", " ", 0); err != ErrInvalidTrack {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidTrack)
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	result := `{"id": 11162127, "type": "release", "title": "Reggaenauts - River Rock", "country": "US", "year": "2017", ` +
+		`"format": ["Vinyl", "7\"", "45 RPM"], "label": ["Artless Records"], "genre": ["Reggae"], "style": ["Rocksteady"], ` +
+		`"catno": "AR 0001", "barcode": ["4607053460238"], "thumb": "", "cover_image": "", ` +
+		`"resource_url": "https://api.discogs.com/releases/11162127", "community": {"want": 12, "have": 34}}`
+	body := `{"pagination": {"per_page": 50, "items": 50, "page": 1, "pages": 1, "urls": {}}, "results": [` +
+		strings.Repeat(result+", ", 49) + result + `]}`
+	roundTrip := func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	}
+	d := newClient(Options{UserAgent: testUserAgent, Token: testToken}, roundTrip)
+	req := SearchRequest{Type: SearchTypeRelease, Artist: "reggaenauts", Title: "river rock", Year: "2017", PerPage: 50}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Search(ctx, req); err != nil {
+			b.Fatalf("failed to search: %s", err)
+		}
+	}
+}
+
+// BenchmarkSearchGzip measures the responses as Discogs sends them, gzipped and thus of unknown length once
+// decompressed, which are decoded as they're read rather than through a pooled buffer.
+func BenchmarkSearchGzip(b *testing.B) {
+	result := `{"id": 11162127, "type": "release", "title": "Reggaenauts - River Rock", "country": "US", "year": "2017", ` +
+		`"format": ["Vinyl", "7\"", "45 RPM"], "label": ["Artless Records"], "genre": ["Reggae"], "style": ["Rocksteady"], ` +
+		`"catno": "AR 0001", "barcode": ["4607053460238"], "thumb": "", "cover_image": "", ` +
+		`"resource_url": "https://api.discogs.com/releases/11162127", "community": {"want": 12, "have": 34}}`
+	body := `{"pagination": {"per_page": 50, "items": 50, "page": 1, "pages": 1, "urls": {}}, "results": [` +
+		strings.Repeat(result+", ", 49) + result + `]}`
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	if _, err := io.WriteString(w, body); err != nil {
+		b.Fatalf("failed to compress body: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		b.Fatalf("failed to compress body: %s", err)
+	}
+	roundTrip := func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Content-Encoding": []string{"gzip"}},
+			ContentLength: int64(gz.Len()),
+			Body:          ioutil.NopCloser(bytes.NewReader(gz.Bytes())),
+		}, nil
+	}
+	d := newClient(Options{UserAgent: testUserAgent, Token: testToken}, roundTrip)
+	req := SearchRequest{Type: SearchTypeRelease, Artist: "reggaenauts", Title: "river rock", Year: "2017", PerPage: 50}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Search(ctx, req); err != nil {
+			b.Fatalf("failed to search: %s", err)
+		}
+	}
+}
@@ -6,8 +6,12 @@ const masterJson = `{"styles": ["Hardcore Hip-Hop", "Boom Bap"], "genres": ["Hip
 
 const releaseJson = `{"styles": ["Ska", "Reggae"], "videos": [{"duration": 301, "description": "ST.PETERSBURG SKA JAZZ REVIEW - WATER TAXI (BalconyTV)", "embed": true, "uri": "https://www.youtube.com/watch?v=i4_kwCTrTRs", "title": "ST.PETERSBURG SKA JAZZ REVIEW - WATER TAXI (BalconyTV)"}, {"duration": 292, "description": "St.Petersburg Ska-Jazz Review  - Action Movie", "embed": true, "uri": "https://www.youtube.com/watch?v=IaQA8uiZUUc", "title": "St.Petersburg Ska-Jazz Review  - Action Movie"}, {"duration": 320, "description": "St.Petersburg Ska-Jazz Review - Misterioso", "embed": true, "uri": "https://www.youtube.com/watch?v=2u5UtZNXugc", "title": "St.Petersburg Ska-Jazz Review - Misterioso"}, {"duration": 209, "description": "St.Petersburg Ska-Jazz Review - Perfidia", "embed": true, "uri": "https://www.youtube.com/watch?v=s3m6QY_JKnE", "title": "St.Petersburg Ska-Jazz Review - Perfidia"}, {"duration": 201, "description": "St.Petersburg Ska-Jazz Review - Volga River Boat Man", "embed": true, "uri": "https://www.youtube.com/watch?v=d-I-4O6JrMs", "title": "St.Petersburg Ska-Jazz Review - Volga River Boat Man"}], "series": [{"name": "Original Jazz Classics", "entity_type": "2", "catno": "", "resource_url": "https://api.discogs.com/labels/34231", "id": 34231, "entity_type_name": "Series"}], "labels": [{"name": "Magnetic Loft Records", "entity_type": "1", "catno": "MLR-007", "resource_url": "https://api.discogs.com/labels/890477", "id": 890477, "entity_type_name": "Label"}], "year": 2016, "community": {"status": "Accepted", "rating": {"count": 11, "average": 4.91}, "have": 73, "contributors": [{"username": "magnetic-loft-music", "resource_url": "https://api.discogs.com/users/magnetic-loft-music"}, {"username": "Shveiker", "resource_url": "https://api.discogs.com/users/Shveiker"}], "want": 18, "submitter": {"username": "magnetic-loft-music", "resource_url": "https://api.discogs.com/users/magnetic-loft-music"}, "data_quality": "Needs Vote"}, "artists": [{"join": "", "name": "St. Petersburg Ska-Jazz Review", "anv": "SPB Ska-Jazz Review", "tracks": "", "role": "", "resource_url": "https://api.discogs.com/artists/794217", "id": 794217}], "images": [{"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "primary", "uri150": ""}, {"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}], "format_quantity": 1, "id": 8138518, "artists_sort": "St. Petersburg Ska-Jazz Review", "genres": ["Jazz", "Reggae"], "thumb": "", "num_for_sale": 8, "title": "Elephant Riddim", "date_changed": "2018-01-30T13:32:46-08:00", "master_id": 960657, "lowest_price": 10.0, "status": "Accepted", "released_formatted": "18 Feb 2016", "estimated_weight": 230, "master_url": "https://api.discogs.com/masters/960657", "released": "2016-02-18", "date_added": "2016-02-19T01:49:21-08:00", "tracklist": [{"duration": "", "position": "A1", "type_": "track", "title": "Action Movie"}, {"duration": "", "position": "A2", "type_": "track", "title": "Elephant Riddim"}, {"duration": "", "position": "A3", "type_": "track", "title": "Ceora"}, {"duration": "", "position": "A4", "type_": "track", "title": "Doop"}, {"duration": "", "position": "A5", "type_": "track", "title": "52d Street Theme"}, {"duration": "", "position": "B1", "type_": "track", "title": "Fly Away"}, {"duration": "", "position": "B2", "type_": "track", "title": "Water Taxi"}, {"duration": "", "position": "B3", "type_": "track", "title": "Misterioso"}, {"duration": "", "position": "B4", "type_": "track", "title": "Keep On Going"}, {"duration": "", "position": "B5", "type_": "track", "title": "Filho Maravilha / Taj Mahal"}], "extraartists": [{"join": "", "name": "Michael Gavrichkov", "anv": "", "tracks": "", "role": "Artwork By", "resource_url": "https://api.discogs.com/artists/4540627", "id": 4540627}, {"join": "", "name": "Stu Allotropia", "anv": "", "tracks": "", "role": "Design", "resource_url": "https://api.discogs.com/artists/4894261", "id": 4894261}], "country": "Russia", "identifiers": [{"type": "Matrix / Runout", "value": "134985E1/A", "description": "Side A - handwritten etched"}, {"type": "Matrix / Runout", "value": "134985E2/A"}], "companies": [{"name": "GZ Media", "entity_type": "17", "catno": "134985E", "resource_url": "https://api.discogs.com/labels/430654", "id": 430654, "entity_type_name": "Pressed By"}], "uri": "https://www.discogs.com/SPB-Ska-Jazz-Review-Elephant-Riddim/release/8138518", "formats": [{"descriptions": ["LP", "Album", "Stereo"], "name": "Vinyl", "qty": "1"}], "resource_url": "https://api.discogs.com/releases/8138518", "data_quality": "Needs Vote"}`
 
+const draftReleaseJson = `{"id": 2, "status": "Draft", "title": "Untitled Draft", "resource_url": "https://api.discogs.com/releases/2", "uri": "https://www.discogs.com/release/2"}`
+
 const artistJson = `{"profile": "Marshall Bruce Mathers III (born October 17, 1972, St. Joseph, Missouri), known by his primary stage name Eminem, or by his alter ego Slim Shady, is an American rapper and record producer who grew up in Detroit, Michigan. He began his professional music career as a member of Soul Intent along with Proof in 1992. He also started his first record label with his group that same year called Mashin' Duck Records.", "realname": "Marshall Bruce Mathers III", "releases_url": "https://api.discogs.com/artists/38661/releases", "name": "Eminem", "uri": "https://www.discogs.com/artist/38661-Eminem", "urls": ["http://www.eminem.com", "http://www.instagram.com/eminem", "http://twitter.com/Eminem", "https://twitter.com/AskAboutREVIVAL", "http://www.facebook.com/eminem", "http://www.imdb.com/name/nm0004896", "http://www.myspace.com/eminem", "https://www.youtube.com/user/EminemMusic", "https://www.youtube.com/user/EminemVEVO", "https://www.filmo.gs/credit/16526-eminem", "https://www.bookogs.com/credit/229267-eminem", "http://eminem.tumblr.com", "http://en.wikipedia.org/wiki/Eminem", "http://equipboard.com/pros/eminem", "https://genius.com/eminem"], "images": [{"uri": "", "height": 607, "width": 600, "resource_url": "", "type": "primary", "uri150": ""}, {"uri": "", "height": 610, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 625, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 503, "width": 409, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 652, "width": 452, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 326, "width": 251, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 397, "width": 441, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 450, "width": 348, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 442, "width": 319, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 740, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 446, "width": 299, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 288, "width": 288, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 360, "width": 468, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 372, "width": 500, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 404, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 444, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 450, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 604, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 642, "width": 500, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 253, "width": 199, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 550, "width": 400, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 160, "width": 236, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 400, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 821, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 258, "width": 195, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 450, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 746, "width": 517, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 170, "width": 220, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 500, "width": 300, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 347, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 281, "width": 500, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 552, "width": 435, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 444, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 507, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 488, "width": 300, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 409, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 515, "width": 578, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 387, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 310, "width": 266, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 800, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 613, "width": 454, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 751, "width": 500, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 657, "width": 485, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 543, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 490, "width": 376, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 450, "width": 403, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 400, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 600, "width": 480, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 532, "width": 415, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 500, "width": 444, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 400, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 256, "width": 256, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 718, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 440, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 400, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 905, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 300, "width": 202, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 552, "width": 435, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 578, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}, {"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "secondary", "uri150": ""}], "resource_url": "https://api.discogs.com/artists/38661", "aliases": [{"resource_url": "https://api.discogs.com/artists/108184", "id": 108184, "name": "Slim Shady"}, {"resource_url": "https://api.discogs.com/artists/644153", "id": 644153, "name": "Marshall Mathers"}, {"resource_url": "https://api.discogs.com/artists/787714", "id": 787714, "name": "Ken Kaniff"}], "id": 38661, "data_quality": "Needs Vote", "namevariations": ["E. Minem", "Em", "Emiem", "Emine", "EMINEM", "Eminem Show", "Eminen", "Enimen", "M & M", "M. Mathers", "M.N.M", "M&M", "MC Double M", "\u30a8\u30df\u30cd\u30e0"]}`
 
+const groupArtistJson = `{"id": 1289, "name": "Daft Punk", "realname": "", "profile": "French electronic music duo formed in 1993 in Paris.", "releases_url": "https://api.discogs.com/artists/1289/releases", "resource_url": "https://api.discogs.com/artists/1289", "uri": "https://www.discogs.com/artist/1289-Daft-Punk", "urls": ["http://www.daftpunk.com"], "images": [{"uri": "", "height": 600, "width": 600, "resource_url": "", "type": "primary", "uri150": ""}], "namevariations": ["Daft Pank", "Daftpunk"], "aliases": [{"id": 26984, "name": "Stardust", "resource_url": "https://api.discogs.com/artists/26984", "thumbnail_url": "https://i.discogs.com/stardust.jpg"}], "members": [{"active": true, "id": 1291, "name": "Thomas Bangalter", "resource_url": "https://api.discogs.com/artists/1291", "thumbnail_url": "https://i.discogs.com/bangalter.jpg"}, {"active": true, "id": 1290, "name": "Guy-Manuel de Homem-Christo", "resource_url": "https://api.discogs.com/artists/1290"}], "groups": [{"active": false, "id": 7054, "name": "Darlin'", "resource_url": "https://api.discogs.com/artists/7054"}], "data_quality": "Correct"}`
+
 const folderJson = `{"id": 0, "name": "All", "count": 95, "resource_url": "https://api.discogs.com/users/test_user/collection/folders/0"}`
 
 const collectionJson = `{"folders": [{"id": 0, "name": "All", "count": 95, "resource_url": "https://api.discogs.com/users/test_user/collection/folders/0"}]}`
@@ -19,3 +23,21 @@ const collectionItemsByRelease = `{"pagination": {"page": 1, "pages": 1, "per_pa
 const priceSuggestionJson = `{"Mint (M)": {"currency": "EUR", "value": 16.625}, "Near Mint (NM or M-)": {"currency": "EUR", "value": 14.875000000000002}, "Very Good Plus (VG+)": {"currency": "EUR", "value": 11.375000000000002}, "Very Good (VG)": {"currency": "EUR", "value": 7.875000000000001}, "Good Plus (G+)": {"currency": "EUR", "value": 4.375}, "Good (G)": {"currency": "EUR", "value": 2.625}, "Fair (F)": {"currency": "EUR", "value": 1.7500000000000002}, "Poor (P)": {"currency": "EUR", "value": 0.8750000000000001}}`
 
 const releaseStatsJson = `{"num_for_sale": 4, "lowest_price": {"value": 18.07, "currency": "USD"}, "blocked_from_sale": false}`
+
+const listingJson = `{"id": 172723812, "status": "For Sale", "price": {"currency": "USD", "value": 120}, "allow_offers": false, "condition": "Mint (M)", "sleeve_condition": "Near Mint (NM or M-)", "comments": "Brand new, still sealed.", "ships_from": "United States", "shipping_price": {"currency": "USD", "value": 5.5}, "posted": "2014-07-15T12:55:01-07:00", "audio": false, "uri": "https://www.discogs.com/sell/item/172723812", "resource_url": "https://api.discogs.com/marketplace/listings/172723812", "seller": {"id": 1369620, "username": "test_seller", "resource_url": "https://api.discogs.com/users/test_seller", "shipping": "Buyer pays shipping.", "payment": "PayPal", "stats": {"rating": "100", "stars": 5, "total": 15}}, "release": {"id": 5610049, "catalog_number": "541125-1, 1-541125 (K1)", "artist": "LCD Soundsystem", "title": "The Long Goodbye: LCD Soundsystem Live At Madison Square Garden", "format": "5xVinyl, LP + Box", "year": 2014, "description": "LCD Soundsystem - The Long Goodbye (5xLP + Box)", "thumbnail": "", "resource_url": "https://api.discogs.com/releases/5610049"}}`
+
+const inventoryJson = `{"pagination": {"per_page": 50, "items": 1, "page": 1, "urls": {}, "pages": 1}, "listings": [{"id": 172723812, "status": "For Sale", "price": {"currency": "USD", "value": 120}, "allow_offers": false, "condition": "Mint (M)", "sleeve_condition": "Near Mint (NM or M-)", "comments": "Brand new, still sealed.", "ships_from": "United States", "posted": "2014-07-15T12:55:01-07:00", "audio": false, "uri": "https://www.discogs.com/sell/item/172723812", "resource_url": "https://api.discogs.com/marketplace/listings/172723812", "seller": {"id": 1369620, "username": "test_seller", "resource_url": "https://api.discogs.com/users/test_seller", "shipping": "Buyer pays shipping.", "payment": "PayPal", "stats": {"rating": "100", "stars": 5, "total": 15}}, "release": {"id": 5610049, "catalog_number": "541125-1, 1-541125 (K1)", "artist": "LCD Soundsystem", "title": "The Long Goodbye: LCD Soundsystem Live At Madison Square Garden", "format": "5xVinyl, LP + Box", "year": 2014, "description": "LCD Soundsystem - The Long Goodbye (5xLP + Box)", "thumbnail": "", "resource_url": "https://api.discogs.com/releases/5610049"}}]}`
+
+const wantlistJson = `{"pagination": {"page": 1, "pages": 1, "per_page": 50, "items": 2, "urls": {}}, "wants": [{"id": 1867708, "rating": 4, "notes": "Original pressing only", "resource_url": "https://api.discogs.com/users/test_user/wants/1867708", "date_added": "2014-07-16T12:32:07-07:00", "basic_information": {"id": 1867708, "master_id": 0, "master_url": null, "resource_url": "https://api.discogs.com/releases/1867708", "thumb": "", "cover_image": "", "title": "Year Zero", "year": 2007, "formats": [{"name": "Vinyl", "qty": "2", "descriptions": ["LP", "Album"]}], "labels": [{"name": "Interscope Records", "catno": "B0008764-01", "entity_type": "1", "entity_type_name": "Label", "id": 82835, "resource_url": "https://api.discogs.com/labels/82835"}], "artists": [{"name": "Nine Inch Nails", "anv": "", "join": "", "role": "", "tracks": "", "id": 3857, "resource_url": "https://api.discogs.com/artists/3857"}], "genres": ["Electronic", "Rock"], "styles": ["Industrial"]}}, {"id": 5610049, "rating": 0, "resource_url": "https://api.discogs.com/users/test_user/wants/5610049", "date_added": "2015-01-02T10:00:00-08:00", "basic_information": {"id": 5610049, "master_id": 727954, "master_url": "https://api.discogs.com/masters/727954", "resource_url": "https://api.discogs.com/releases/5610049", "thumb": "", "cover_image": "", "title": "The Long Goodbye", "year": 2014, "formats": [{"name": "Vinyl", "qty": "5", "descriptions": ["LP"]}], "labels": [{"name": "DFA", "catno": "541125-1", "entity_type": "1", "entity_type_name": "Label", "id": 7519, "resource_url": "https://api.discogs.com/labels/7519"}], "artists": [{"name": "LCD Soundsystem", "anv": "", "join": "", "role": "", "tracks": "", "id": 18469, "resource_url": "https://api.discogs.com/artists/18469"}], "genres": ["Electronic"], "styles": ["Disco"]}}]}`
+
+const orderJson = `{"id": "1-1", "resource_url": "https://api.discogs.com/marketplace/orders/1-1", "messages_url": "https://api.discogs.com/marketplace/orders/1-1/messages", "uri": "https://www.discogs.com/sell/order/1-1", "status": "New Order", "next_status": ["New Order", "Buyer Contacted", "Invoice Sent", "Payment Pending", "Payment Received", "Shipped", "Refund Sent", "Cancelled (Non-Paying Buyer)", "Cancelled (Item Unavailable)", "Cancelled (Per Buyer's Request)"], "fee": {"currency": "USD", "value": 2.52}, "created": "2011-10-21T09:25:17-07:00", "items": [{"release": {"id": 1, "description": "Persuader, The - Stockholm (2x12\")"}, "price": {"currency": "USD", "value": 42.0}, "media_condition": "Mint (M)", "sleeve_condition": "Mint (M)", "id": 41578242}], "shipping": {"currency": "USD", "method": "Standard", "value": 0.0}, "shipping_address": "Asdf Exampleton\n234 NE Asdf St.\nAsdf Town, Oregon, 14423\nUnited States\n\nPhone: 555-555-2733\nPaypal address: asdf@example.com", "additional_instructions": "please use sturdy packaging.", "archived": false, "seller": {"resource_url": "https://api.discogs.com/users/test_seller", "username": "test_seller", "id": 1369620}, "last_activity": "2011-10-21T09:25:17-07:00", "buyer": {"resource_url": "https://api.discogs.com/users/example_buyer", "username": "example_buyer", "id": 2}, "total": {"currency": "USD", "value": 42.0}}`
+
+const ordersJson = `{"pagination": {"per_page": 50, "items": 1, "page": 1, "urls": {}, "pages": 1}, "orders": [` + orderJson + `]}`
+
+const inventoryUploadJson = `{"status": "success", "results": "CSV file contains 1 records.<p>Processed 1 records.", "created_ts": "2017-12-18T09:21:57", "finished_ts": "2017-12-18T09:22:04", "filename": "add.csv", "type": "add", "id": 599632}`
+
+const inventoryUploadsJson = `{"pagination": {"per_page": 20, "items": 1, "page": 1, "urls": {}, "pages": 1}, "items": [` + inventoryUploadJson + `]}`
+
+const inventoryExportJson = `{"status": "success", "created_ts": "2018-09-27T12:59:02", "url": "https://api.discogs.com/inventory/export/599632", "finished_ts": "2018-09-27T12:59:02", "download_url": "https://api.discogs.com/inventory/export/599632/download", "filename": "cburmeister-inventory-20180927-1259.csv", "id": 599632}`
+
+const inventoryExportsJson = `{"pagination": {"per_page": 50, "items": 1, "page": 1, "urls": {}, "pages": 1}, "items": [` + inventoryExportJson + `]}`
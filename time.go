@@ -0,0 +1,52 @@
+package discogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Time is a timestamp reported by Discogs, such as the time a release was added, e.g. "2016-02-19T01:49:21-08:00".
+// It embeds time.Time and keeps the offset reported by Discogs. An empty or null timestamp decodes to the zero time,
+// which is encoded as an empty string.
+type Time struct {
+	time.Time
+}
+
+// localTimeFormat is the format of the timestamps without offset some endpoints report, e.g. inventory uploads.
+const localTimeFormat = "2006-01-02T15:04:05"
+
+// UnmarshalJSON decodes an RFC 3339 timestamp, or a timestamp without offset such as "2017-12-18T09:13:32" as UTC.
+func (t *Time) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		var lerr error
+		if parsed, lerr = time.Parse(localTimeFormat, s); lerr != nil {
+			return err
+		}
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalJSON encodes the time as an RFC 3339 timestamp.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(t.Format(time.RFC3339))
+}
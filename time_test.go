@@ -0,0 +1,44 @@
+package discogs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeJSON(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want time.Time
+		out  string
+	}{
+		"offset": {`"2016-02-19T01:49:21-08:00"`, time.Date(2016, 2, 19, 9, 49, 21, 0, time.UTC), `"2016-02-19T01:49:21-08:00"`},
+		"utc":    {`"2020-01-19T14:19:11Z"`, time.Date(2020, 1, 19, 14, 19, 11, 0, time.UTC), `"2020-01-19T14:19:11Z"`},
+		"local":  {`"2017-12-18T09:13:32"`, time.Date(2017, 12, 18, 9, 13, 32, 0, time.UTC), `"2017-12-18T09:13:32Z"`},
+		"empty":  {`""`, time.Time{}, `""`},
+		"null":   {`null`, time.Time{}, `""`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got Time
+			if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+				t.Fatalf("failed to unmarshal time: %s", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("time got=%s; want=%s", got, tt.want)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("failed to marshal time: %s", err)
+			}
+			if string(b) != tt.out {
+				t.Errorf("json got=%s; want=%s", b, tt.out)
+			}
+		})
+	}
+
+	var invalid Time
+	if err := json.Unmarshal([]byte(`"19 Feb 2016"`), &invalid); err == nil {
+		t.Error("expected error for invalid time")
+	}
+}
@@ -0,0 +1,138 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/irlndts/go-discogs"
+
+// idAttributes maps the path segments preceding an ID to the span attribute recording it.
+var idAttributes = map[string]string{
+	"artists":           "discogs.artist_id",
+	"export":            "discogs.export_id",
+	"folders":           "discogs.folder_id",
+	"instances":         "discogs.instance_id",
+	"labels":            "discogs.label_id",
+	"listings":          "discogs.listing_id",
+	"masters":           "discogs.master_id",
+	"orders":            "discogs.order_id",
+	"price_suggestions": "discogs.release_id",
+	"releases":          "discogs.release_id",
+	"stats":             "discogs.release_id",
+	"upload":            "discogs.upload_id",
+	"users":             "discogs.username",
+	"wants":             "discogs.release_id",
+}
+
+// idSegment matches the path segments holding an ID, numeric or an order ID such as "1369620-1".
+var idSegment = regexp.MustCompile(`^\d+(-\d+)?$`)
+
+// tracing returns middleware recording a span for every API request.
+func tracing(tp trace.TracerProvider) Middleware {
+	tracer := tp.Tracer(tracerName)
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			name, attrs := endpoint(r.URL.Path)
+			attrs = append(attrs,
+				attribute.String("http.method", r.Method),
+				attribute.String("http.url", r.URL.String()),
+			)
+
+			ctx, span := tracer.Start(r.Context(), r.Method+" "+name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			resp, err := next(r.WithContext(ctx))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+
+			rl := rateLimitSnapshot(resp.Header)
+			span.AddEvent("rate limit", trace.WithAttributes(
+				attribute.Int("discogs.ratelimit.total", rl.Total),
+				attribute.Int("discogs.ratelimit.used", rl.Used),
+				attribute.Int("discogs.ratelimit.remaining", rl.Remaining),
+			))
+			return resp, nil
+		}
+	}
+}
+
+// Traced returns d with all functions replaced with versions recording a span for every call, e.g.
+// "DatabaseService.Release". With Options.TracerProvider set as well, the spans of the API requests made by a call are
+// its children, showing how long it waited for rate limits or retries. Clients derived with WithToken or WithOAuth are
+// traced as well.
+func Traced(d Discogs, tp trace.TracerProvider) Discogs {
+	tracer := tp.Tracer(tracerName)
+
+	return Intercept(d, func(ctx context.Context, call Call, invoke func(ctx context.Context) error) error {
+		ctx, span := tracer.Start(ctx, string(call.Service)+"."+call.Method, trace.WithAttributes(
+			attribute.String("discogs.service", string(call.Service)),
+			attribute.String("discogs.method", call.Method),
+		))
+		defer span.End()
+
+		err := invoke(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	})
+}
+
+// Endpoint returns the name of the endpoint requested by path with IDs and usernames replaced by placeholders, e.g.
+// "/releases/{id}" for "/releases/8138518". It keeps the number of distinct names small, which makes it suitable for
+// labelling metrics in Middleware.
+func Endpoint(path string) string {
+	name, _ := endpoint(path)
+	return name
+}
+
+// endpoint returns the name of the endpoint requested by path, with IDs and usernames replaced by placeholders,
+// along with attributes recording the replaced values. Every numeric or order ID is replaced, whatever precedes it.
+func endpoint(path string) (string, []attribute.KeyValue) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var attrs []attribute.KeyValue
+	for i := 1; i < len(segments); i++ {
+		key, ok := idAttributes[segments[i-1]]
+		switch {
+		case segments[i-1] == "users":
+			attrs = append(attrs, attribute.String(key, segments[i]))
+			segments[i] = "{username}"
+		case idSegment.MatchString(segments[i]):
+			if ok {
+				attrs = append(attrs, idAttribute(key, segments[i]))
+			}
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/"), attrs
+}
+
+// idAttribute returns the attribute recording the ID, an int unless it's an order ID.
+func idAttribute(key, id string) attribute.KeyValue {
+	if n, err := strconv.Atoi(id); err == nil {
+		return attribute.Int(key, n)
+	}
+	return attribute.String(key, id)
+}
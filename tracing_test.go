@@ -0,0 +1,119 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	d := initDiscogsClient(t, &Options{
+		URL:            ts.URL,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	})
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("spans got=%d; want=1", len(spans))
+	}
+	span := spans[0]
+
+	if want := "GET /releases/{id}"; span.Name() != want {
+		t.Errorf("span name got=%q; want=%q", span.Name(), want)
+	}
+
+	var releaseID int64
+	for _, attr := range span.Attributes() {
+		if attr.Key == "discogs.release_id" {
+			releaseID = attr.Value.AsInt64()
+		}
+	}
+	if releaseID != 8138518 {
+		t.Errorf("release id attribute got=%d; want=8138518", releaseID)
+	}
+
+	if len(span.Events()) != 1 || span.Events()[0].Name != "rate limit" {
+		t.Errorf("events got=%v; want a rate limit event", span.Events())
+	}
+}
+
+func TestTraced(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	d := Traced(initDiscogsClient(t, &Options{URL: ts.URL, TracerProvider: tp}), tp)
+
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("spans got=%d; want=2", len(spans))
+	}
+	request, call := spans[0], spans[1]
+
+	if want := "DatabaseService.Release"; call.Name() != want {
+		t.Errorf("span name got=%q; want=%q", call.Name(), want)
+	}
+	if request.Parent().SpanID() != call.SpanContext().SpanID() {
+		t.Errorf("expected the request span to be a child of the call span")
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		path  string
+		name  string
+		attrs []attribute.KeyValue
+	}{
+		{"/releases/8138518/rating", "/releases/{id}/rating", []attribute.KeyValue{attribute.Int("discogs.release_id", 8138518)}},
+		{"/database/search", "/database/search", nil},
+		{"/marketplace/stats/9893847", "/marketplace/stats/{id}", []attribute.KeyValue{attribute.Int("discogs.release_id", 9893847)}},
+		{"/users/test_user/collection/folders/0/releases", "/users/{username}/collection/folders/{id}/releases", []attribute.KeyValue{
+			attribute.String("discogs.username", "test_user"),
+			attribute.Int("discogs.folder_id", 0),
+		}},
+		{"/marketplace/listings/172723812", "/marketplace/listings/{id}", []attribute.KeyValue{attribute.Int("discogs.listing_id", 172723812)}},
+		{"/marketplace/orders/1369620-1/messages", "/marketplace/orders/{id}/messages", []attribute.KeyValue{
+			attribute.String("discogs.order_id", "1369620-1"),
+		}},
+		{"/users/test_user/wants/130076", "/users/{username}/wants/{id}", []attribute.KeyValue{
+			attribute.String("discogs.username", "test_user"),
+			attribute.Int("discogs.release_id", 130076),
+		}},
+		{"/inventory/upload/599632", "/inventory/upload/{id}", []attribute.KeyValue{attribute.Int("discogs.upload_id", 599632)}},
+		{"/some/12345", "/some/{id}", nil},
+	}
+
+	for _, tt := range tests {
+		name, attrs := endpoint(tt.path)
+		if name != tt.name {
+			t.Errorf("%s: name got=%q; want=%q", tt.path, name, tt.name)
+		}
+		if len(attrs) != len(tt.attrs) {
+			t.Errorf("%s: attributes got=%v; want=%v", tt.path, attrs, tt.attrs)
+			continue
+		}
+		for i := range attrs {
+			if attrs[i] != tt.attrs[i] {
+				t.Errorf("%s: attributes got=%v; want=%v", tt.path, attrs, tt.attrs)
+			}
+		}
+	}
+}
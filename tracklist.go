@@ -0,0 +1,129 @@
+package discogs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of tracklist entries as reported in Track.Type.
+const (
+	TrackTypeTrack   = "track"
+	TrackTypeHeading = "heading" // a heading such as a side or part title, which isn't played
+	TrackTypeIndex   = "index"   // an index track, whose parts are its SubTracks
+)
+
+// ParseTrackDuration parses a track duration such as "3:45", "03:45" or "1:02:03", returning ErrInvalidDuration if it
+// is empty or malformed.
+func ParseTrackDuration(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+	}
+
+	var d time.Duration
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		// the leading part may be any number of minutes or hours, the following ones must be below 60
+		if err != nil || n < 0 || (i > 0 && (n >= 60 || len(part) != 2)) {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+		}
+		d = d*60 + time.Duration(n)
+	}
+	return d * time.Second, nil
+}
+
+// Length returns the duration of the track, returning ErrInvalidDuration if it is unknown or malformed.
+func (t Track) Length() (time.Duration, error) {
+	return ParseTrackDuration(t.Duration)
+}
+
+// Runtime returns the total duration of the tracklist and whether the durations of all its tracks are known. Headings
+// are skipped and index tracks without a duration of their own count with the sum of their sub tracks.
+func Runtime(tracklist []Track) (time.Duration, bool) {
+	var total time.Duration
+	complete := true
+	for _, t := range tracklist {
+		if t.Type == TrackTypeHeading {
+			continue
+		}
+		if d, err := t.Length(); err == nil {
+			total += d
+			continue
+		}
+		if len(t.SubTracks) > 0 {
+			d, ok := Runtime(t.SubTracks)
+			total += d
+			complete = complete && ok
+			continue
+		}
+		complete = false
+	}
+	return total, complete
+}
+
+// TrackPosition is a track position split into its parts, e.g. side "A" and track 1 for "A1" on vinyl or cassette,
+// disc 2 and track 5 for "2-05" on multi-disc CDs.
+type TrackPosition struct {
+	Side  string // side of vinyl or cassette, e.g. "A" or "AA", empty for numbered positions
+	Disc  int    // number of the disc, zero if not given
+	Track int    // number of the track on the side or disc, zero for sides holding a single track such as "A"
+	Sub   string // part of a sub track, e.g. "a" for "A1a"
+}
+
+var (
+	// sidePosition matches vinyl and cassette positions, e.g. "A", "A1", "a01", "AA2" and "B3.b".
+	sidePosition = regexp.MustCompile(`^([A-Za-z]{1,2})(\d{0,3})(?:\.?([a-z]))?$`)
+	// discPosition matches numbered positions, optionally with the disc, e.g. "5", "05", "1-05", "2.3", "CD2-3" and
+	// "1a".
+	discPosition = regexp.MustCompile(`^(?:(?i:CD|DVD|BD|Disc)\s*)?(?:(\d{1,3})[-.])?(\d{1,3})(?:\.?([a-z]))?$`)
+)
+
+// ParsePosition parses a track position such as "A1", "b2", "1-05" or "CD2-3", returning ErrInvalidPosition if it
+// is empty or has an unknown form.
+func ParsePosition(pos string) (TrackPosition, error) {
+	pos = strings.TrimSpace(pos)
+	if m := discPosition.FindStringSubmatch(pos); m != nil {
+		var p TrackPosition
+		p.Disc, _ = strconv.Atoi(m[1])
+		p.Track, _ = strconv.Atoi(m[2])
+		p.Sub = m[3]
+		return p, nil
+	}
+	if m := sidePosition.FindStringSubmatch(pos); m != nil {
+		p := TrackPosition{Side: strings.ToUpper(m[1]), Sub: m[3]}
+		p.Track, _ = strconv.Atoi(m[2])
+		return p, nil
+	}
+	return TrackPosition{}, fmt.Errorf("%w: %q", ErrInvalidPosition, pos)
+}
+
+// String returns the normalized position, e.g. "A1" for "a01" and "2-5" for "CD2-05".
+func (p TrackPosition) String() string {
+	var b strings.Builder
+	switch {
+	case p.Side != "":
+		b.WriteString(p.Side)
+		if p.Track > 0 {
+			b.WriteString(strconv.Itoa(p.Track))
+		}
+	case p.Disc > 0:
+		b.WriteString(strconv.Itoa(p.Disc) + "-" + strconv.Itoa(p.Track))
+	default:
+		b.WriteString(strconv.Itoa(p.Track))
+	}
+	b.WriteString(p.Sub)
+	return b.String()
+}
+
+// NormalizedPosition returns the track's position normalized as by TrackPosition.String, or the position as is if it
+// can't be parsed, e.g. the empty position of a heading.
+func (t Track) NormalizedPosition() string {
+	p, err := ParsePosition(t.Position)
+	if err != nil {
+		return t.Position
+	}
+	return p.String()
+}
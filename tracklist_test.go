@@ -0,0 +1,86 @@
+package discogs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTrackDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"3:45":    3*time.Minute + 45*time.Second,
+		"03:05":   3*time.Minute + 5*time.Second,
+		"72:10":   72*time.Minute + 10*time.Second,
+		"1:02:03": time.Hour + 2*time.Minute + 3*time.Second,
+		" 0:59 ":  59 * time.Second,
+	}
+	for s, want := range tests {
+		if d, err := ParseTrackDuration(s); err != nil || d != want {
+			t.Errorf("%q: got=%v, %v; want=%v", s, d, err, want)
+		}
+	}
+	for _, s := range []string{"", "45", "3:5", "3:60", "1:60:00", "a:bc", "1:2:3:4"} {
+		if _, err := ParseTrackDuration(s); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("%q: err got=%v; want=%s", s, err, ErrInvalidDuration)
+		}
+	}
+}
+
+func TestRuntime(t *testing.T) {
+	tracklist := []Track{
+		{Type: TrackTypeHeading, Title: "Side A"},
+		{Position: "A1", Type: TrackTypeTrack, Duration: "3:00"},
+		{Type: TrackTypeIndex, Title: "Suite", SubTracks: []Track{
+			{Position: "A2a", Duration: "1:30"},
+			{Position: "A2b", Duration: "2:30"},
+		}},
+		{Type: TrackTypeIndex, Title: "Medley", Duration: "10:00", SubTracks: []Track{
+			{Position: "B1a"},
+		}},
+	}
+	if d, ok := Runtime(tracklist); d != 17*time.Minute || !ok {
+		t.Errorf("runtime got=%v, %t; want=17m0s, true", d, ok)
+	}
+
+	tracklist = append(tracklist, Track{Position: "B2", Type: TrackTypeTrack})
+	if d, ok := Runtime(tracklist); d != 17*time.Minute || ok {
+		t.Errorf("runtime got=%v, %t; want=17m0s, false", d, ok)
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	tests := map[string]struct {
+		want       TrackPosition
+		normalized string
+	}{
+		"A1":     {TrackPosition{Side: "A", Track: 1}, "A1"},
+		"a01":    {TrackPosition{Side: "A", Track: 1}, "A1"},
+		"AA2":    {TrackPosition{Side: "AA", Track: 2}, "AA2"},
+		"B":      {TrackPosition{Side: "B"}, "B"},
+		"B3.b":   {TrackPosition{Side: "B", Track: 3, Sub: "b"}, "B3b"},
+		"5":      {TrackPosition{Track: 5}, "5"},
+		"05":     {TrackPosition{Track: 5}, "5"},
+		"1a":     {TrackPosition{Track: 1, Sub: "a"}, "1a"},
+		"1-05":   {TrackPosition{Disc: 1, Track: 5}, "1-5"},
+		"2.3":    {TrackPosition{Disc: 2, Track: 3}, "2-3"},
+		"CD2-03": {TrackPosition{Disc: 2, Track: 3}, "2-3"},
+	}
+	for pos, tt := range tests {
+		p, err := ParsePosition(pos)
+		if err != nil || p != tt.want {
+			t.Errorf("%q: got=%+v, %v; want=%+v", pos, p, err, tt.want)
+		}
+		if s := (Track{Position: pos}).NormalizedPosition(); s != tt.normalized {
+			t.Errorf("%q: normalized got=%s; want=%s", pos, s, tt.normalized)
+		}
+	}
+
+	for _, pos := range []string{"", "Video", "1-2-3"} {
+		if _, err := ParsePosition(pos); !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("%q: err got=%v; want=%s", pos, err, ErrInvalidPosition)
+		}
+	}
+	if s := (Track{Position: "Video"}).NormalizedPosition(); s != "Video" {
+		t.Errorf("normalized got=%s; want=Video", s)
+	}
+}
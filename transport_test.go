@@ -0,0 +1,146 @@
+package discogs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient(t *testing.T) {
+	if httpClient(TransportOptions{}) != httpClient(TransportOptions{}) {
+		t.Errorf("clients with default options aren't shared")
+	}
+
+	c := httpClient(TransportOptions{})
+	tr := c.Transport.(*http.Transport)
+	if c.Timeout != DefaultClientTimeout || tr.ResponseHeaderTimeout != DefaultResponseHeaderTimeout ||
+		tr.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || !tr.ForceAttemptHTTP2 || tr.DisableKeepAlives {
+		t.Errorf("default client got timeout=%s, transport=%+v", c.Timeout, tr)
+	}
+
+	c = httpClient(TransportOptions{Timeout: time.Second, MaxIdleConnsPerHost: 2, DisableHTTP2: true})
+	tr = c.Transport.(*http.Transport)
+	if c.Timeout != time.Second || tr.MaxIdleConnsPerHost != 2 || tr.ForceAttemptHTTP2 ||
+		tr.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("client got timeout=%s, transport=%+v", c.Timeout, tr)
+	}
+}
+
+func TestTransportOptionsStalledServer(t *testing.T) {
+	stall := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-stall
+	}))
+	defer ts.Close()
+	defer close(stall)
+
+	d, err := NewClient(WithUserAgent(testUserAgent), WithBaseURL(ts.URL),
+		WithTransportOptions(TransportOptions{ResponseHeaderTimeout: 50 * time.Millisecond}))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := d.Release(context.Background(), 8138518)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("err got=nil; want a timeout")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("request to a stalled server didn't time out")
+	}
+}
+
+func TestProxy(t *testing.T) {
+	var host string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.URL.Host
+		DatabaseServer(w, r)
+	}))
+	defer proxy.Close()
+
+	// the API host doesn't resolve, so only the proxy can serve the request
+	d, err := NewClient(WithUserAgent(testUserAgent), WithBaseURL("http://api.discogs.invalid"), WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	if _, err := d.Release(context.Background(), 8138518); err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if host != "api.discogs.invalid" {
+		t.Errorf("proxied host got=%s; want=api.discogs.invalid", host)
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(DatabaseServer))
+	defer ts.Close()
+
+	ctx := context.Background()
+	d, err := NewClient(WithUserAgent(testUserAgent), WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	if _, err := d.Release(ctx, 8138518); err == nil {
+		t.Errorf("err got=nil; want an unknown certificate authority")
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+	d, err = NewClient(WithUserAgent(testUserAgent), WithBaseURL(ts.URL), WithTLSConfig(&tls.Config{RootCAs: roots}),
+		WithDialTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	if _, err := d.Release(ctx, 8138518); err != nil {
+		t.Errorf("failed to get release: %s", err)
+	}
+}
+
+func TestTransportOptionsValidate(t *testing.T) {
+	tests := map[string]struct {
+		options *Options
+		err     error
+	}{
+		"defaults": {&Options{UserAgent: testUserAgent}, nil},
+		"custom":   {&Options{UserAgent: testUserAgent, Transport: TransportOptions{Timeout: time.Second}}, nil},
+		"negative timeout": {
+			&Options{UserAgent: testUserAgent, Transport: TransportOptions{ResponseHeaderTimeout: -time.Second}},
+			ErrInvalidOptions,
+		},
+		"negative idle connections": {
+			&Options{UserAgent: testUserAgent, Transport: TransportOptions{MaxIdleConnsPerHost: -1}},
+			ErrInvalidOptions,
+		},
+		"socks proxy": {&Options{UserAgent: testUserAgent, Transport: TransportOptions{ProxyURL: "socks5://proxy:1080"}}, nil},
+		"invalid proxy": {
+			&Options{UserAgent: testUserAgent, Transport: TransportOptions{ProxyURL: "ftp://proxy"}},
+			ErrInvalidURL,
+		},
+		"proxy without host": {
+			&Options{UserAgent: testUserAgent, Transport: TransportOptions{ProxyURL: "http://"}},
+			ErrInvalidURL,
+		},
+		"client and transport": {
+			&Options{UserAgent: testUserAgent, Client: &http.Client{}, Transport: TransportOptions{DisableHTTP2: true}},
+			ErrInvalidOptions,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tt.options.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("err got=%v; want=%v", err, tt.err)
+			}
+		})
+	}
+}
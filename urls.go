@@ -0,0 +1,139 @@
+package discogs
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of entity a discogs.com link refers to.
+type Kind string
+
+// Kinds of entities linked on discogs.com.
+const (
+	KindRelease Kind = "release"
+	KindMaster  Kind = "master"
+	KindArtist  Kind = "artist"
+	KindLabel   Kind = "label"
+	KindListing Kind = "listing"
+)
+
+const webURL = "https://www.discogs.com"
+
+// webPaths are the path segments of the discogs.com pages of each kind of entity.
+var webPaths = map[Kind]string{
+	KindRelease: "release",
+	KindMaster:  "master",
+	KindArtist:  "artist",
+	KindLabel:   "label",
+	KindListing: "sell/item",
+}
+
+var (
+	// webPath matches the paths of entity pages on discogs.com, optionally slugged, e.g. "/release/8138518",
+	// "/release/8138518-Elephant-Riddim", the older "/SPB-Ska-Jazz-Review-Elephant-Riddim/release/8138518" and
+	// "/sell/item/172723812".
+	webPath = regexp.MustCompile(`/(release|master|artist|label|sell/item)/(\d+)(?:-[^/]*)?/?$`)
+	// apiPath matches the paths of API resources, e.g. "/releases/8138518".
+	apiPath = regexp.MustCompile(`^/(releases|masters|artists|labels|marketplace/listings)/(\d+)/?$`)
+)
+
+// kinds maps the path segments matched by webPath and apiPath to the kinds of entities.
+var kinds = map[string]Kind{
+	"release":              KindRelease,
+	"releases":             KindRelease,
+	"master":               KindMaster,
+	"masters":              KindMaster,
+	"artist":               KindArtist,
+	"artists":              KindArtist,
+	"label":                KindLabel,
+	"labels":               KindLabel,
+	"sell/item":            KindListing,
+	"marketplace/listings": KindListing,
+}
+
+// ParseURL returns the kind and ID of the entity linked by u, a discogs.com page or API resource URL such as
+// "https://www.discogs.com/release/8138518-Elephant-Riddim" or "https://api.discogs.com/masters/960657".
+func ParseURL(u string) (Kind, int, error) {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil {
+		return "", 0, ErrInvalidURL
+	}
+
+	var m []string
+	switch strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.") {
+	case "discogs.com":
+		m = webPath.FindStringSubmatch(parsed.Path)
+	case "api.discogs.com":
+		m = apiPath.FindStringSubmatch(parsed.Path)
+	}
+	if m == nil {
+		return "", 0, ErrInvalidURL
+	}
+
+	id, err := strconv.Atoi(m[2])
+	if err != nil || id <= 0 {
+		return "", 0, ErrInvalidURL
+	}
+	return kinds[m[1]], id, nil
+}
+
+// WebURL returns the discogs.com page of the entity of the given kind and ID, or an empty string if kind is unknown.
+func WebURL(kind Kind, id int) string {
+	path, ok := webPaths[kind]
+	if !ok {
+		return ""
+	}
+	return webURL + "/" + path + "/" + strconv.Itoa(id)
+}
+
+// ReleaseIDFromURL returns the ID of the release linked by u, a discogs.com release page or API resource URL such as
+// the Discogs links of MusicBrainz releases.
+func ReleaseIDFromURL(u string) (int, error) {
+	kind, id, err := ParseURL(u)
+	if err != nil {
+		return 0, err
+	}
+	if kind != KindRelease {
+		return 0, ErrInvalidURL
+	}
+	return id, nil
+}
+
+// joinURL returns base with the path elements appended, like url.JoinPath: elements are separated by a single slash
+// whatever slashes base and the elements start or end with, and the query of base is kept. Elements must already be
+// escaped, so user input such as usernames has to be passed through url.PathEscape.
+func joinURL(base string, elem ...string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		// options are validated, so this only happens for bases built by hand
+		return strings.TrimRight(base, "/") + "/" + strings.Join(elem, "/")
+	}
+	p := strings.TrimRight(u.EscapedPath(), "/")
+	for _, e := range elem {
+		if e = strings.Trim(e, "/"); e != "" {
+			p += "/" + e
+		}
+	}
+	path, err := url.PathUnescape(p)
+	if err != nil {
+		return strings.TrimRight(base, "/") + "/" + strings.Join(elem, "/")
+	}
+	u.Path, u.RawPath = path, p
+	return u.String()
+}
+
+// withParams returns u with params added to its query, keeping any parameters u already has.
+func withParams(u string, params url.Values) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.RawQuery == "" {
+		return u + "?" + params.Encode()
+	}
+	q := parsed.Query()
+	for k, v := range params {
+		q[k] = append(q[k], v...)
+	}
+	parsed.RawQuery = q.Encode()
+	return parsed.String()
+}
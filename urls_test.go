@@ -0,0 +1,104 @@
+package discogs
+
+import "testing"
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		kind Kind
+		id   int
+	}{
+		{"https://www.discogs.com/release/8138518", KindRelease, 8138518},
+		{"https://www.discogs.com/release/8138518-Elephant-Riddim", KindRelease, 8138518},
+		{"https://www.discogs.com/SPB-Ska-Jazz-Review-Elephant-Riddim/release/8138518", KindRelease, 8138518},
+		{"http://discogs.com/de/release/8138518?ev=rr", KindRelease, 8138518},
+		{"https://www.discogs.com/master/718441-Eminem-Infinite", KindMaster, 718441},
+		{"https://www.discogs.com/artist/38661-Eminem", KindArtist, 38661},
+		{"https://www.discogs.com/label/1-Planet-E/", KindLabel, 1},
+		{"https://www.discogs.com/sell/item/172723812", KindListing, 172723812},
+		{"https://api.discogs.com/releases/8138518", KindRelease, 8138518},
+		{"https://api.discogs.com/masters/718441", KindMaster, 718441},
+		{"https://api.discogs.com/marketplace/listings/172723812", KindListing, 172723812},
+	}
+	for _, tt := range tests {
+		kind, id, err := ParseURL(tt.url)
+		if err != nil || kind != tt.kind || id != tt.id {
+			t.Errorf("%s: got=%s, %d, %v; want=%s, %d", tt.url, kind, id, err, tt.kind, tt.id)
+		}
+	}
+
+	for _, u := range []string{
+		"https://www.discogs.com/release/",
+		"https://www.discogs.com/artist/38661-Eminem/releases",
+		"https://www.discogs.com/releases/8138518",
+		"https://api.discogs.com/release/8138518",
+		"https://musicbrainz.org/release/8138518",
+		"not a url",
+	} {
+		if _, _, err := ParseURL(u); err != ErrInvalidURL {
+			t.Errorf("%s: err got=%v; want=%s", u, err, ErrInvalidURL)
+		}
+	}
+}
+
+func TestWebURL(t *testing.T) {
+	for _, kind := range []Kind{KindRelease, KindMaster, KindArtist, KindLabel, KindListing} {
+		u := WebURL(kind, 42)
+		if k, id, err := ParseURL(u); err != nil || k != kind || id != 42 {
+			t.Errorf("%s: got=%s, %d, %v; want=%s, 42", u, k, id, err, kind)
+		}
+	}
+	if u := WebURL(KindListing, 172723812); u != "https://www.discogs.com/sell/item/172723812" {
+		t.Errorf("url got=%s; want=https://www.discogs.com/sell/item/172723812", u)
+	}
+	if u := WebURL("track", 1); u != "" {
+		t.Errorf("url got=%s; want empty", u)
+	}
+}
+
+func TestReleaseIDFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		id   int
+		fail bool
+	}{
+		{"https://www.discogs.com/release/8138518", 8138518, false},
+		{"https://www.discogs.com/release/8138518-Elephant-Riddim", 8138518, false},
+		{"https://www.discogs.com/SPB-Ska-Jazz-Review-Elephant-Riddim/release/8138518", 8138518, false},
+		{"http://discogs.com/release/8138518?ev=rr", 8138518, false},
+		{"https://api.discogs.com/releases/8138518", 8138518, false},
+		{"https://www.discogs.com/master/960657", 0, true},
+		{"https://musicbrainz.org/release/8138518", 0, true},
+		{"not a url", 0, true},
+	}
+	for _, tt := range tests {
+		id, err := ReleaseIDFromURL(tt.url)
+		if tt.fail {
+			if err != ErrInvalidURL {
+				t.Errorf("%s: err got=%v; want=%s", tt.url, err, ErrInvalidURL)
+			}
+			continue
+		}
+		if err != nil || id != tt.id {
+			t.Errorf("%s: got=%d, %v; want=%d", tt.url, id, err, tt.id)
+		}
+	}
+}
+
+func TestJoinURL(t *testing.T) {
+	tests := []struct {
+		base string
+		elem []string
+		want string
+	}{
+		{"https://api.discogs.com", []string{"/releases/", "1"}, "https://api.discogs.com/releases/1"},
+		{"https://api.discogs.com/", []string{"users", "a%20b", "wants"}, "https://api.discogs.com/users/a%20b/wants"},
+		{"http://localhost:8080/proxy//", []string{"/masters/", "2", "versions"}, "http://localhost:8080/proxy/masters/2/versions"},
+		{"http://localhost:8080/p?key=1", []string{"database/search"}, "http://localhost:8080/p/database/search?key=1"},
+	}
+	for _, tt := range tests {
+		if got := joinURL(tt.base, tt.elem...); got != tt.want {
+			t.Errorf("%s %v: got=%s; want=%s", tt.base, tt.elem, got, tt.want)
+		}
+	}
+}
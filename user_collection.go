@@ -2,36 +2,68 @@ package discogs
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 )
 
 // CollectionService is an interface to work with collection.
 type CollectionService interface {
 	// Retrieve a list of folders in a user’s collection.
-	// If folder_id is not 0, authentication as the collection owner is required.
-	CollectionFolders(ctx context.Context, username string) (*CollectionFolders, error)
+	// Unless authenticated as the collection owner, only FolderAll is listed.
+	CollectionFolders(ctx context.Context, username string, opts ...RequestOption) (*CollectionFolders, error)
 	// Retrieve a list of items in a folder in a user’s collection.
-	// If folderID is not 0, authentication with token is required.
-	CollectionItemsByFolder(ctx context.Context, username string, folderID int, pagination *Pagination) (*CollectionItems, error)
+	// If folderID is not FolderAll, authentication with token is required.
+	CollectionItemsByFolder(ctx context.Context, username string, folderID int, pagination *Pagination, opts ...RequestOption) (*CollectionItems, error)
 	// Retrieve the user’s collection folders which contain a specified release.
 	// The releaseID must be non-zero.
-	CollectionItemsByRelease(ctx context.Context, username string, releaseID int) (*CollectionItems, error)
+	CollectionItemsByRelease(ctx context.Context, username string, releaseID int, opts ...RequestOption) (*CollectionItems, error)
 	// Retrieve metadata about a folder in a user’s collection.
-	Folder(ctx context.Context, username string, folderID int) (*Folder, error)
+	Folder(ctx context.Context, username string, folderID int, opts ...RequestOption) (*Folder, error)
+	// Change the rating of an instance of a release in the folder folderID of a user’s collection, which can't be
+	// FolderAll. The rating is from 1 to 5, or 0 to clear it.
+	// Authentication as the collection owner is required.
+	// https://www.discogs.com/developers#page:user-collection,header:user-collection-change-rating-of-release
+	RateCollectionInstance(ctx context.Context, username string, folderID, releaseID int, instanceID int64, rating int, opts ...RequestOption) error
 }
 
 type collectionService struct {
 	request requestFunc
+	call    callFunc
 	url     string
 }
 
-func newCollectionService(req requestFunc, url string) CollectionService {
+func newCollectionService(req requestFunc, call callFunc, url string) CollectionService {
 	return &collectionService{
 		request: req,
+		call:    call,
 		url:     url,
 	}
 }
 
+// Folders every collection has. Other folders are created by the user and have IDs above FolderUncategorized.
+const (
+	// FolderAll lists the releases of all folders. It can't be written to: releases are added to and moved into
+	// FolderUncategorized or the folders the user created.
+	FolderAll = 0
+	// FolderUncategorized holds the releases the user didn't file into a folder of their own.
+	FolderUncategorized = 1
+)
+
+// CheckFolderWritable returns ErrInvalidFolderID describing the problem unless releases can be added to or moved into
+// the folder folderID, i.e. unless it's FolderAll or negative.
+func CheckFolderWritable(folderID int) error {
+	switch {
+	case folderID == FolderAll:
+		return fmt.Errorf("%w: %d lists all releases and can't be written to, use FolderUncategorized instead", ErrInvalidFolderID, folderID)
+	case folderID < 0:
+		return fmt.Errorf("%w: %d", ErrInvalidFolderID, folderID)
+	}
+	return nil
+}
+
 // Folder serves folder response from discogs.
 type Folder struct {
 	ID          int    `json:"id"`
@@ -40,12 +72,12 @@ type Folder struct {
 	ResourceURL string `json:"resource_url"`
 }
 
-func (s *collectionService) Folder(ctx context.Context, username string, folderID int) (*Folder, error) {
+func (s *collectionService) Folder(ctx context.Context, username string, folderID int, opts ...RequestOption) (*Folder, error) {
 	if username == "" {
 		return nil, ErrInvalidUsername
 	}
 	var folder *Folder
-	err := s.request(ctx, s.url+"/"+username+"/collection/folders/"+strconv.Itoa(folderID), nil, &folder)
+	err := s.request(ctx, joinURL(s.url, url.PathEscape(username), "collection/folders", strconv.Itoa(folderID)), nil, &folder, opts...)
 	return folder, err
 }
 
@@ -54,12 +86,12 @@ type CollectionFolders struct {
 	Folders []Folder `json:"folders"`
 }
 
-func (s *collectionService) CollectionFolders(ctx context.Context, username string) (*CollectionFolders, error) {
+func (s *collectionService) CollectionFolders(ctx context.Context, username string, opts ...RequestOption) (*CollectionFolders, error) {
 	if username == "" {
 		return nil, ErrInvalidUsername
 	}
 	var collection *CollectionFolders
-	err := s.request(ctx, s.url+"/"+username+"/collection/folders", nil, &collection)
+	err := s.request(ctx, joinURL(s.url, url.PathEscape(username), "collection/folders"), nil, &collection, opts...)
 	return collection, err
 }
 
@@ -67,11 +99,34 @@ func (s *collectionService) CollectionFolders(ctx context.Context, username stri
 type CollectionItemSource struct {
 	ID               int              `json:"id"`
 	BasicInformation BasicInformation `json:"basic_information"`
-	DateAdded        string           `json:"date_added"`
+	DateAdded        Time             `json:"date_added"`
 	FolderID         int              `json:"folder_id,omitempty"`
-	InstanceID       int              `json:"instance_id"`
-	Notes            []Notes          `json:"notes,omitempty"`
-	Rating           int              `json:"rating"`
+	// InstanceID is int64 as instance IDs approach the range of int on 32-bit platforms.
+	InstanceID int64   `json:"instance_id"`
+	Notes      []Notes `json:"notes,omitempty"`
+	Rating     int     `json:"rating"`
+	// Extra holds the fields of the API response the struct doesn't map.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// ListingConditions returns the media and sleeve conditions graded in the item's default notes fields, "Media
+// Condition" and "Sleeve Condition", as marketplace listings take them. It returns ErrInvalidCondition if the media
+// condition is missing or isn't a media grade, or the sleeve condition isn't a grade. The sleeve condition is empty if
+// it isn't graded, which listings accept.
+func (i CollectionItemSource) ListingConditions() (media, sleeve Condition, err error) {
+	v := collectionNote(i.Notes, mediaConditionField)
+	if v == "" {
+		return "", "", fmt.Errorf("%w: no media condition", ErrInvalidCondition)
+	}
+	if media, err = ParseCondition(v); err != nil || media.SleeveOnly() {
+		return "", "", fmt.Errorf("%w: media condition %q", ErrInvalidCondition, v)
+	}
+	if v := collectionNote(i.Notes, sleeveConditionField); v != "" {
+		if sleeve, err = ParseCondition(v); err != nil {
+			return "", "", fmt.Errorf("%w: sleeve condition %q", ErrInvalidCondition, v)
+		}
+	}
+	return media, sleeve, nil
 }
 
 // BasicInformation ...
@@ -99,33 +154,30 @@ type CollectionItems struct {
 
 // valid sort keys
 // https://www.discogs.com/developers#page:user-collection,header:user-collection-collection-items-by-folder
-var validItemsByFolderSort = map[string]struct{}{
-	"":       struct{}{},
-	"label":  struct{}{},
-	"artist": struct{}{},
-	"title":  struct{}{},
-	"catno":  struct{}{},
-	"format": struct{}{},
-	"rating": struct{}{},
-	"added":  struct{}{},
-	"year":   struct{}{},
-}
-
-func (s *collectionService) CollectionItemsByFolder(ctx context.Context, username string, folderID int, pagination *Pagination) (*CollectionItems, error) {
+var validItemsByFolderSort = map[SortKey]struct{}{
+	SortLabel:  struct{}{},
+	SortArtist: struct{}{},
+	SortTitle:  struct{}{},
+	SortCatno:  struct{}{},
+	SortFormat: struct{}{},
+	SortRating: struct{}{},
+	SortAdded:  struct{}{},
+	SortYear:   struct{}{},
+}
+
+func (s *collectionService) CollectionItemsByFolder(ctx context.Context, username string, folderID int, pagination *Pagination, opts ...RequestOption) (*CollectionItems, error) {
 	if username == "" {
 		return nil, ErrInvalidUsername
 	}
-	if pagination != nil {
-		if _, ok := validItemsByFolderSort[pagination.Sort]; !ok {
-			return nil, ErrInvalidSortKey
-		}
+	if err := pagination.validate(validItemsByFolderSort); err != nil {
+		return nil, err
 	}
 	var items *CollectionItems
-	err := s.request(ctx, s.url+"/"+username+"/collection/folders/"+strconv.Itoa(folderID)+"/releases", pagination.params(), &items)
+	err := s.request(ctx, joinURL(s.url, url.PathEscape(username), "collection/folders", strconv.Itoa(folderID), "releases"), pagination.params(), &items, opts...)
 	return items, err
 }
 
-func (s *collectionService) CollectionItemsByRelease(ctx context.Context, username string, releaseID int) (*CollectionItems, error) {
+func (s *collectionService) CollectionItemsByRelease(ctx context.Context, username string, releaseID int, opts ...RequestOption) (*CollectionItems, error) {
 	if username == "" {
 		return nil, ErrInvalidUsername
 	}
@@ -133,6 +185,35 @@ func (s *collectionService) CollectionItemsByRelease(ctx context.Context, userna
 		return nil, ErrInvalidReleaseID
 	}
 	var items *CollectionItems
-	err := s.request(ctx, s.url+"/"+username+"/collection/releases/"+strconv.Itoa(releaseID), nil, &items)
+	err := s.request(ctx, joinURL(s.url, url.PathEscape(username), "collection/releases", strconv.Itoa(releaseID)), nil, &items, opts...)
 	return items, err
 }
+
+func (s *collectionService) RateCollectionInstance(ctx context.Context, username string, folderID, releaseID int, instanceID int64, rating int, opts ...RequestOption) error {
+	if username == "" {
+		return ErrInvalidUsername
+	}
+	if err := CheckFolderWritable(folderID); err != nil {
+		return err
+	}
+	if releaseID <= 0 {
+		return ErrInvalidReleaseID
+	}
+	if err := validateRating(rating); err != nil {
+		return err
+	}
+	path := joinURL(s.url, url.PathEscape(username), "collection/folders", strconv.Itoa(folderID),
+		"releases", strconv.Itoa(releaseID), "instances", strconv.FormatInt(instanceID, 10))
+	body := struct {
+		Rating int `json:"rating"`
+	}{Rating: rating}
+	return s.call(ctx, http.MethodPost, path, nil, body, nil, opts...)
+}
+
+// validateRating returns ErrInvalidRating unless rating is from 1 to 5, or 0 for none.
+func validateRating(rating int) error {
+	if rating < 0 || rating > 5 {
+		return fmt.Errorf("%w: %d is not from 0 to 5", ErrInvalidRating, rating)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package discogs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -114,7 +115,7 @@ func TestCollectionServiceCollectionItemsByFolderError(t *testing.T) {
 	d := initDiscogsClient(t, &Options{URL: ts.URL})
 
 	_, err := d.CollectionItemsByFolder(context.Background(), testUsername, 0, &Pagination{Sort: "invalid"})
-	if err != ErrInvalidSortKey {
+	if !errors.Is(err, ErrInvalidSortKey) {
 		t.Fatalf("err got=%s; want=%s", err, ErrInvalidSortKey)
 	}
 }
@@ -173,3 +174,52 @@ func TestCollectionServiceCollectionItemsByReleaseErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectionItemListingConditions(t *testing.T) {
+	tests := map[string]struct {
+		notes  []Notes
+		media  Condition
+		sleeve Condition
+		err    error
+	}{
+		"graded": {
+			[]Notes{{FieldID: 1, Value: "Very Good Plus (VG+)"}, {FieldID: 2, Value: "Generic"}, {FieldID: 3, Value: "signed"}},
+			ConditionVeryGoodPlus, ConditionGeneric, nil,
+		},
+		"abbreviated": {[]Notes{{FieldID: 1, Value: "NM"}, {FieldID: 2, Value: "VG"}}, ConditionNearMint, ConditionVeryGood, nil},
+		"no sleeve":   {[]Notes{{FieldID: 1, Value: "Mint (M)"}}, ConditionMint, "", nil},
+		"no media":    {[]Notes{{FieldID: 2, Value: "Mint (M)"}}, "", "", ErrInvalidCondition},
+		"sleeve only": {[]Notes{{FieldID: 1, Value: "No Cover"}}, "", "", ErrInvalidCondition},
+		"unknown":     {[]Notes{{FieldID: 1, Value: "Mint (M)"}, {FieldID: 2, Value: "Shiny"}}, "", "", ErrInvalidCondition},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			media, sleeve, err := CollectionItemSource{Notes: tt.notes}.ListingConditions()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err got=%v; want=%v", err, tt.err)
+			}
+			if media != tt.media || sleeve != tt.sleeve {
+				t.Errorf("conditions got=%s, %s; want=%s, %s", media, sleeve, tt.media, tt.sleeve)
+			}
+		})
+	}
+}
+
+func TestCheckFolderWritable(t *testing.T) {
+	tests := map[string]struct {
+		folderID int
+		want     error
+	}{
+		"all":           {folderID: FolderAll, want: ErrInvalidFolderID},
+		"negative":      {folderID: -1, want: ErrInvalidFolderID},
+		"uncategorized": {folderID: FolderUncategorized},
+		"user folder":   {folderID: 2},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := CheckFolderWritable(tt.folderID); !errors.Is(err, tt.want) {
+				t.Errorf("err got=%v; want=%v", err, tt.want)
+			}
+		})
+	}
+}
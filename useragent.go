@@ -0,0 +1,51 @@
+package discogs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// genericUserAgents are the product names of the default user-agents of HTTP libraries and tools, which Discogs
+// throttles as they don't identify the application.
+var genericUserAgents = []string{
+	"go-http-client", "python-requests", "python-urllib", "aiohttp", "curl", "wget", "okhttp", "axios", "node-fetch",
+	"java", "apache-httpclient", "libwww-perl", "postmanruntime", "ruby", "guzzlehttp", "mozilla",
+}
+
+// UserAgent returns a user-agent identifying the application as Discogs asks for, e.g.
+// "MyApp/1.0 +https://example.com". The version and URL are optional.
+// https://www.discogs.com/developers#page:home,header:home-general-information
+func UserAgent(name, version, url string) string {
+	ua := strings.Join(strings.Fields(name), "")
+	if version = strings.TrimSpace(version); version != "" {
+		ua += "/" + version
+	}
+	if url = strings.TrimSpace(url); url != "" {
+		ua += " +" + url
+	}
+	return ua
+}
+
+// CheckUserAgent reports whether the user-agent identifies the application as Discogs asks for, returning
+// ErrInvalidUserAgent describing the problem if it's empty, the default of an HTTP library or tool, or lacks a
+// version, e.g. "MyApp" instead of "MyApp/1.0".
+func CheckUserAgent(ua string) error {
+	ua = strings.TrimSpace(ua)
+	if ua == "" {
+		return ErrInvalidUserAgent
+	}
+	product := strings.ToLower(strings.Fields(ua)[0])
+	name := product
+	if i := strings.IndexByte(product, '/'); i >= 0 {
+		name = product[:i]
+	}
+	for _, g := range genericUserAgents {
+		if name == g {
+			return fmt.Errorf("%w: %q is a generic user-agent", ErrInvalidUserAgent, ua)
+		}
+	}
+	if i := strings.IndexByte(product, '/'); i <= 0 || i == len(product)-1 {
+		return fmt.Errorf("%w: %q lacks a version", ErrInvalidUserAgent, ua)
+	}
+	return nil
+}
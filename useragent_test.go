@@ -0,0 +1,56 @@
+package discogs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	tests := map[string]struct {
+		name, version, url string
+		want               string
+	}{
+		"full":       {name: "MyApp", version: "1.0", url: "https://example.com", want: "MyApp/1.0 +https://example.com"},
+		"no url":     {name: "MyApp", version: "1.0", want: "MyApp/1.0"},
+		"no version": {name: "MyApp", url: "https://example.com", want: "MyApp +https://example.com"},
+		"spaces":     {name: " My App ", version: " 1.0 ", want: "MyApp/1.0"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := UserAgent(tt.name, tt.version, tt.url); got != tt.want {
+				t.Errorf("got=%q; want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckUserAgent(t *testing.T) {
+	tests := map[string]struct {
+		ua  string
+		err error
+	}{
+		"compliant":       {ua: "MyApp/1.0 +https://example.com", err: nil},
+		"no url":          {ua: testUserAgent, err: nil},
+		"empty":           {ua: " ", err: ErrInvalidUserAgent},
+		"go default":      {ua: "Go-http-client/1.1", err: ErrInvalidUserAgent},
+		"python requests": {ua: "python-requests/2.31.0", err: ErrInvalidUserAgent},
+		"curl":            {ua: "curl/8.4.0", err: ErrInvalidUserAgent},
+		"browser":         {ua: "Mozilla/5.0 (X11; Linux x86_64)", err: ErrInvalidUserAgent},
+		"java default":    {ua: "Java/17.0.2", err: ErrInvalidUserAgent},
+		"tool prefix":     {ua: "RubyRipper/1.0", err: nil},
+		"curl prefix":     {ua: "Curlew/2.0", err: nil},
+		"java prefix":     {ua: "Javelin/1.0", err: nil},
+		"bare tool":       {ua: "wget", err: ErrInvalidUserAgent},
+		"no version":      {ua: "MyApp +https://example.com", err: ErrInvalidUserAgent},
+		"empty version":   {ua: "MyApp/", err: ErrInvalidUserAgent},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := CheckUserAgent(tt.ua); !errors.Is(err, tt.err) {
+				t.Errorf("err got=%v; want=%v", err, tt.err)
+			}
+		})
+	}
+}
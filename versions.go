@@ -0,0 +1,122 @@
+package discogs
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// VersionPreferences describes the preferred version of a master release. Every field is optional.
+type VersionPreferences struct {
+	// Countries lists the preferred release countries, most preferred first.
+	Countries []string
+	// Formats lists the preferred formats, most preferred first, e.g. "Vinyl" or "CD". A format matches a version if
+	// it's one of the comma separated parts of Version.Format, ignoring case.
+	Formats []string
+	// MinYear and MaxYear restrict the versions to those released within the years, inclusive.
+	MinYear int
+	MaxYear int
+}
+
+// versionRank is the rank of a version according to the preferences; lower ranks are preferred.
+type versionRank struct {
+	country int
+	format  int
+	year    int
+}
+
+func (r versionRank) less(o versionRank) bool {
+	if r.country != o.country {
+		return r.country < o.country
+	}
+	if r.format != o.format {
+		return r.format < o.format
+	}
+	return r.year < o.year
+}
+
+// rank returns the rank of v and whether it is acceptable at all.
+func (p VersionPreferences) rank(v Version) (versionRank, bool) {
+	if !v.Status.Accepted() {
+		return versionRank{}, false
+	}
+
+	year, err := strconv.Atoi(v.Released)
+	if err != nil && len(v.Released) >= 4 {
+		// released dates may be formatted as YYYY-MM-DD
+		year, err = strconv.Atoi(v.Released[:4])
+	}
+	if err != nil || year == 0 {
+		if p.MinYear > 0 || p.MaxYear > 0 {
+			return versionRank{}, false
+		}
+		year = math.MaxInt
+	}
+	if (p.MinYear > 0 && year < p.MinYear) || (p.MaxYear > 0 && year > p.MaxYear) {
+		return versionRank{}, false
+	}
+
+	r := versionRank{country: len(p.Countries), format: len(p.Formats), year: year}
+	for i, country := range p.Countries {
+		if strings.EqualFold(country, v.Country) {
+			r.country = i
+			break
+		}
+	}
+	for i, format := range p.Formats {
+		if hasFormat(v.Format, format) {
+			r.format = i
+			break
+		}
+	}
+	return r, true
+}
+
+// hasFormat reports whether format is one of the comma separated parts of formats.
+func hasFormat(formats, format string) bool {
+	for _, f := range strings.Split(formats, ",") {
+		if strings.EqualFold(strings.TrimSpace(f), format) {
+			return true
+		}
+	}
+	return false
+}
+
+// BestVersion returns the version of the master release which best matches the preferences, preferring the
+// country over the format and earlier releases over later ones. Versions which haven't been accepted into the
+// database or were released outside the requested years are never selected. All pages of versions are fetched.
+func BestVersion(ctx context.Context, s DatabaseService, masterID int, prefs VersionPreferences, opts ...RequestOption) (*Version, error) {
+	var best *Version
+	var bestRank versionRank
+
+	it := MasterVersionsIter(ctx, s, masterID, nil, opts...)
+	for it.Next() {
+		v := it.Item()
+		r, ok := prefs.rank(v)
+		if !ok {
+			continue
+		}
+		if best == nil || r.less(bestRank) {
+			best, bestRank = &v, r
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	if best == nil {
+		return nil, ErrNoMatchingVersion
+	}
+	return best, nil
+}
+
+// BestRelease returns the release of the version of the master release which best matches the preferences, as
+// selected by BestVersion.
+func BestRelease(ctx context.Context, s DatabaseService, masterID int, prefs VersionPreferences, opts ...RequestOption) (*Release, error) {
+	v, err := BestVersion(ctx, s, masterID, prefs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.Release(ctx, v.ID, opts...)
+}
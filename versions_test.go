@@ -0,0 +1,85 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func VersionsServer(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/masters/1/versions":
+		versions := MasterVersions{
+			Pagination: Page{Page: 1, Pages: 1},
+			Versions: []Version{
+				{ID: 1, Country: "US", Format: "CD, Album", Released: "1996", Status: "Accepted"},
+				{ID: 2, Country: "UK", Format: "Vinyl, LP, Album", Released: "1997", Status: "Accepted"},
+				{ID: 3, Country: "UK", Format: "CD, Album", Released: "1996", Status: "Accepted"},
+				{ID: 4, Country: "UK", Format: "Vinyl, LP, Album, Reissue", Released: "2015-04-18", Status: "Accepted"},
+				{ID: 5, Country: "Germany", Format: "Vinyl, LP", Released: "1995", Status: "Draft"},
+				{ID: 6, Country: "Japan", Format: "CD", Released: "", Status: "Accepted"},
+			},
+		}
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(versions); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	case "/releases/2":
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(Release{ID: 2, Title: "Infinite"}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestBestVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(VersionsServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	tests := map[string]struct {
+		prefs VersionPreferences
+		want  int
+		err   error
+	}{
+		"no preferences":     {VersionPreferences{}, 1, nil},
+		"country":            {VersionPreferences{Countries: []string{"uk"}}, 3, nil},
+		"country and format": {VersionPreferences{Countries: []string{"UK"}, Formats: []string{"vinyl"}}, 2, nil},
+		"drafts are skipped": {VersionPreferences{Countries: []string{"Germany", "Japan"}, Formats: []string{"LP"}}, 6, nil},
+		"year range":         {VersionPreferences{Formats: []string{"Vinyl"}, MinYear: 2000, MaxYear: 2020}, 4, nil},
+		"no match":           {VersionPreferences{MinYear: 2020}, 0, ErrNoMatchingVersion},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			v, err := BestVersion(context.Background(), d, 1, tt.prefs)
+			if err != tt.err {
+				t.Fatalf("err got=%v; want=%v", err, tt.err)
+			}
+			if err == nil && v.ID != tt.want {
+				t.Errorf("version got=%d; want=%d", v.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestRelease(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(VersionsServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	release, err := BestRelease(context.Background(), d, 1, VersionPreferences{Formats: []string{"Vinyl"}, MaxYear: 2000})
+	if err != nil {
+		t.Fatalf("failed to get best release: %s", err)
+	}
+	if release.ID != 2 {
+		t.Errorf("release got=%d; want=2", release.ID)
+	}
+}
@@ -0,0 +1,130 @@
+package discogs
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// WantlistService is an interface to work with wantlists.
+type WantlistService interface {
+	// Retrieve the list of releases in a user’s wantlist.
+	// Authentication as the wantlist owner is required if the wantlist is private.
+	// https://www.discogs.com/developers#page:user-wantlist,header:user-wantlist-wantlist
+	Wantlist(ctx context.Context, username string, pagination *Pagination, opts ...RequestOption) (*Wantlist, error)
+	// Add a release to a user’s wantlist with notes and a rating from 1 to 5, both optional.
+	// Authentication as the wantlist owner is required.
+	// https://www.discogs.com/developers#page:user-wantlist,header:user-wantlist-add-to-wantlist-put
+	AddWant(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...RequestOption) (*Want, error)
+	// Set the notes and rating of a release in a user’s wantlist. Empty notes or a zero rating clear them.
+	// Authentication as the wantlist owner is required.
+	// https://www.discogs.com/developers#page:user-wantlist,header:user-wantlist-add-to-wantlist-post
+	EditWant(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...RequestOption) (*Want, error)
+	// Remove a release from a user’s wantlist.
+	// Authentication as the wantlist owner is required.
+	// https://www.discogs.com/developers#page:user-wantlist,header:user-wantlist-add-to-wantlist-delete
+	DeleteWant(ctx context.Context, username string, releaseID int, opts ...RequestOption) error
+}
+
+type wantlistService struct {
+	request requestFunc
+	call    callFunc
+	url     string
+}
+
+func newWantlistService(req requestFunc, call callFunc, url string) WantlistService {
+	return &wantlistService{
+		request: req,
+		call:    call,
+		url:     url,
+	}
+}
+
+// Want is a release in a user's wantlist.
+type Want struct {
+	ID               int              `json:"id"`
+	BasicInformation BasicInformation `json:"basic_information"`
+	DateAdded        Time             `json:"date_added"`
+	Notes            string           `json:"notes,omitempty"`
+	Rating           int              `json:"rating"`
+	ResourceURL      string           `json:"resource_url"`
+}
+
+// Wantlist is a list of releases in a user's wantlist.
+type Wantlist struct {
+	Pagination Page   `json:"pagination"`
+	Wants      []Want `json:"wants"`
+}
+
+// valid sort keys, which match those of collection folders
+var validWantlistSort = map[SortKey]struct{}{
+	SortLabel:  struct{}{},
+	SortArtist: struct{}{},
+	SortTitle:  struct{}{},
+	SortCatno:  struct{}{},
+	SortFormat: struct{}{},
+	SortRating: struct{}{},
+	SortAdded:  struct{}{},
+	SortYear:   struct{}{},
+}
+
+func (s *wantlistService) Wantlist(ctx context.Context, username string, pagination *Pagination, opts ...RequestOption) (*Wantlist, error) {
+	if username == "" {
+		return nil, ErrInvalidUsername
+	}
+	if err := pagination.validate(validWantlistSort); err != nil {
+		return nil, err
+	}
+	var wantlist *Wantlist
+	err := s.request(ctx, joinURL(s.url, url.PathEscape(username), "wants"), pagination.params(), &wantlist, opts...)
+	return wantlist, err
+}
+
+func (s *wantlistService) AddWant(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...RequestOption) (*Want, error) {
+	if err := validateWant(username, releaseID, rating); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	if notes != "" {
+		params.Set("notes", notes)
+	}
+	if rating != 0 {
+		params.Set("rating", strconv.Itoa(rating))
+	}
+	var want *Want
+	err := s.call(ctx, http.MethodPut, s.wantURL(username, releaseID), params, nil, &want, opts...)
+	return want, err
+}
+
+func (s *wantlistService) EditWant(ctx context.Context, username string, releaseID int, notes string, rating int, opts ...RequestOption) (*Want, error) {
+	if err := validateWant(username, releaseID, rating); err != nil {
+		return nil, err
+	}
+	params := url.Values{"notes": {notes}, "rating": {strconv.Itoa(rating)}}
+	var want *Want
+	err := s.call(ctx, http.MethodPost, s.wantURL(username, releaseID), params, nil, &want, opts...)
+	return want, err
+}
+
+func (s *wantlistService) DeleteWant(ctx context.Context, username string, releaseID int, opts ...RequestOption) error {
+	if err := validateWant(username, releaseID, 0); err != nil {
+		return err
+	}
+	return s.call(ctx, http.MethodDelete, s.wantURL(username, releaseID), nil, nil, nil, opts...)
+}
+
+func (s *wantlistService) wantURL(username string, releaseID int) string {
+	return joinURL(s.url, url.PathEscape(username), "wants", strconv.Itoa(releaseID))
+}
+
+// validateWant validates the arguments of a wantlist change.
+func validateWant(username string, releaseID int, rating int) error {
+	if username == "" {
+		return ErrInvalidUsername
+	}
+	if releaseID <= 0 {
+		return ErrInvalidReleaseID
+	}
+	return validateRating(rating)
+}
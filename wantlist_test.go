@@ -0,0 +1,144 @@
+package discogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func WantlistServer(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/users/"+testUsername+"/wants/1867708" {
+		wantServer(w, r)
+		return
+	}
+	if r.Method != "GET" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/users/" + testUsername + "/wants":
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, wantlistJson); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// wantServer adds, edits and deletes a want, echoing the notes and rating it's sent.
+func wantServer(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut, http.MethodPost:
+		status := http.StatusOK
+		if r.Method == http.MethodPut {
+			status = http.StatusCreated
+		}
+		w.WriteHeader(status)
+		rating, _ := strconv.Atoi(r.URL.Query().Get("rating"))
+		if err := json.NewEncoder(w).Encode(Want{ID: 1867708, Notes: r.URL.Query().Get("notes"), Rating: rating}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestWantlistServiceWantlist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(WantlistServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	wantlist, err := d.Wantlist(context.Background(), testUsername, nil)
+	if err != nil {
+		t.Fatalf("failed to get wantlist: %s", err)
+	}
+
+	json, err := json.Marshal(wantlist)
+	if err != nil {
+		t.Fatalf("failed to marshal wantlist: %s", err)
+	}
+
+	compareJson(t, string(json), wantlistJson)
+}
+
+func TestWantlistServiceWantlistError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(WantlistServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+
+	if _, err := d.Wantlist(context.Background(), "", nil); err != ErrInvalidUsername {
+		t.Fatalf("err got=%v; want=%s", err, ErrInvalidUsername)
+	}
+}
+
+func TestWantlistServiceEdits(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(WantlistServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	want, err := d.AddWant(ctx, testUsername, 1867708, "Original pressing only", 4)
+	if err != nil {
+		t.Fatalf("failed to add want: %s", err)
+	}
+	if want.Notes != "Original pressing only" || want.Rating != 4 {
+		t.Errorf("want got=%+v; want notes and rating 4", want)
+	}
+
+	want, err = d.EditWant(ctx, testUsername, 1867708, "", 5)
+	if err != nil {
+		t.Fatalf("failed to edit want: %s", err)
+	}
+	if want.Notes != "" || want.Rating != 5 {
+		t.Errorf("want got=%+v; want no notes and rating 5", want)
+	}
+
+	if err := d.DeleteWant(ctx, testUsername, 1867708); err != nil {
+		t.Errorf("failed to delete want: %s", err)
+	}
+}
+
+func TestWantlistServiceEditsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(WantlistServer))
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		username  string
+		releaseID int
+		rating    int
+		want      error
+	}{
+		"username":   {releaseID: 1867708, want: ErrInvalidUsername},
+		"release id": {username: testUsername, want: ErrInvalidReleaseID},
+		"rating":     {username: testUsername, releaseID: 1867708, rating: 6, want: ErrInvalidRating},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := d.AddWant(ctx, tt.username, tt.releaseID, "", tt.rating); !errors.Is(err, tt.want) {
+				t.Errorf("add err got=%v; want=%s", err, tt.want)
+			}
+			if _, err := d.EditWant(ctx, tt.username, tt.releaseID, "", tt.rating); !errors.Is(err, tt.want) {
+				t.Errorf("edit err got=%v; want=%s", err, tt.want)
+			}
+		})
+	}
+	if err := d.DeleteWant(ctx, testUsername, 0); err != ErrInvalidReleaseID {
+		t.Errorf("delete err got=%v; want=%s", err, ErrInvalidReleaseID)
+	}
+}
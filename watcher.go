@@ -0,0 +1,249 @@
+package discogs
+
+import (
+	"context"
+	"time"
+)
+
+// WatchTarget is a list of a user's releases a Watcher polls.
+type WatchTarget int
+
+// Targets of a Watcher.
+const (
+	WatchCollection WatchTarget = iota
+	WatchWantlist
+)
+
+func (t WatchTarget) String() string {
+	switch t {
+	case WatchCollection:
+		return "collection"
+	case WatchWantlist:
+		return "wantlist"
+	default:
+		return "unknown"
+	}
+}
+
+// ChangeType is the kind of change a ChangeEvent reports.
+type ChangeType int
+
+// Kinds of changes.
+const (
+	// ItemAdded reports a release added to the collection or wantlist.
+	ItemAdded ChangeType = iota
+	// ItemRemoved reports a release removed from the collection or wantlist.
+	ItemRemoved
+	// RatingChanged reports a new rating of a release in the collection or wantlist.
+	RatingChanged
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ItemAdded:
+		return "added"
+	case ItemRemoved:
+		return "removed"
+	case RatingChanged:
+		return "rating changed"
+	default:
+		return "unknown"
+	}
+}
+
+// ChangeEvent is a change to a user's collection or wantlist detected by a Watcher.
+type ChangeEvent struct {
+	Type   ChangeType
+	Target WatchTarget
+	// ReleaseID is the ID of the release added, removed or rated.
+	ReleaseID int
+	// InstanceID identifies the copy of the release in the collection, zero for the wantlist.
+	InstanceID int64
+	// Info describes the release as listed when the change was detected, or last listed if it was removed.
+	Info BasicInformation
+	// Rating is the rating of the release and PreviousRating the one before a RatingChanged event.
+	Rating         int
+	PreviousRating int
+}
+
+// WatcherConfig configures a Watcher. Zero values select the defaults.
+type WatcherConfig struct {
+	// Username is the user whose collection and wantlist to watch (required).
+	Username string
+	// Interval is the time between polls (default 5 minutes).
+	Interval time.Duration
+	// Targets are the lists to watch (default both the collection and the wantlist).
+	Targets []WatchTarget
+	// FolderID is the collection folder to watch (default FolderAll).
+	FolderID int
+	// OnError is called with the errors of polls (optional). Failed polls don't emit events, the next poll compares
+	// the lists with the last ones listed successfully.
+	OnError func(err error)
+}
+
+// watchedItem is a release listed in a watched list.
+type watchedItem struct {
+	releaseID  int
+	instanceID int64
+	info       BasicInformation
+	rating     int
+}
+
+// Watcher polls a user's collection and wantlist and emits the changes it detects, as Discogs offers no webhooks.
+// Every poll lists the watched lists sorted by date added, newest first, and compares them with the previous poll.
+// The first poll only records the lists.
+type Watcher struct {
+	d      Discogs
+	cfg    WatcherConfig
+	events chan ChangeEvent
+
+	// lists holds the items of the watched lists by target, keyed by instance ID for the collection and by release
+	// ID for the wantlist; nil until the first successful poll.
+	lists map[WatchTarget]map[int64]watchedItem
+	order map[WatchTarget][]int64
+}
+
+// NewWatcher returns a Watcher polling with d, or ErrInvalidUsername if no username is configured.
+func NewWatcher(d Discogs, cfg WatcherConfig) (*Watcher, error) {
+	if cfg.Username == "" {
+		return nil, ErrInvalidUsername
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Minute
+	}
+	if len(cfg.Targets) == 0 {
+		cfg.Targets = []WatchTarget{WatchCollection, WatchWantlist}
+	}
+	return &Watcher{
+		d:      d,
+		cfg:    cfg,
+		events: make(chan ChangeEvent),
+		lists:  make(map[WatchTarget]map[int64]watchedItem),
+		order:  make(map[WatchTarget][]int64),
+	}, nil
+}
+
+// Events returns the channel Run emits the detected changes on, which is closed when Run returns.
+func (w *Watcher) Events() <-chan ChangeEvent {
+	return w.events
+}
+
+// Run polls the watched lists at the configured interval, starting immediately, and emits the changes on the Events
+// channel until ctx is done. Errors of polls are reported to OnError and don't stop the watcher. Run returns the
+// error of ctx and must be called only once.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	t := time.NewTicker(w.cfg.Interval)
+	defer t.Stop()
+
+	for {
+		events, err := w.Poll(ctx)
+		if err != nil && ctx.Err() == nil && w.cfg.OnError != nil {
+			w.cfg.OnError(err)
+		}
+		for _, e := range events {
+			select {
+			case w.events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll lists the watched lists once and returns the changes since the previous poll, for callers driving the polling
+// themselves instead of calling Run. Added items are returned newest first, followed by removed and rated ones.
+func (w *Watcher) Poll(ctx context.Context) ([]ChangeEvent, error) {
+	var events []ChangeEvent
+	for _, target := range w.cfg.Targets {
+		items, err := w.list(ctx, target)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, w.update(target, items)...)
+	}
+	return events, nil
+}
+
+// list returns the items of a watched list, newest first.
+func (w *Watcher) list(ctx context.Context, target WatchTarget) ([]watchedItem, error) {
+	pagination := &Pagination{Sort: SortAdded, SortOrder: SortDesc, PerPage: 100}
+	var items []watchedItem
+	switch target {
+	case WatchCollection:
+		collection, err := AllCollectionItemsByFolder(ctx, w.d, w.cfg.Username, w.cfg.FolderID, pagination, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range collection {
+			items = append(items, watchedItem{releaseID: item.ID, instanceID: item.InstanceID, info: item.BasicInformation, rating: item.Rating})
+		}
+	case WatchWantlist:
+		wants, err := AllWantlist(ctx, w.d, w.cfg.Username, pagination, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, want := range wants {
+			items = append(items, watchedItem{releaseID: want.ID, info: want.BasicInformation, rating: want.Rating})
+		}
+	}
+	return items, nil
+}
+
+// update records the items of a watched list and returns the changes since they were last recorded.
+func (w *Watcher) update(target WatchTarget, items []watchedItem) []ChangeEvent {
+	key := func(item watchedItem) int64 {
+		if target == WatchCollection {
+			return item.instanceID
+		}
+		return int64(item.releaseID)
+	}
+	event := func(t ChangeType, item watchedItem) ChangeEvent {
+		return ChangeEvent{Type: t, Target: target, ReleaseID: item.releaseID, InstanceID: item.instanceID, Info: item.info, Rating: item.rating}
+	}
+
+	previous, watched := w.lists[target]
+	current := make(map[int64]watchedItem, len(items))
+	order := make([]int64, 0, len(items))
+	var added, rated []ChangeEvent
+	for _, item := range items {
+		k := key(item)
+		if _, ok := current[k]; ok {
+			continue
+		}
+		current[k] = item
+		order = append(order, k)
+
+		old, ok := previous[k]
+		switch {
+		case !ok:
+			added = append(added, event(ItemAdded, item))
+		case old.rating != item.rating:
+			e := event(RatingChanged, item)
+			e.PreviousRating = old.rating
+			rated = append(rated, e)
+		}
+	}
+
+	var removed []ChangeEvent
+	for _, k := range w.order[target] {
+		if _, ok := current[k]; !ok {
+			removed = append(removed, event(ItemRemoved, previous[k]))
+		}
+	}
+
+	w.lists[target] = current
+	w.order[target] = order
+	if !watched {
+		return nil
+	}
+	events := append(added, removed...)
+	return append(events, rated...)
+}
@@ -0,0 +1,172 @@
+package discogs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// watchedServer serves a collection and a wantlist which tests can change between polls.
+type watchedServer struct {
+	mu         sync.Mutex
+	collection []string
+	wants      []string
+	requests   int
+}
+
+func (s *watchedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if q := r.URL.Query(); q.Get("sort") != "added" || q.Get("sort_order") != "desc" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.URL.Path {
+	case "/users/test_user/collection/folders/0/releases":
+		fmt.Fprintf(w, `{"pagination": {"page": 1, "pages": 1, "items": %d}, "releases": [%s]}`, len(s.collection), strings.Join(s.collection, ", "))
+	case "/users/test_user/wants":
+		fmt.Fprintf(w, `{"pagination": {"page": 1, "pages": 1, "items": %d}, "wants": [%s]}`, len(s.wants), strings.Join(s.wants, ", "))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *watchedServer) set(collection, wants []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.collection, s.wants = collection, wants
+}
+
+func (s *watchedServer) served() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func watchedItemJSON(releaseID, instanceID, rating int) string {
+	return fmt.Sprintf(`{"id": %d, "instance_id": %d, "rating": %d, "basic_information": {"id": %d, "title": "Release %d"}}`,
+		releaseID, instanceID, rating, releaseID, releaseID)
+}
+
+func TestWatcherPoll(t *testing.T) {
+	s := &watchedServer{}
+	s.set(
+		[]string{watchedItemJSON(2, 102, 0), watchedItemJSON(1, 101, 3)},
+		[]string{watchedItemJSON(10, 0, 0)},
+	)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	w, err := NewWatcher(d, WatcherConfig{Username: testUsername})
+	if err != nil {
+		t.Fatalf("failed to create watcher: %s", err)
+	}
+	ctx := context.Background()
+
+	events, err := w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("failed to poll: %s", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events of first poll got=%+v; want none", events)
+	}
+
+	// release 3 and a second copy of release 1 are added, release 2 is removed, release 1 is rated and release 10
+	// leaves the wantlist for release 11
+	s.set(
+		[]string{watchedItemJSON(1, 103, 0), watchedItemJSON(3, 104, 0), watchedItemJSON(1, 101, 5)},
+		[]string{watchedItemJSON(11, 0, 0)},
+	)
+	events, err = w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("failed to poll: %s", err)
+	}
+
+	type change struct {
+		Type       ChangeType
+		Target     WatchTarget
+		ReleaseID  int
+		InstanceID int64
+		Rating     int
+		Previous   int
+	}
+	var got []change
+	for _, e := range events {
+		got = append(got, change{e.Type, e.Target, e.ReleaseID, e.InstanceID, e.Rating, e.PreviousRating})
+	}
+	want := []change{
+		{ItemAdded, WatchCollection, 1, 103, 0, 0},
+		{ItemAdded, WatchCollection, 3, 104, 0, 0},
+		{ItemRemoved, WatchCollection, 2, 102, 0, 0},
+		{RatingChanged, WatchCollection, 1, 101, 5, 3},
+		{ItemAdded, WatchWantlist, 11, 0, 0, 0},
+		{ItemRemoved, WatchWantlist, 10, 0, 0, 0},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("events got=%+v; want=%+v", got, want)
+	}
+	if events[2].Info.Title != "Release 2" {
+		t.Errorf("removed title got=%s; want=Release 2", events[2].Info.Title)
+	}
+
+	if _, err := NewWatcher(d, WatcherConfig{}); err != ErrInvalidUsername {
+		t.Errorf("err got=%v; want=%s", err, ErrInvalidUsername)
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	s := &watchedServer{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	d := initDiscogsClient(t, &Options{URL: ts.URL})
+	w, err := NewWatcher(d, WatcherConfig{
+		Username: testUsername,
+		Interval: 10 * time.Millisecond,
+		Targets:  []WatchTarget{WatchWantlist},
+	})
+	if err != nil {
+		t.Fatalf("failed to create watcher: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+
+	// the first poll only records the empty wantlist
+	for s.served() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	s.set(nil, []string{watchedItemJSON(10, 0, 0)})
+
+	select {
+	case e := <-w.Events():
+		if e.Type != ItemAdded || e.Target != WatchWantlist || e.ReleaseID != 10 {
+			t.Errorf("event got=%+v; want release 10 added to the wantlist", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event emitted")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("err got=%v; want=%s", err, context.Canceled)
+	}
+	if _, ok := <-w.Events(); ok {
+		t.Error("events channel not closed")
+	}
+}